@@ -0,0 +1,2 @@
+// Package check verifies the references between the resources of a manifest bundle.
+package check
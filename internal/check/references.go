@@ -0,0 +1,499 @@
+package check
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/lint"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Finding represents a single broken or dangling reference.
+type Finding struct {
+	File     string        `json:"file" yaml:"file"`
+	Document int           `json:"document" yaml:"document"`
+	Resource string        `json:"resource" yaml:"resource"`
+	Path     string        `json:"path" yaml:"path"`
+	Check    string        `json:"check" yaml:"check"`
+	Severity lint.Severity `json:"severity" yaml:"severity"`
+	Message  string        `json:"message" yaml:"message"`
+}
+
+// References checks the references between resources of a bundle.
+type References struct {
+	Namespace string   // Namespace is attributed to resources that don't declare one.
+	External  []string // External are resources that exist outside the bundle - either a kind (e.g. "secret") or "<kind>/<name>".
+
+	documents []*manifests.Document
+	index     map[string]*manifests.Document
+	findings  []Finding
+}
+
+// Check evaluates every reference of the documents, returning the findings in document order.
+func (r *References) Check(documents ...*manifests.Document) []Finding {
+	r.documents, r.findings = nil, nil
+	r.index = make(map[string]*manifests.Document)
+
+	for _, document := range documents {
+		if document.Empty() || document.Kustomization() {
+			continue
+		}
+
+		r.documents = append(r.documents, document)
+		r.index[r.key(document.Kind(), r.namespace(document), document.Name())] = document
+	}
+
+	for _, document := range r.documents {
+		switch document.Kind() {
+		case "Service":
+			r.service(document)
+		case "HorizontalPodAutoscaler":
+			r.autoscaler(document)
+		case "PodDisruptionBudget":
+			r.budget(document)
+		case "Ingress":
+			r.ingress(document)
+		case "RoleBinding", "ClusterRoleBinding":
+			r.binding(document)
+		}
+
+		if document.Workload() {
+			r.workload(document)
+		}
+	}
+
+	return r.findings
+}
+
+// namespace returns the document's namespace, or the fallback if it doesn't declare one.
+func (r *References) namespace(document *manifests.Document) string {
+	if namespace := document.Namespace(); namespace != "" {
+		return namespace
+	}
+
+	return r.Namespace
+}
+
+// key identifies a resource by its kind, namespace, and name.
+func (r *References) key(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// lookup returns the bundle's resource of kind, namespace, and name, or nil.
+func (r *References) lookup(kind, namespace, name string) *manifests.Document {
+	return r.index[r.key(kind, namespace, name)]
+}
+
+// external reports whether a resource of kind and name was declared to exist outside the bundle.
+func (r *References) external(kind, name string) bool {
+	for _, reference := range r.External {
+		k, n, _ := strings.Cut(reference, "/")
+		if strings.EqualFold(k, kind) && (n == "" || n == name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// report records a finding against document.
+func (r *References) report(document *manifests.Document, path manifests.Path, check string, severity lint.Severity, message string, arguments ...interface{}) {
+	r.findings = append(r.findings, Finding{
+		File:     document.File,
+		Document: document.Index,
+		Resource: document.String(),
+		Path:     path.String(),
+		Check:    check,
+		Severity: severity,
+		Message:  fmt.Sprintf(message, arguments...),
+	})
+}
+
+// labels returns the pod labels of a workload document - its pod template's labels, or a Pod's own.
+func labels(document *manifests.Document) map[string]string {
+	if document.Kind() == "Pod" {
+		return document.Labels()
+	}
+
+	template, _ := document.PodTemplate()
+
+	return manifests.Map(manifests.Value(template, "labels"))
+}
+
+// format renders labels as a sorted, comma-separated "key=value" list.
+func format(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// selected returns the workloads in namespace whose pods match selector.
+func (r *References) selected(namespace string, selector map[string]string) []*manifests.Document {
+	var workloads []*manifests.Document
+	for _, document := range r.documents {
+		if document.Workload() && r.namespace(document) == namespace && manifests.Matches(selector, labels(document)) {
+			workloads = append(workloads, document)
+		}
+	}
+
+	return workloads
+}
+
+// closest returns the workload in namespace sharing the most selector labels, along with the selector labels it
+// doesn't match - nil if no workload shares any.
+func (r *References) closest(namespace string, selector map[string]string) (*manifests.Document, []string) {
+	var (
+		best       *manifests.Document
+		score      int
+		mismatches []string
+	)
+
+	for _, document := range r.documents {
+		if !(document.Workload()) || r.namespace(document) != namespace {
+			continue
+		}
+
+		pods := labels(document)
+
+		var shared int
+		var differences []string
+		for key, value := range selector {
+			if actual, found := pods[key]; !(found) {
+				differences = append(differences, fmt.Sprintf("%s is missing", key))
+			} else if actual != value {
+				differences = append(differences, fmt.Sprintf("%s=%s", key, actual))
+			} else {
+				shared++
+			}
+		}
+
+		if shared > score {
+			sort.Strings(differences)
+
+			best, score, mismatches = document, shared, differences
+		}
+	}
+
+	return best, mismatches
+}
+
+// service verifies a Service's selector matches a workload's pods, and that its target ports are declared by them.
+func (r *References) service(document *manifests.Document) {
+	if manifests.Scalar(document.Root(), "spec", "type") == "ExternalName" {
+		return
+	}
+
+	selector := manifests.Map(manifests.Lookup(document.Root(), "spec", "selector"))
+	if len(selector) == 0 {
+		return
+	}
+
+	namespace := r.namespace(document)
+
+	workloads := r.selected(namespace, selector)
+	if len(workloads) == 0 {
+		message := fmt.Sprintf("selector (%s) doesn't match the pod template labels of any workload in namespace %s", format(selector), namespace)
+		if candidate, differences := r.closest(namespace, selector); candidate != nil {
+			message = fmt.Sprintf("%s - closest is %s (%s)", message, candidate, strings.Join(differences, ", "))
+		}
+
+		r.report(document, manifests.Path{"spec", "selector"}, "service-selector", lint.Error, "%s", message)
+
+		return
+	}
+
+	for index, port := range manifests.Items(manifests.Lookup(document.Root(), "spec", "ports")) {
+		path := manifests.Path{"spec", "ports"}.Index(index)
+
+		target := manifests.Scalar(port, "targetPort")
+		if target == "" {
+			path, target = path.Key("port"), manifests.Scalar(port, "port")
+		} else {
+			path = path.Key("targetPort")
+		}
+
+		if target == "" {
+			continue
+		}
+
+		var missing []string
+		for _, workload := range workloads {
+			if !(declares(workload, target)) {
+				missing = append(missing, workload.String())
+			}
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		// --> a numeric target port still routes to a process listening on it; a named port can't be resolved at all
+		severity := lint.Warning
+		if _, e := strconv.Atoi(target); e != nil {
+			severity = lint.Error
+		}
+
+		r.report(document, path, "target-port", severity, "target port %s isn't a declared containerPort of %s", target, strings.Join(missing, ", "))
+	}
+}
+
+// declares reports whether any of a workload's containers declares port - either a container port's name or number.
+func declares(document *manifests.Document, port string) bool {
+	_, numeric := strconv.Atoi(port)
+
+	for _, container := range document.Containers() {
+		if container.Type == "ephemeralContainers" {
+			continue
+		}
+
+		for _, declaration := range manifests.Items(manifests.Value(container.Node, "ports")) {
+			if numeric == nil && manifests.Scalar(declaration, "containerPort") == port {
+				return true
+			} else if numeric != nil && manifests.Scalar(declaration, "name") == port {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// workload verifies the service account, config-map(s), and secret(s) a workload's pods reference.
+func (r *References) workload(document *manifests.Document) {
+	spec, path := document.PodSpec()
+	if spec == nil {
+		return
+	}
+
+	namespace := r.namespace(document)
+
+	for _, field := range []string{"serviceAccountName", "serviceAccount"} {
+		name := manifests.Scalar(spec, field)
+		if name == "" || name == "default" {
+			continue
+		}
+
+		if r.lookup("ServiceAccount", namespace, name) == nil && !(r.external("ServiceAccount", name)) {
+			r.report(document, path.Key(field), "service-account", lint.Error, "service account %s doesn't exist in namespace %s", name, namespace)
+		}
+
+		break
+	}
+
+	for index, secret := range manifests.Items(manifests.Value(spec, "imagePullSecrets")) {
+		r.dependency(document, path.Key("imagePullSecrets").Index(index), "Secret", manifests.Scalar(secret, "name"), false)
+	}
+
+	for _, container := range document.Containers() {
+		for index, variable := range manifests.Items(manifests.Value(container.Node, "env")) {
+			location := container.Path.Key("env").Index(index).Key("valueFrom")
+			for _, reference := range []struct{ field, kind string }{{"configMapKeyRef", "ConfigMap"}, {"secretKeyRef", "Secret"}} {
+				node := manifests.Lookup(variable, "valueFrom", reference.field)
+				if node == nil {
+					continue
+				}
+
+				r.dependency(document, location.Key(reference.field), reference.kind, manifests.Scalar(node, "name"), optional(node), manifests.Scalar(node, "key"))
+			}
+		}
+
+		for index, source := range manifests.Items(manifests.Value(container.Node, "envFrom")) {
+			location := container.Path.Key("envFrom").Index(index)
+			for _, reference := range []struct{ field, kind string }{{"configMapRef", "ConfigMap"}, {"secretRef", "Secret"}} {
+				node := manifests.Value(source, reference.field)
+				if node == nil {
+					continue
+				}
+
+				r.dependency(document, location.Key(reference.field), reference.kind, manifests.Scalar(node, "name"), optional(node))
+			}
+		}
+	}
+
+	for index, volume := range manifests.Items(manifests.Value(spec, "volumes")) {
+		location := path.Key("volumes").Index(index)
+
+		if node := manifests.Value(volume, "configMap"); node != nil {
+			r.dependency(document, location.Key("configMap"), "ConfigMap", manifests.Scalar(node, "name"), optional(node), keys(node)...)
+		}
+
+		if node := manifests.Value(volume, "secret"); node != nil {
+			r.dependency(document, location.Key("secret"), "Secret", manifests.Scalar(node, "secretName"), optional(node), keys(node)...)
+		}
+
+		for position, source := range manifests.Items(manifests.Lookup(volume, "projected", "sources")) {
+			projection := location.Key("projected").Key("sources").Index(position)
+
+			if node := manifests.Value(source, "configMap"); node != nil {
+				r.dependency(document, projection.Key("configMap"), "ConfigMap", manifests.Scalar(node, "name"), optional(node), keys(node)...)
+			}
+
+			if node := manifests.Value(source, "secret"); node != nil {
+				r.dependency(document, projection.Key("secret"), "Secret", manifests.Scalar(node, "name"), optional(node), keys(node)...)
+			}
+		}
+	}
+}
+
+// optional reports whether a config-map or secret reference is marked "optional: true".
+func optional(node *yaml.Node) bool {
+	return manifests.Scalar(node, "optional") == "true"
+}
+
+// keys returns the key(s) a config-map or secret volume projects through its "items".
+func keys(node *yaml.Node) []string {
+	var projected []string
+	for _, item := range manifests.Items(manifests.Value(node, "items")) {
+		if key := manifests.Scalar(item, "key"); key != "" {
+			projected = append(projected, key)
+		}
+	}
+
+	return projected
+}
+
+// dependency verifies a workload's reference to a ConfigMap or Secret - the resource must exist (unless the reference
+// is optional), and, if it's part of the bundle, must contain each referenced key.
+func (r *References) dependency(document *manifests.Document, path manifests.Path, kind, name string, optional bool, keys ...string) {
+	if name == "" {
+		return
+	}
+
+	check := map[string]string{"ConfigMap": "configmap-reference", "Secret": "secret-reference"}[kind]
+
+	namespace := r.namespace(document)
+
+	target := r.lookup(kind, namespace, name)
+	if target == nil {
+		if !(optional) && !(r.external(kind, name)) {
+			r.report(document, path, check, lint.Error, "%s doesn't exist in namespace %s", manifests.Reference(kind, name), namespace)
+		}
+
+		return
+	}
+
+	available := make(map[string]bool)
+	for _, field := range []string{"data", "stringData", "binaryData"} {
+		for _, key := range manifests.Keys(manifests.Value(target.Root(), field)) {
+			available[key] = true
+		}
+	}
+
+	for _, key := range keys {
+		if key != "" && !(available[key]) && !(optional) {
+			r.report(document, path, check, lint.Error, "%s doesn't contain key %s", manifests.Reference(kind, name), key)
+		}
+	}
+}
+
+// autoscaler verifies a HorizontalPodAutoscaler's scale target exists.
+func (r *References) autoscaler(document *manifests.Document) {
+	kind := manifests.Scalar(document.Root(), "spec", "scaleTargetRef", "kind")
+	name := manifests.Scalar(document.Root(), "spec", "scaleTargetRef", "name")
+	if kind == "" || name == "" {
+		return
+	}
+
+	if r.lookup(kind, r.namespace(document), name) == nil && !(r.external(kind, name)) {
+		r.report(document, manifests.Path{"spec", "scaleTargetRef"}, "scale-target", lint.Error, "scale target %s doesn't exist in namespace %s", manifests.Reference(kind, name), r.namespace(document))
+	}
+}
+
+// budget verifies a PodDisruptionBudget's selector matches a workload's pods.
+func (r *References) budget(document *manifests.Document) {
+	selector := manifests.Map(manifests.Lookup(document.Root(), "spec", "selector", "matchLabels"))
+	if len(selector) == 0 || manifests.Lookup(document.Root(), "spec", "selector", "matchExpressions") != nil {
+		return
+	}
+
+	if len(r.selected(r.namespace(document), selector)) == 0 {
+		r.report(document, manifests.Path{"spec", "selector", "matchLabels"}, "disruption-budget-selector", lint.Warning, "selector (%s) doesn't match the pod template labels of any workload in namespace %s", format(selector), r.namespace(document))
+	}
+}
+
+// ingress verifies the Service(s), and their port(s), an Ingress routes to.
+func (r *References) ingress(document *manifests.Document) {
+	verify := func(path manifests.Path, backend *yaml.Node) {
+		name := manifests.Scalar(backend, "service", "name")
+		if name == "" {
+			return
+		}
+
+		namespace := r.namespace(document)
+
+		service := r.lookup("Service", namespace, name)
+		if service == nil {
+			if !(r.external("Service", name)) {
+				r.report(document, path.Key("service"), "ingress-backend", lint.Error, "service %s doesn't exist in namespace %s", name, namespace)
+			}
+
+			return
+		}
+
+		number, named := manifests.Scalar(backend, "service", "port", "number"), manifests.Scalar(backend, "service", "port", "name")
+		if number == "" && named == "" {
+			return
+		}
+
+		for _, port := range manifests.Items(manifests.Lookup(service.Root(), "spec", "ports")) {
+			if (number != "" && manifests.Scalar(port, "port") == number) || (named != "" && manifests.Scalar(port, "name") == named) {
+				return
+			}
+		}
+
+		r.report(document, path.Key("service").Key("port"), "ingress-backend", lint.Error, "service %s doesn't declare port %s", name, number+named)
+	}
+
+	verify(manifests.Path{"spec", "defaultBackend"}, manifests.Lookup(document.Root(), "spec", "defaultBackend"))
+
+	for index, rule := range manifests.Items(manifests.Lookup(document.Root(), "spec", "rules")) {
+		for position, route := range manifests.Items(manifests.Lookup(rule, "http", "paths")) {
+			verify(manifests.Path{"spec", "rules"}.Index(index).Key("http").Key("paths").Index(position).Key("backend"), manifests.Value(route, "backend"))
+		}
+	}
+}
+
+// binding verifies a RoleBinding's or ClusterRoleBinding's role, and its service account subject(s).
+func (r *References) binding(document *manifests.Document) {
+	kind, name := manifests.Scalar(document.Root(), "roleRef", "kind"), manifests.Scalar(document.Root(), "roleRef", "name")
+	if kind != "" && name != "" {
+		var namespace string
+		if kind == "Role" {
+			namespace = r.namespace(document)
+		}
+
+		// --> cluster roles are commonly built-in (e.g. "view", "edit"), so only a missing namespaced role is reported
+		if kind == "Role" && r.lookup(kind, namespace, name) == nil && !(r.external(kind, name)) {
+			r.report(document, manifests.Path{"roleRef"}, "role-binding", lint.Error, "role %s doesn't exist in namespace %s", name, namespace)
+		}
+	}
+
+	for index, subject := range manifests.Items(manifests.Value(document.Root(), "subjects")) {
+		if manifests.Scalar(subject, "kind") != "ServiceAccount" {
+			continue
+		}
+
+		account := manifests.Scalar(subject, "name")
+		namespace := manifests.Scalar(subject, "namespace")
+		if namespace == "" {
+			namespace = r.namespace(document)
+		}
+
+		if account == "" || account == "default" {
+			continue
+		}
+
+		if r.lookup("ServiceAccount", namespace, account) == nil && !(r.external("ServiceAccount", account)) {
+			r.report(document, manifests.Path{"subjects"}.Index(index), "role-binding", lint.Error, "service account %s doesn't exist in namespace %s", account, namespace)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package check
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// bundle is a set of resources whose references all resolve.
+const bundle = `apiVersion: apps/v1
+kind: Deployment
+metadata: { name: api, namespace: backend }
+spec:
+    template:
+        metadata:
+            labels: { app: api, tier: backend }
+        spec:
+            serviceAccountName: api
+            containers:
+                - name: api
+                  image: api:1.0.0
+                  ports: [ { name: http, containerPort: 8080 } ]
+                  env:
+                      - { name: PASSWORD, valueFrom: { secretKeyRef: { name: credentials, key: password } } }
+                  envFrom: [ { configMapRef: { name: settings } } ]
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata: { name: api, namespace: backend }
+---
+apiVersion: v1
+kind: Secret
+metadata: { name: credentials, namespace: backend }
+stringData: { password: secret }
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: { name: settings, namespace: backend }
+data: { LEVEL: info }
+---
+apiVersion: v1
+kind: Service
+metadata: { name: api, namespace: backend }
+spec:
+    selector: { app: api }
+    ports: [ { name: http, port: 80, targetPort: http } ]
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata: { name: api, namespace: backend }
+spec:
+    rules:
+        - http:
+              paths: [ { path: /, pathType: Prefix, backend: { service: { name: api, port: { name: http } } } } ]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata: { name: api, namespace: backend }
+roleRef: { apiGroup: rbac.authorization.k8s.io, kind: ClusterRole, name: view }
+subjects: [ { kind: ServiceAccount, name: api } ]
+`
+
+// checks returns the findings' check IDs.
+func checks(findings []Finding) []string {
+	var ids []string
+	for _, finding := range findings {
+		ids = append(ids, finding.Check)
+	}
+
+	return ids
+}
+
+func TestReferencesCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string // old is replaced by new within the bundle
+		external []string
+		expected []string
+	}{
+		{name: "valid"},
+		{name: "service-selector", old: "selector: { app: api }", new: "selector: { app: other }", expected: []string{"service-selector"}},
+		{name: "service-selector-empty-value", old: "selector: { app: api }", new: `selector: { app: api, canary: "" }`, expected: []string{"service-selector"}},
+		{name: "target-port", old: "targetPort: http", new: "targetPort: grpc", expected: []string{"target-port"}},
+		{name: "service-account", old: "serviceAccountName: api", new: "serviceAccountName: other", expected: []string{"service-account"}},
+		{name: "service-account-external", old: "serviceAccountName: api", new: "serviceAccountName: other", external: []string{"serviceaccount/other"}},
+		{name: "secret-key", old: "key: password", new: "key: username", expected: []string{"secret-reference"}},
+		{name: "configmap", old: "configMapRef: { name: settings }", new: "configMapRef: { name: missing }", expected: []string{"configmap-reference"}},
+		{name: "configmap-optional", old: "configMapRef: { name: settings }", new: "configMapRef: { name: missing, optional: true }"},
+		{name: "ingress-backend", old: "port: { name: http }", new: "port: { number: 8080 }", expected: []string{"ingress-backend"}},
+		{name: "role-binding", old: "kind: ClusterRole, name: view", new: "kind: Role, name: view", expected: []string{"role-binding"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documents, e := manifests.Decode("test.yaml", strings.NewReader(strings.Replace(bundle, test.old, test.new, 1)))
+			if e != nil {
+				t.Fatalf("unable to decode manifest: %v", e)
+			}
+
+			references := &References{Namespace: "default", External: test.external}
+
+			if ids := checks(references.Check(documents...)); !(slices.Equal(ids, test.expected)) {
+				t.Errorf("Check() = %v, expected %v", ids, test.expected)
+			}
+		})
+	}
+}
+
+func TestReferencesClosest(t *testing.T) {
+	documents, e := manifests.Decode("test.yaml", strings.NewReader(strings.Replace(bundle, "selector: { app: api }", "selector: { app: api, tier: frontend, zone: a }", 1)))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	findings := (&References{}).Check(documents...)
+	if len(findings) != 1 {
+		t.Fatalf("Check() = %v, expected a single finding", checks(findings))
+	}
+
+	if expected := "closest is deployment/api (tier=backend, zone is missing)"; !(strings.Contains(findings[0].Message, expected)) {
+		t.Errorf("Check() message = %q, expected it to contain %q", findings[0].Message, expected)
+	}
+}
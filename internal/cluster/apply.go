@@ -0,0 +1,319 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Action describes the outcome of applying, or pruning, a resource.
+type Action string
+
+const (
+	Created    Action = "created"
+	Configured Action = "configured"
+	Unchanged  Action = "unchanged"
+	Pruned     Action = "pruned"
+)
+
+// Result represents the outcome of applying, or pruning, a single resource.
+type Result struct {
+	Resource  string `json:"resource" yaml:"resource"` // Resource is the kubectl-style reference (e.g. "deployment.apps/example").
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Action    Action `json:"action" yaml:"action"`
+
+	mapping Mapping
+	name    string
+}
+
+// key identifies a resource independent of its version.
+func key(mapping Mapping, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", mapping.Group, mapping.Kind, namespace, name)
+}
+
+// Object represents a resource returned by the API server.
+type Object map[string]interface{}
+
+// Metadata returns the object's "metadata" field.
+func (o Object) Metadata() map[string]interface{} {
+	metadata, _ := o["metadata"].(map[string]interface{})
+
+	return metadata
+}
+
+// String returns a string field of the object's metadata.
+func (o Object) String(field string) string {
+	value, _ := o.Metadata()[field].(string)
+
+	return value
+}
+
+// stripped returns a copy of the object without the metadata the API server maintains on every write.
+func (o Object) stripped() Object {
+	copied := make(Object, len(o))
+	for key, value := range o {
+		copied[key] = value
+	}
+
+	metadata := make(map[string]interface{})
+	for key, value := range o.Metadata() {
+		switch key {
+		case "managedFields", "resourceVersion", "generation":
+		default:
+			metadata[key] = value
+		}
+	}
+
+	copied["metadata"] = metadata
+
+	return copied
+}
+
+// Get returns a named resource.
+func (c *Client) Get(ctx context.Context, mapping Mapping, namespace, name string) (Object, error) {
+	content, e := c.Request(ctx, http.MethodGet, mapping.Path(namespace, name), nil, "", nil)
+	if e != nil {
+		return nil, e
+	}
+
+	var object Object
+	if e := json.Unmarshal(content, &object); e != nil {
+		return nil, e
+	}
+
+	return object, nil
+}
+
+// List returns the resources of a mapping in namespace (every namespace if empty) that match a label selector.
+func (c *Client) List(ctx context.Context, mapping Mapping, namespace, selector string) ([]Object, error) {
+	query := url.Values{}
+	if selector != "" {
+		query.Set("labelSelector", selector)
+	}
+
+	content, e := c.Request(ctx, http.MethodGet, mapping.Path(namespace, ""), query, "", nil)
+	if e != nil {
+		return nil, e
+	}
+
+	var list struct {
+		Items []Object `json:"items"`
+	}
+
+	if e := json.Unmarshal(content, &list); e != nil {
+		return nil, e
+	}
+
+	return list.Items, nil
+}
+
+// Applier performs server-side apply.
+type Applier struct {
+	Client  *Client
+	Manager string // Manager is the field manager recorded against applied fields.
+	Force   bool   // Force takes ownership of fields another manager owns, rather than failing with a conflict.
+	DryRun  bool   // DryRun has the API server validate and compute the outcome without persisting it.
+
+	applied map[string]bool
+	scopes  map[string]bool
+}
+
+// query returns the parameters shared by the applier's mutating requests.
+func (a *Applier) query() url.Values {
+	query := url.Values{}
+	if a.DryRun {
+		query.Set("dryRun", "All")
+	}
+
+	return query
+}
+
+// Apply server-side applies a document ("PATCH" with "application/apply-patch+yaml"), reporting whether the resource
+// was created, configured, or left unchanged.
+func (a *Applier) Apply(ctx context.Context, document *manifests.Document) (Result, error) {
+	if a.applied == nil {
+		a.applied, a.scopes = make(map[string]bool), make(map[string]bool)
+	}
+
+	mapping, e := a.Client.Mapping(ctx, document.APIVersion(), document.Kind())
+	if e != nil {
+		return Result{}, e
+	}
+
+	var namespace string
+	if mapping.Namespaced {
+		namespace = document.Namespace()
+		if namespace == "" {
+			namespace = a.Client.Namespace
+		}
+	}
+
+	name := document.Name()
+	if name == "" {
+		return Result{}, fmt.Errorf("%s has no name", document.Location())
+	}
+
+	result := Result{Resource: mapping.String() + "/" + name, Namespace: namespace, mapping: mapping, name: name}
+
+	a.applied[key(mapping, namespace, name)] = true
+	a.scopes[namespace] = true
+
+	previous, e := a.Client.Get(ctx, mapping, namespace, name)
+	if e != nil && !(NotFound(e)) {
+		e = fmt.Errorf("unable to get %s: %w", result.Resource, e)
+		return result, e
+	}
+
+	body, e := yaml.Marshal(document.Root())
+	if e != nil {
+		return result, e
+	}
+
+	query := a.query()
+	query.Set("fieldManager", a.Manager)
+	if a.Force {
+		query.Set("force", "true")
+	}
+
+	content, e := a.Client.Request(ctx, http.MethodPatch, mapping.Path(namespace, name), query, "application/apply-patch+yaml", body)
+	if e != nil {
+		e = fmt.Errorf("unable to apply %s: %w", result.Resource, e)
+		return result, e
+	}
+
+	var current Object
+	if e := json.Unmarshal(content, &current); e != nil {
+		return result, e
+	}
+
+	// --> the API server only assigns a new resourceVersion when a write changes the object; a dry-run is never
+	// persisted, so its outcome is compared by content instead
+	switch {
+	case previous == nil:
+		result.Action = Created
+	case a.DryRun && reflect.DeepEqual(previous.stripped(), current.stripped()):
+		result.Action = Unchanged
+	case !(a.DryRun) && previous.String("resourceVersion") == current.String("resourceVersion"):
+		result.Action = Unchanged
+	default:
+		result.Action = Configured
+	}
+
+	return result, nil
+}
+
+// allowlist are the kinds considered for pruning in addition to the applied kinds - kubectl's default prune set.
+var allowlist = [][2]string{
+	{"v1", "ConfigMap"},
+	{"v1", "Endpoints"},
+	{"v1", "Namespace"},
+	{"v1", "PersistentVolumeClaim"},
+	{"v1", "PersistentVolume"},
+	{"v1", "Pod"},
+	{"v1", "ReplicationController"},
+	{"v1", "Secret"},
+	{"v1", "Service"},
+	{"batch/v1", "Job"},
+	{"batch/v1", "CronJob"},
+	{"networking.k8s.io/v1", "Ingress"},
+	{"apps/v1", "DaemonSet"},
+	{"apps/v1", "Deployment"},
+	{"apps/v1", "ReplicaSet"},
+	{"apps/v1", "StatefulSet"},
+}
+
+// Prune deletes the resources that match selector, were applied by the applier's field manager, and weren't part of
+// applied - the results of this run's [Applier.Apply] calls. Namespaced resources are only pruned within the
+// namespaces this run applied to. Resources are deleted in the reverse of their apply order.
+func (a *Applier) Prune(ctx context.Context, selector string, applied []Result) ([]Result, error) {
+	candidates := make(map[string]Mapping)
+	for _, result := range applied {
+		candidates[result.mapping.Group+"/"+result.mapping.Kind] = result.mapping
+	}
+
+	for _, entry := range allowlist {
+		mapping, e := a.Client.Mapping(ctx, entry[0], entry[1])
+		if e != nil {
+			continue
+		}
+
+		if _, exists := candidates[mapping.Group+"/"+mapping.Kind]; !(exists) {
+			candidates[mapping.Group+"/"+mapping.Kind] = mapping
+		}
+	}
+
+	var namespaces []string
+	for namespace := range a.scopes {
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	sort.Strings(namespaces)
+
+	var pruned []Result
+	for _, mapping := range candidates {
+		scopes := namespaces
+		if !(mapping.Namespaced) {
+			scopes = []string{""}
+		}
+
+		for _, namespace := range scopes {
+			objects, e := a.Client.List(ctx, mapping, namespace, selector)
+			if e != nil {
+				e = fmt.Errorf("unable to list %s: %w", mapping, e)
+				return nil, e
+			}
+
+			for _, object := range objects {
+				name := object.String("name")
+				if a.applied[key(mapping, namespace, name)] || object.String("deletionTimestamp") != "" || !(managed(object, a.Manager)) {
+					continue
+				}
+
+				pruned = append(pruned, Result{Resource: mapping.String() + "/" + name, Namespace: namespace, Action: Pruned, mapping: mapping, name: name})
+			}
+		}
+	}
+
+	sort.SliceStable(pruned, func(i, j int) bool {
+		if left, right := manifests.Rank(pruned[i].mapping.Kind), manifests.Rank(pruned[j].mapping.Kind); left != right {
+			return left > right
+		}
+
+		return pruned[i].Namespace+pruned[i].Resource < pruned[j].Namespace+pruned[j].Resource
+	})
+
+	for index, result := range pruned {
+		query := a.query()
+		query.Set("propagationPolicy", "Background")
+
+		if _, e := a.Client.Request(ctx, http.MethodDelete, result.mapping.Path(result.Namespace, result.name), query, "", nil); e != nil && !(NotFound(e)) {
+			e = fmt.Errorf("unable to prune %s: %w", result.Resource, e)
+			return pruned[:index], e
+		}
+	}
+
+	return pruned, nil
+}
+
+// managed reports whether a field manager has applied (rather than updated) any of an object's fields.
+func managed(object Object, manager string) bool {
+	entries, _ := object.Metadata()["managedFields"].([]interface{})
+	for _, entry := range entries {
+		fields, _ := entry.(map[string]interface{})
+		if fields["manager"] == manager && fields["operation"] == "Apply" {
+			return true
+		}
+	}
+
+	return false
+}
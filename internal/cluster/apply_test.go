@@ -0,0 +1,132 @@
+package cluster
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// configmap returns a ConfigMap manifest, labeled "app=example", with a single data value.
+func configmap(t *testing.T, name, value string) *manifests.Document {
+	t.Helper()
+
+	content := strings.Join([]string{
+		"apiVersion: v1",
+		"kind: ConfigMap",
+		"metadata:",
+		"    name: " + name,
+		"    labels: { app: example }",
+		"data:",
+		"    key: " + value,
+	}, "\n")
+
+	documents, e := manifests.Decode("test.yaml", strings.NewReader(content))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	return documents[0]
+}
+
+func TestApplierApply(t *testing.T) {
+	server, client := serve(t)
+
+	ctx := context.Background()
+
+	applier := &Applier{Client: client, Manager: "ethr"}
+
+	steps := []struct {
+		name     string
+		value    string
+		dry      bool
+		expected Action
+	}{
+		{name: "created", value: "first", expected: Created},
+		{name: "unchanged", value: "first", expected: Unchanged},
+		{name: "configured", value: "second", expected: Configured},
+		{name: "dry-run-unchanged", value: "second", dry: true, expected: Unchanged},
+		{name: "dry-run-configured", value: "third", dry: true, expected: Configured},
+	}
+
+	for _, step := range steps {
+		applier.DryRun = step.dry
+
+		result, e := applier.Apply(ctx, configmap(t, "example", step.value))
+		if e != nil {
+			t.Fatalf("%s: Apply() returned an unexpected error: %v", step.name, e)
+		}
+
+		if result.Action != step.expected || result.Resource != "configmap/example" || result.Namespace != "default" {
+			t.Errorf("%s: Apply() = %+v, expected %s configmap/example in default", step.name, result, step.expected)
+		}
+	}
+
+	stored := server.objects["/api/v1/namespaces/default/configmaps/example"]
+	if data, _ := stored["data"].(map[string]interface{}); data["key"] != "second" {
+		t.Errorf("stored data = %v, expected the dry-run(s) not to be persisted", data)
+	}
+
+	if _, e := applier.Apply(ctx, configmap(t, "", "value")); e == nil {
+		t.Errorf("Apply() of an unnamed resource expected an error")
+	}
+}
+
+func TestApplierPrune(t *testing.T) {
+	server, client := serve(t)
+
+	ctx := context.Background()
+
+	owned := func(name, manager, operation string) Object {
+		return Object{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":          name,
+				"namespace":     "default",
+				"labels":        map[string]interface{}{"app": "example"},
+				"managedFields": []interface{}{map[string]interface{}{"manager": manager, "operation": operation}},
+			},
+		}
+	}
+
+	server.store("/api/v1/namespaces/default/configmaps/stale", owned("stale", "ethr", "Apply"))
+	server.store("/api/v1/namespaces/default/configmaps/foreign", owned("foreign", "kubectl", "Apply"))
+	server.store("/api/v1/namespaces/default/configmaps/updated", owned("updated", "ethr", "Update"))
+	server.store("/api/v1/namespaces/other/configmaps/scoped", owned("scoped", "ethr", "Apply"))
+
+	unlabeled := owned("unlabeled", "ethr", "Apply")
+	delete(unlabeled.Metadata(), "labels")
+	server.store("/api/v1/namespaces/default/configmaps/unlabeled", unlabeled)
+
+	applier := &Applier{Client: client, Manager: "ethr"}
+
+	result, e := applier.Apply(ctx, configmap(t, "example", "value"))
+	if e != nil {
+		t.Fatalf("Apply() returned an unexpected error: %v", e)
+	}
+
+	// --> a dry-run reports, but doesn't delete, the resources
+	applier.DryRun = true
+
+	pruned, e := applier.Prune(ctx, "app=example", []Result{result})
+	if e != nil {
+		t.Fatalf("Prune() returned an unexpected error: %v", e)
+	}
+
+	if len(pruned) != 1 || pruned[0].Resource != "configmap/stale" || pruned[0].Action != Pruned || len(server.deleted) != 0 {
+		t.Fatalf("Prune() with a dry-run = (%+v, deleted %v), expected configmap/stale to be reported only", pruned, server.deleted)
+	}
+
+	applier.DryRun = false
+
+	if _, e := applier.Prune(ctx, "app=example", []Result{result}); e != nil {
+		t.Fatalf("Prune() returned an unexpected error: %v", e)
+	}
+
+	if expected := []string{"/api/v1/namespaces/default/configmaps/stale"}; !(slices.Equal(server.deleted, expected)) {
+		t.Errorf("Prune() deleted %v, expected %v", server.deleted, expected)
+	}
+}
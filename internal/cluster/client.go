@@ -0,0 +1,306 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
+)
+
+// Client represents an authenticated connection to an API server.
+type Client struct {
+	Server    string       // Server is the API server's base url (e.g. "https://127.0.0.1:6443").
+	HTTP      *http.Client // HTTP performs the request(s); its transport carries the cluster's tls configuration.
+	Token     string       // Token is an optional bearer token.
+	Username  string       // Username is an optional basic-authentication username.
+	Password  string       // Password is an optional basic-authentication password.
+	Namespace string       // Namespace is the default namespace of namespaced resources.
+
+	mutex     sync.Mutex
+	discovery map[string][]Resource
+}
+
+// New creates a [Client] from a kubeconfig's context - the current-context if name is empty.
+func New(config *kubeconfig.Config, name string) (*Client, error) {
+	if name == "" {
+		name = config.CurrentContext
+	}
+
+	if name == "" {
+		return nil, errors.New("no context selected - set --context or a current-context")
+	}
+
+	selected := config.Context(name)
+	if selected == nil {
+		return nil, fmt.Errorf("context not found: %s", name)
+	}
+
+	cluster := config.Cluster(selected.Cluster)
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster (%s) of context (%s) not found", selected.Cluster, name)
+	}
+
+	if cluster.Server == "" {
+		return nil, fmt.Errorf("cluster (%s) has no server", selected.Cluster)
+	}
+
+	user := config.User(selected.User)
+	if user == nil {
+		user = &kubeconfig.User{}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: cluster.InsecureSkipTLSVerify,
+		ServerName:         cluster.TLSServerName,
+	}
+
+	if cluster.ProxyURL != "" {
+		proxy, e := url.Parse(cluster.ProxyURL)
+		if e != nil {
+			e = fmt.Errorf("invalid proxy-url: %w", e)
+			return nil, e
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	authority, e := material(cluster.CertificateAuthorityData, cluster.CertificateAuthority)
+	if e != nil {
+		e = fmt.Errorf("unable to read certificate-authority: %w", e)
+		return nil, e
+	}
+
+	if len(authority) > 0 {
+		pool := x509.NewCertPool()
+		if !(pool.AppendCertsFromPEM(authority)) {
+			return nil, fmt.Errorf("cluster (%s) certificate-authority contains no valid certificate", selected.Cluster)
+		}
+
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	client := &Client{
+		Server:    strings.TrimSuffix(cluster.Server, "/"),
+		HTTP:      &http.Client{Transport: transport},
+		Token:     user.Token,
+		Username:  user.Username,
+		Password:  user.Password,
+		Namespace: selected.Namespace,
+	}
+
+	if client.Namespace == "" {
+		client.Namespace = "default"
+	}
+
+	if user.TokenFile != "" && client.Token == "" {
+		token, e := os.ReadFile(user.TokenFile)
+		if e != nil {
+			e = fmt.Errorf("unable to read token file: %w", e)
+			return nil, e
+		}
+
+		client.Token = strings.TrimSpace(string(token))
+	}
+
+	certificate, e := material(user.ClientCertificateData, user.ClientCertificate)
+	if e != nil {
+		e = fmt.Errorf("unable to read client-certificate: %w", e)
+		return nil, e
+	}
+
+	key, e := material(user.ClientKeyData, user.ClientKey)
+	if e != nil {
+		e = fmt.Errorf("unable to read client-key: %w", e)
+		return nil, e
+	}
+
+	if user.Exec != nil {
+		credential, e := plugin(user.Exec)
+		if e != nil {
+			return nil, e
+		}
+
+		if credential.Token != "" {
+			client.Token = credential.Token
+		}
+
+		if credential.ClientCertificateData != "" {
+			certificate, key = []byte(credential.ClientCertificateData), []byte(credential.ClientKeyData)
+		}
+	}
+
+	if len(certificate) > 0 {
+		pair, e := tls.X509KeyPair(certificate, key)
+		if e != nil {
+			e = fmt.Errorf("invalid client certificate: %w", e)
+			return nil, e
+		}
+
+		transport.TLSClientConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	return client, nil
+}
+
+// material returns base64-encoded data if set, otherwise the content of file, if set.
+func material(data, file string) ([]byte, error) {
+	if data != "" {
+		return base64.StdEncoding.DecodeString(data)
+	}
+
+	if file != "" {
+		return os.ReadFile(file)
+	}
+
+	return nil, nil
+}
+
+// credential represents the status of an ExecCredential returned by a credential plugin.
+type credential struct {
+	Token                 string `json:"token"`
+	ClientCertificateData string `json:"clientCertificateData"`
+	ClientKeyData         string `json:"clientKeyData"`
+}
+
+// plugin runs a client-go credential plugin, returning the credential it issues.
+func plugin(configuration *kubeconfig.Exec) (*credential, error) {
+	command := exec.Command(configuration.Command, configuration.Args...)
+	command.Env = os.Environ()
+	for _, variable := range configuration.Env {
+		command.Env = append(command.Env, fmt.Sprintf("%s=%s", variable.Name, variable.Value))
+	}
+
+	information, e := json.Marshal(map[string]interface{}{
+		"apiVersion": configuration.APIVersion,
+		"kind":       "ExecCredential",
+		"spec":       map[string]interface{}{"interactive": false},
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	command.Env = append(command.Env, fmt.Sprintf("KUBERNETES_EXEC_INFO=%s", information))
+	command.Stderr = os.Stderr
+
+	output, e := command.Output()
+	if e != nil {
+		e = fmt.Errorf("credential plugin (%s) failed: %w", configuration.Command, e)
+		return nil, e
+	}
+
+	var response struct {
+		Status credential `json:"status"`
+	}
+
+	if e := json.Unmarshal(output, &response); e != nil {
+		e = fmt.Errorf("unable to parse credential plugin (%s) output: %w", configuration.Command, e)
+		return nil, e
+	}
+
+	return &response.Status, nil
+}
+
+// Error represents a failed request, decoded from the API server's Status response when available.
+type Error struct {
+	Code    int    `json:"code"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("request failed (%d)", e.Code)
+	}
+
+	return fmt.Sprintf("%s (%d %s)", e.Message, e.Code, e.Reason)
+}
+
+// NotFound reports whether e is an API server's "NotFound" response.
+func NotFound(e error) bool {
+	var exception *Error
+
+	return errors.As(e, &exception) && exception.Code == http.StatusNotFound
+}
+
+// Request performs an API request, returning the response body. Non-2xx responses are returned as an [*Error].
+func (c *Client) Request(ctx context.Context, method, path string, query url.Values, contentType string, body []byte) ([]byte, error) {
+	response, e := c.open(ctx, method, path, query, contentType, body)
+	if e != nil {
+		return nil, e
+	}
+
+	defer response.Body.Close()
+
+	return io.ReadAll(response.Body)
+}
+
+// open performs an API request, returning the response for the caller to consume and close.
+func (c *Client) open(ctx context.Context, method, path string, query url.Values, contentType string, body []byte) (*http.Response, error) {
+	endpoint := c.Server + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	request, e := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if e != nil {
+		return nil, e
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	switch {
+	case c.Token != "":
+		request.Header.Set("Authorization", "Bearer "+c.Token)
+	case c.Username != "":
+		request.SetBasicAuth(c.Username, c.Password)
+	}
+
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		return nil, e
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+
+		content, _ := io.ReadAll(response.Body)
+
+		exception := &Error{Code: response.StatusCode}
+		if e := json.Unmarshal(content, exception); e != nil || exception.Message == "" {
+			exception.Message = strings.TrimSpace(string(content))
+		}
+
+		exception.Code = response.StatusCode
+
+		return nil, exception
+	}
+
+	return response, nil
+}
@@ -0,0 +1,283 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// discovery are the fake API server's group-version discovery documents.
+var discovery = map[string][]Resource{
+	"/api/v1": {
+		{Name: "configmaps", SingularName: "configmap", ShortNames: []string{"cm"}, Kind: "ConfigMap", Namespaced: true},
+		{Name: "namespaces", SingularName: "namespace", ShortNames: []string{"ns"}, Kind: "Namespace"},
+		{Name: "pods", SingularName: "pod", ShortNames: []string{"po"}, Kind: "Pod", Namespaced: true},
+		{Name: "services", SingularName: "service", ShortNames: []string{"svc"}, Kind: "Service", Namespaced: true},
+	},
+	"/apis/apps/v1": {
+		{Name: "deployments", SingularName: "deployment", ShortNames: []string{"deploy"}, Kind: "Deployment", Namespaced: true},
+		{Name: "deployments/scale", SingularName: "", Kind: "Scale", Namespaced: true},
+	},
+}
+
+// fake is an in-memory API server that implements discovery, get, list, server-side apply, delete, and watch. Like
+// the API server, it only assigns a new resourceVersion when a write changes an object, and never persists a dry-run.
+type fake struct {
+	t *testing.T
+
+	mutex   sync.Mutex
+	version int
+	objects map[string]Object // objects are keyed by their REST path.
+	deleted []string          // deleted are the REST paths of deleted objects, in order.
+	changes []Change          // changes are streamed to every watch, which then blocks until its request is cancelled.
+}
+
+// serve starts a fake API server, returning it along with a [Client] connected to it.
+func serve(t *testing.T) (*fake, *Client) {
+	t.Helper()
+
+	server := &fake{t: t, objects: make(map[string]Object)}
+
+	endpoint := httptest.NewServer(server)
+	t.Cleanup(endpoint.Close)
+
+	return server, &Client{Server: endpoint.URL, HTTP: endpoint.Client(), Namespace: "default"}
+}
+
+// store adds an object at a REST path, assigning it a new resourceVersion.
+func (f *fake) store(path string, object Object) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.version++
+	object.Metadata()["resourceVersion"] = strconv.Itoa(f.version)
+
+	f.objects[path] = object
+}
+
+// status writes a kubernetes Status error response.
+func status(w http.ResponseWriter, code int, reason, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "Status", "code": code, "reason": reason, "message": message})
+}
+
+// collection reports whether a REST path addresses a collection, rather than a named object.
+func collection(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if segments[0] == "api" {
+		segments = segments[2:]
+	} else {
+		segments = segments[3:]
+	}
+
+	if len(segments) > 2 && segments[0] == "namespaces" {
+		segments = segments[2:]
+	}
+
+	return len(segments) == 1
+}
+
+func (f *fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/apis" {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"groups": []interface{}{
+			map[string]interface{}{"name": "apps", "preferredVersion": map[string]interface{}{"groupVersion": "apps/v1"}},
+		}})
+
+		return
+	}
+
+	if resources, exists := discovery[r.URL.Path]; exists {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"resources": resources})
+		return
+	}
+
+	// --> any other group-version is undiscoverable
+	if segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/"); len(segments) < 3 || (segments[0] == "apis" && len(segments) < 4) {
+		status(w, http.StatusNotFound, "NotFound", "the server could not find the requested resource")
+		return
+	}
+
+	query := r.URL.Query()
+
+	if collection(r.URL.Path) {
+		if r.Method != http.MethodGet {
+			status(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+			return
+		}
+
+		if query.Get("watch") == "true" {
+			f.watch(w, r)
+			return
+		}
+
+		f.list(w, r.URL.Path, query)
+
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	previous, exists := f.objects[r.URL.Path]
+
+	switch r.Method {
+	case http.MethodGet:
+		if !(exists) {
+			status(w, http.StatusNotFound, "NotFound", "not found")
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(previous)
+	case http.MethodPatch:
+		if r.Header.Get("Content-Type") != "application/apply-patch+yaml" || query.Get("fieldManager") == "" {
+			status(w, http.StatusUnsupportedMediaType, "UnsupportedMediaType", "expected a server-side apply")
+			return
+		}
+
+		content, _ := io.ReadAll(r.Body)
+
+		var body map[string]interface{}
+		if e := yaml.Unmarshal(content, &body); e != nil {
+			status(w, http.StatusBadRequest, "BadRequest", e.Error())
+			return
+		}
+
+		// --> normalize the body's types to those of a decoded JSON response
+		content, _ = json.Marshal(body)
+
+		var object Object
+		_ = json.Unmarshal(content, &object)
+
+		metadata := object.Metadata()
+		metadata["managedFields"] = []interface{}{map[string]interface{}{"manager": query.Get("fieldManager"), "operation": "Apply"}}
+		metadata["resourceVersion"] = previous.String("resourceVersion")
+
+		if !(exists) || !(reflect.DeepEqual(previous.stripped(), object.stripped())) {
+			if query.Get("dryRun") != "All" {
+				f.version++
+				metadata["resourceVersion"] = strconv.Itoa(f.version)
+			}
+		}
+
+		if query.Get("dryRun") != "All" {
+			f.objects[r.URL.Path] = object
+		}
+
+		_ = json.NewEncoder(w).Encode(object)
+	case http.MethodDelete:
+		if !(exists) {
+			status(w, http.StatusNotFound, "NotFound", "not found")
+			return
+		}
+
+		if query.Get("dryRun") != "All" {
+			delete(f.objects, r.URL.Path)
+			f.deleted = append(f.deleted, r.URL.Path)
+		}
+
+		_ = json.NewEncoder(w).Encode(previous)
+	default:
+		status(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+// list writes the objects of a collection that match its label (a single "<key>=<value>") and field selectors.
+func (f *fake) list(w http.ResponseWriter, path string, query url.Values) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	label, field := query.Get("labelSelector"), query.Get("fieldSelector")
+
+	items := []Object{}
+	for location, object := range f.objects {
+		parent, name, _ := strings.Cut(strings.TrimPrefix(location, path+"/"), "/")
+		if !(strings.HasPrefix(location, path+"/")) || name != "" {
+			continue
+		}
+
+		if field != "" && field != "metadata.name="+parent {
+			continue
+		}
+
+		if key, value, found := strings.Cut(label, "="); found {
+			labels, _ := object.Metadata()["labels"].(map[string]interface{})
+			if labels[key] != value {
+				continue
+			}
+		}
+
+		items = append(items, object)
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"metadata": map[string]interface{}{"resourceVersion": strconv.Itoa(f.version)}, "items": items})
+}
+
+// watch streams the fake's changes, then blocks until the request is cancelled.
+func (f *fake) watch(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	changes := f.changes
+	f.mutex.Unlock()
+
+	for _, change := range changes {
+		_ = json.NewEncoder(w).Encode(change)
+	}
+
+	w.(http.Flusher).Flush()
+
+	<-r.Context().Done()
+}
+
+func TestClientRequest(t *testing.T) {
+	_, client := serve(t)
+
+	client.Token = "token"
+
+	_, e := client.Request(context.Background(), http.MethodGet, "/api/v1/namespaces/default/configmaps/missing", nil, "", nil)
+	if !(NotFound(e)) {
+		t.Fatalf("Request() of a missing object = %v, expected a NotFound error", e)
+	}
+
+	if expected := "not found (404 NotFound)"; e.Error() != expected {
+		t.Errorf("Request() error = %q, expected %q", e.Error(), expected)
+	}
+}
+
+func TestClientFind(t *testing.T) {
+	_, client := serve(t)
+
+	tests := []struct {
+		name     string
+		expected string
+		valid    bool
+	}{
+		{name: "deploy", expected: "deployment.apps", valid: true},
+		{name: "deployments.apps", expected: "deployment.apps", valid: true},
+		{name: "cm", expected: "configmap", valid: true},
+		{name: "Service", expected: "service", valid: true},
+		{name: "scale"},
+		{name: "unknown"},
+	}
+
+	for _, test := range tests {
+		mapping, e := client.Find(context.Background(), test.name)
+		if test.valid && (e != nil || mapping.String() != test.expected) {
+			t.Errorf("Find(%s) = (%s, %v), expected %s", test.name, mapping, e, test.expected)
+		}
+
+		if !(test.valid) && e == nil {
+			t.Errorf("Find(%s) = %s, expected an error", test.name, mapping)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
+)
+
+// Connection represents the flag(s) that select a cluster and its default namespace. The kubeconfig file(s) are selected
+// by the shared "--kubeconfig" flag (see [kubeconfig.Register]).
+type Connection struct {
+	Context   string // Context overrides the kubeconfig's current-context.
+	Namespace string // Namespace overrides the context's namespace.
+}
+
+// Register adds the connection's flag(s) to a command.
+func (c *Connection) Register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	flags.StringVar(&c.Context, "context", "", "the kubeconfig context to use - defaults to the current-context")
+	flags.StringVarP(&c.Namespace, "namespace", "n", "", "the namespace of resources that don't declare one - defaults to the context's namespace")
+}
+
+// Client loads the kubeconfig and creates a [Client] for the selected context.
+func (c *Connection) Client() (*Client, error) {
+	config, e := kubeconfig.Load(kubeconfig.Paths(kubeconfig.Explicit)...)
+	if e != nil {
+		return nil, e
+	}
+
+	client, e := New(config, c.Context)
+	if e != nil {
+		return nil, e
+	}
+
+	if c.Namespace != "" {
+		client.Namespace = c.Namespace
+	}
+
+	return client, nil
+}
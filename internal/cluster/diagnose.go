@@ -0,0 +1,239 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	pods   = Mapping{Version: "v1", Kind: "Pod", Resource: "pods", Namespaced: true}
+	events = Mapping{Version: "v1", Kind: "Event", Resource: "events", Namespaced: true}
+)
+
+// Container represents the status of one of a pod's containers.
+type Container struct {
+	Name     string `json:"name" yaml:"name"`
+	Init     bool   `json:"init,omitempty" yaml:"init,omitempty"`
+	Ready    bool   `json:"ready" yaml:"ready"`
+	Restarts int64  `json:"restarts" yaml:"restarts"`
+	State    string `json:"state" yaml:"state"` // State is one of "waiting", "running", or "terminated".
+	Reason   string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+	Last     string `json:"last,omitempty" yaml:"last,omitempty"` // Last describes the previous termination, if any (e.g. "OOMKilled (exit 137)").
+}
+
+// Pod represents a pod that isn't ready.
+type Pod struct {
+	Name       string      `json:"name" yaml:"name"`
+	Phase      string      `json:"phase" yaml:"phase"`
+	Reason     string      `json:"reason,omitempty" yaml:"reason,omitempty"` // Reason is the scheduling (or eviction) failure, if any.
+	Containers []Container `json:"containers" yaml:"containers"`
+}
+
+// Event represents a kubernetes Event.
+type Event struct {
+	Time    string `json:"time" yaml:"time"`
+	Type    string `json:"type" yaml:"type"` // Type is either "Normal" or "Warning".
+	Reason  string `json:"reason" yaml:"reason"`
+	Object  string `json:"object" yaml:"object"` // Object is the involved object's reference (e.g. "pod/example-7d4b9c").
+	Message string `json:"message" yaml:"message"`
+	Count   int64  `json:"count,omitempty" yaml:"count,omitempty"`
+}
+
+// Diagnosis explains why a resource hasn't reached a condition.
+type Diagnosis struct {
+	Pods   []Pod   `json:"pods" yaml:"pods"`
+	Events []Event `json:"events" yaml:"events"`
+}
+
+// Diagnose collects the pods of an object (itself, if a Pod; otherwise those matching its "spec.selector") that
+// aren't ready, and the most recent events (at most limit) of the object, its ReplicaSets, and those pods.
+func (c *Client) Diagnose(ctx context.Context, mapping Mapping, object Object, limit int) (*Diagnosis, error) {
+	diagnosis := &Diagnosis{}
+
+	namespace, name := object.String("namespace"), object.String("name")
+
+	var candidates []Object
+	switch {
+	case mapping.Group == "" && mapping.Kind == "Pod":
+		candidates = []Object{object}
+	default:
+		if selector := Selector(field(field(object, "spec"), "selector")); selector != "" {
+			list, e := c.List(ctx, pods, namespace, selector)
+			if e != nil {
+				e = fmt.Errorf("unable to list pods: %w", e)
+				return nil, e
+			}
+
+			candidates = list
+		}
+	}
+
+	involved := map[string]bool{strings.ToLower(mapping.Kind) + "/" + name: true}
+	for _, candidate := range candidates {
+		if pod, failing := diagnose(candidate); failing {
+			diagnosis.Pods = append(diagnosis.Pods, pod)
+			involved["pod/"+pod.Name] = true
+		}
+	}
+
+	if !(mapping.Namespaced) {
+		return diagnosis, nil
+	}
+
+	list, e := c.List(ctx, events, namespace, "")
+	if e != nil {
+		e = fmt.Errorf("unable to list events: %w", e)
+		return nil, e
+	}
+
+	for _, entry := range list {
+		reference := field(entry, "involvedObject")
+		kind, _ := reference["kind"].(string)
+		target, _ := reference["name"].(string)
+
+		identifier := strings.ToLower(kind) + "/" + target
+		if !(involved[identifier]) && !(kind == "ReplicaSet" && mapping.Kind == "Deployment" && strings.HasPrefix(target, name+"-")) {
+			continue
+		}
+
+		event := Event{Type: text(entry, "type"), Reason: text(entry, "reason"), Object: identifier, Message: strings.TrimSpace(text(entry, "message")), Count: integer(entry, "count", 0)}
+		for _, key := range []string{"lastTimestamp", "eventTime", "firstTimestamp"} {
+			if event.Time = text(entry, key); event.Time != "" {
+				break
+			}
+		}
+
+		if event.Time == "" {
+			event.Time = entry.String("creationTimestamp")
+		}
+
+		diagnosis.Events = append(diagnosis.Events, event)
+	}
+
+	// --> RFC 3339 timestamps sort chronologically as strings
+	sort.SliceStable(diagnosis.Events, func(i, j int) bool {
+		return diagnosis.Events[i].Time < diagnosis.Events[j].Time
+	})
+
+	if limit > 0 && len(diagnosis.Events) > limit {
+		diagnosis.Events = diagnosis.Events[len(diagnosis.Events)-limit:]
+	}
+
+	return diagnosis, nil
+}
+
+// diagnose summarizes a pod, reporting whether it's failing - not ready, and not completed.
+func diagnose(object Object) (Pod, bool) {
+	status := field(object, "status")
+
+	pod := Pod{Name: object.String("name"), Phase: text(status, "phase"), Reason: text(status, "reason")}
+	if pod.Phase == "Succeeded" {
+		return pod, false
+	}
+
+	ready := pod.Phase == "Running"
+	conditions, _ := status["conditions"].([]interface{})
+	for _, entry := range conditions {
+		condition, _ := entry.(map[string]interface{})
+		switch text(condition, "type") {
+		case "Ready":
+			ready = ready && text(condition, "status") == "True"
+		case "PodScheduled":
+			if text(condition, "status") == "False" && pod.Reason == "" {
+				pod.Reason = strings.TrimSpace(text(condition, "reason") + ": " + text(condition, "message"))
+			}
+		}
+	}
+
+	for _, key := range []string{"initContainerStatuses", "containerStatuses"} {
+		entries, _ := status[key].([]interface{})
+		for _, entry := range entries {
+			container, _ := entry.(map[string]interface{})
+
+			summary := Container{Name: text(container, "name"), Init: key == "initContainerStatuses", Restarts: integer(container, "restartCount", 0)}
+			summary.Ready, _ = container["ready"].(bool)
+			summary.State, summary.Reason, summary.Message = state(field(container, "state"))
+
+			if previous, reason, _ := state(field(container, "lastState")); previous == "terminated" {
+				summary.Last = fmt.Sprintf("%s (exit %d)", reason, integer(field(field(container, "lastState"), "terminated"), "exitCode", 0))
+			}
+
+			if summary.Init && summary.State == "terminated" && summary.Reason == "Completed" {
+				continue
+			}
+
+			pod.Containers = append(pod.Containers, summary)
+		}
+	}
+
+	return pod, !(ready)
+}
+
+// state summarizes a container's state: its name, reason, and message.
+func state(value map[string]interface{}) (string, string, string) {
+	for _, name := range []string{"waiting", "terminated", "running"} {
+		if detail, exists := value[name].(map[string]interface{}); exists {
+			reason := text(detail, "reason")
+			if name == "terminated" && reason == "" {
+				reason = fmt.Sprintf("exit %d", integer(detail, "exitCode", 0))
+			}
+
+			return name, reason, strings.TrimSpace(text(detail, "message"))
+		}
+	}
+
+	return "unknown", "", ""
+}
+
+// Selector formats a LabelSelector as a label selector query (e.g. "app=example,tier notin (cache)").
+func Selector(selector map[string]interface{}) string {
+	var requirements []string
+
+	labels := field(selector, "matchLabels")
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		requirements = append(requirements, fmt.Sprintf("%s=%v", key, labels[key]))
+	}
+
+	expressions, _ := selector["matchExpressions"].([]interface{})
+	for _, entry := range expressions {
+		expression, _ := entry.(map[string]interface{})
+
+		key := text(expression, "key")
+
+		var values []string
+		list, _ := expression["values"].([]interface{})
+		for _, value := range list {
+			values = append(values, fmt.Sprintf("%v", value))
+		}
+
+		switch text(expression, "operator") {
+		case "In":
+			requirements = append(requirements, fmt.Sprintf("%s in (%s)", key, strings.Join(values, ",")))
+		case "NotIn":
+			requirements = append(requirements, fmt.Sprintf("%s notin (%s)", key, strings.Join(values, ",")))
+		case "Exists":
+			requirements = append(requirements, key)
+		case "DoesNotExist":
+			requirements = append(requirements, "!"+key)
+		}
+	}
+
+	return strings.Join(requirements, ",")
+}
+
+// text returns a string field of parent, or "".
+func text(parent map[string]interface{}, key string) string {
+	value, _ := parent[key].(string)
+
+	return value
+}
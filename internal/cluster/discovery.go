@@ -0,0 +1,201 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Resource represents an entry of an API group-version's discovery document.
+type Resource struct {
+	Name         string   `json:"name"`
+	SingularName string   `json:"singularName"`
+	ShortNames   []string `json:"shortNames"`
+	Kind         string   `json:"kind"`
+	Namespaced   bool     `json:"namespaced"`
+	Verbs        []string `json:"verbs"`
+}
+
+// matches reports whether a kubectl-style resource name (plural, singular, short-name, or kind) refers to the resource.
+func (r Resource) matches(name string) bool {
+	name = strings.ToLower(name)
+	if name == r.Name || name == r.SingularName || name == strings.ToLower(r.Kind) {
+		return true
+	}
+
+	return slices.Contains(r.ShortNames, name)
+}
+
+// Mapping resolves a kind to its REST resource.
+type Mapping struct {
+	Group      string // Group is the API group; empty for the core group.
+	Version    string // Version is the API version.
+	Kind       string // Kind is the resource's kind (e.g. "Deployment").
+	Resource   string // Resource is the REST resource's plural name (e.g. "deployments").
+	Namespaced bool   // Namespaced reports whether the resource is namespace-scoped.
+}
+
+// String returns the mapping's kubectl-style name (e.g. "deployment.apps").
+func (m Mapping) String() string {
+	if m.Group == "" {
+		return strings.ToLower(m.Kind)
+	}
+
+	return strings.ToLower(m.Kind) + "." + m.Group
+}
+
+// APIVersion returns the mapping's "<group>/<version>", or just the version for the core group.
+func (m Mapping) APIVersion() string {
+	if m.Group == "" {
+		return m.Version
+	}
+
+	return m.Group + "/" + m.Version
+}
+
+// Path returns the REST path of the mapping's collection (when name is empty) or of a named resource. The namespace
+// is ignored for cluster-scoped resources, and an empty namespace addresses every namespace.
+func (m Mapping) Path(namespace, name string) string {
+	path := "/apis/" + m.Group + "/" + m.Version
+	if m.Group == "" {
+		path = "/api/" + m.Version
+	}
+
+	if m.Namespaced && namespace != "" {
+		path += "/namespaces/" + url.PathEscape(namespace)
+	}
+
+	path += "/" + m.Resource
+
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+
+	return path
+}
+
+// Resources returns the discovery document of an API group-version (e.g. "apps/v1"), caching the result. Set
+// refresh to bypass the cache (e.g. after a CustomResourceDefinition has been applied).
+func (c *Client) Resources(ctx context.Context, groupVersion string, refresh bool) ([]Resource, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.discovery == nil {
+		c.discovery = make(map[string][]Resource)
+	}
+
+	if resources, cached := c.discovery[groupVersion]; cached && !(refresh) {
+		return resources, nil
+	}
+
+	path := "/apis/" + groupVersion
+	if !(strings.Contains(groupVersion, "/")) {
+		path = "/api/" + groupVersion
+	}
+
+	content, e := c.Request(ctx, http.MethodGet, path, nil, "", nil)
+	if NotFound(e) {
+		c.discovery[groupVersion] = nil
+
+		return nil, nil
+	} else if e != nil {
+		e = fmt.Errorf("unable to discover %s: %w", groupVersion, e)
+		return nil, e
+	}
+
+	var list struct {
+		Resources []Resource `json:"resources"`
+	}
+
+	if e := json.Unmarshal(content, &list); e != nil {
+		e = fmt.Errorf("unable to parse discovery of %s: %w", groupVersion, e)
+		return nil, e
+	}
+
+	c.discovery[groupVersion] = list.Resources
+
+	return list.Resources, nil
+}
+
+// Mapping discovers the REST resource of an apiVersion and kind. The discovery cache is refreshed once on a miss, so
+// that custom resources whose definition was applied earlier in the same run are found.
+func (c *Client) Mapping(ctx context.Context, apiVersion, kind string) (Mapping, error) {
+	group, version := manifests.Split(apiVersion)
+
+	for _, refresh := range []bool{false, true} {
+		resources, e := c.Resources(ctx, apiVersion, refresh)
+		if e != nil {
+			return Mapping{}, e
+		}
+
+		for _, resource := range resources {
+			// --> sub-resources (e.g. "deployments/scale") share their parent's kind
+			if resource.Kind == kind && !(strings.Contains(resource.Name, "/")) {
+				return Mapping{Group: group, Version: version, Kind: kind, Resource: resource.Name, Namespaced: resource.Namespaced}, nil
+			}
+		}
+	}
+
+	return Mapping{}, fmt.Errorf("no resource mapping for %s, kind %s - is its CustomResourceDefinition installed?", apiVersion, kind)
+}
+
+// Find resolves a kubectl-style resource name - plural, singular, short-name, or kind, optionally qualified by its
+// group (e.g. "deploy", "deployments.apps", "certificate.cert-manager.io") - to the mapping of the group's preferred
+// version. The core group is searched first.
+func (c *Client) Find(ctx context.Context, name string) (Mapping, error) {
+	resource, group, qualified := strings.Cut(name, ".")
+
+	content, e := c.Request(ctx, http.MethodGet, "/apis", nil, "", nil)
+	if e != nil {
+		e = fmt.Errorf("unable to discover api groups: %w", e)
+		return Mapping{}, e
+	}
+
+	var list struct {
+		Groups []struct {
+			Name      string `json:"name"`
+			Preferred struct {
+				GroupVersion string `json:"groupVersion"`
+			} `json:"preferredVersion"`
+		} `json:"groups"`
+	}
+
+	if e := json.Unmarshal(content, &list); e != nil {
+		e = fmt.Errorf("unable to parse api groups: %w", e)
+		return Mapping{}, e
+	}
+
+	var versions []string
+	if !(qualified) {
+		versions = append(versions, "v1")
+	}
+
+	for _, entry := range list.Groups {
+		if !(qualified) || entry.Name == group {
+			versions = append(versions, entry.Preferred.GroupVersion)
+		}
+	}
+
+	for _, version := range versions {
+		resources, e := c.Resources(ctx, version, false)
+		if e != nil {
+			return Mapping{}, e
+		}
+
+		for _, candidate := range resources {
+			if !(strings.Contains(candidate.Name, "/")) && candidate.matches(resource) {
+				group, version := manifests.Split(version)
+
+				return Mapping{Group: group, Version: version, Kind: candidate.Kind, Resource: candidate.Name, Namespaced: candidate.Namespaced}, nil
+			}
+		}
+	}
+
+	return Mapping{}, fmt.Errorf("the server doesn't have a resource type %q", name)
+}
@@ -0,0 +1,3 @@
+// Package cluster is a minimal kubernetes REST API client - discovery, server-side apply, list, delete, and watch -
+// configured from a kubeconfig.
+package cluster
@@ -0,0 +1,281 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// step is a single segment of a parsed JSONPath expression.
+type step func(values []interface{}) ([]interface{}, error)
+
+// JSONPath evaluates a kubectl-style JSONPath expression (e.g. "{.status.conditions[?(@.type=="Ready")].status}")
+// against a decoded JSON value. Supported are field access (including "\."-escaped and bracket-quoted keys), indices,
+// "*" wildcards, and "[?(@.field==value)]" filters; recursive descent and slices aren't.
+func JSONPath(value interface{}, expression string) ([]interface{}, error) {
+	steps, e := parse(expression)
+	if e != nil {
+		e = fmt.Errorf("invalid jsonpath (%s): %w", expression, e)
+		return nil, e
+	}
+
+	values := []interface{}{value}
+	for _, evaluate := range steps {
+		if values, e = evaluate(values); e != nil {
+			e = fmt.Errorf("invalid jsonpath (%s): %w", expression, e)
+			return nil, e
+		}
+	}
+
+	return values, nil
+}
+
+// Render formats a JSONPath result as kubectl would - strings verbatim, other scalars in their JSON form.
+func Render(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+
+	content, _ := json.Marshal(value)
+
+	return string(content)
+}
+
+// parse compiles an expression into its steps.
+func parse(expression string) ([]step, error) {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "{") && strings.HasSuffix(expression, "}") {
+		expression = strings.TrimSpace(expression[1 : len(expression)-1])
+	}
+
+	expression = strings.TrimPrefix(expression, "$")
+
+	var steps []step
+	for index := 0; index < len(expression); {
+		switch expression[index] {
+		case '.':
+			index++
+			if index < len(expression) && expression[index] == '.' {
+				return nil, fmt.Errorf("recursive descent isn't supported")
+			}
+
+			var name strings.Builder
+			for ; index < len(expression) && expression[index] != '.' && expression[index] != '['; index++ {
+				if expression[index] == '\\' && index+1 < len(expression) {
+					index++
+				}
+
+				name.WriteByte(expression[index])
+			}
+
+			switch name.String() {
+			case "":
+				if index < len(expression) && expression[index] != '[' {
+					return nil, fmt.Errorf("empty field name at offset %d", index)
+				}
+			case "*":
+				steps = append(steps, wildcard)
+			default:
+				steps = append(steps, member(name.String()))
+			}
+		case '[':
+			end := closing(expression, index)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated \"[\" at offset %d", index)
+			}
+
+			partial, e := bracket(strings.TrimSpace(expression[index+1 : end]))
+			if e != nil {
+				return nil, e
+			}
+
+			steps = append(steps, partial)
+			index = end + 1
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", expression[index], index)
+		}
+	}
+
+	return steps, nil
+}
+
+// closing returns the index of the "]" matching the "[" at start, skipping quoted strings and nested brackets.
+func closing(expression string, start int) int {
+	var depth int
+	var quote byte
+	for index := start; index < len(expression); index++ {
+		character := expression[index]
+		switch {
+		case quote != 0:
+			if character == quote {
+				quote = 0
+			}
+		case character == '\'' || character == '"':
+			quote = character
+		case character == '[' || character == '(':
+			depth++
+		case character == ']' || character == ')':
+			depth--
+			if depth == 0 {
+				return index
+			}
+		}
+	}
+
+	return -1
+}
+
+// bracket compiles the content of a "[...]" segment.
+func bracket(content string) (step, error) {
+	switch {
+	case content == "*":
+		return wildcard, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		return filter(strings.TrimSpace(content[2 : len(content)-1]))
+	case len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0]:
+		return member(content[1 : len(content)-1]), nil
+	case strings.Contains(content, ":"):
+		return nil, fmt.Errorf("slices aren't supported")
+	}
+
+	position, e := strconv.Atoi(content)
+	if e != nil {
+		return nil, fmt.Errorf("invalid index %q", content)
+	}
+
+	return func(values []interface{}) ([]interface{}, error) {
+		var results []interface{}
+		for _, value := range values {
+			list, valid := value.([]interface{})
+			if !(valid) {
+				continue
+			}
+
+			index := position
+			if index < 0 {
+				index += len(list)
+			}
+
+			if index >= 0 && index < len(list) {
+				results = append(results, list[index])
+			}
+		}
+
+		return results, nil
+	}, nil
+}
+
+// member selects a key of each map value.
+func member(name string) step {
+	return func(values []interface{}) ([]interface{}, error) {
+		var results []interface{}
+		for _, value := range values {
+			if object, valid := value.(map[string]interface{}); valid {
+				if child, exists := object[name]; exists {
+					results = append(results, child)
+				}
+			}
+		}
+
+		return results, nil
+	}
+}
+
+// wildcard selects every element of lists, and every value (ordered by key) of maps.
+func wildcard(values []interface{}) ([]interface{}, error) {
+	var results []interface{}
+	for _, value := range values {
+		switch value := value.(type) {
+		case []interface{}:
+			results = append(results, value...)
+		case map[string]interface{}:
+			keys := make([]string, 0, len(value))
+			for key := range value {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				results = append(results, value[key])
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// filter compiles a "@.path", "@.path==literal", or "@.path!=literal" predicate selecting list elements.
+func filter(predicate string) (step, error) {
+	operator := ""
+	left, right := predicate, ""
+	for _, candidate := range []string{"==", "!="} {
+		if index := strings.Index(predicate, candidate); index >= 0 {
+			operator, left, right = candidate, strings.TrimSpace(predicate[:index]), strings.TrimSpace(predicate[index+2:])
+			break
+		}
+	}
+
+	if !(strings.HasPrefix(left, "@")) {
+		return nil, fmt.Errorf("unsupported filter %q", predicate)
+	}
+
+	path, e := parse(left[1:])
+	if e != nil {
+		return nil, e
+	}
+
+	var literal interface{}
+	if operator != "" {
+		switch {
+		case len(right) >= 2 && (right[0] == '\'' || right[0] == '"') && right[len(right)-1] == right[0]:
+			literal = right[1 : len(right)-1]
+		default:
+			if e := json.Unmarshal([]byte(right), &literal); e != nil {
+				return nil, fmt.Errorf("invalid filter literal %q", right)
+			}
+		}
+	}
+
+	return func(values []interface{}) ([]interface{}, error) {
+		var results []interface{}
+		for _, value := range values {
+			list, valid := value.([]interface{})
+			if !(valid) {
+				continue
+			}
+
+			for _, element := range list {
+				matches := []interface{}{element}
+				for _, evaluate := range path {
+					if matches, e = evaluate(matches); e != nil {
+						return nil, e
+					}
+				}
+
+				var selected bool
+				switch operator {
+				case "":
+					selected = len(matches) > 0
+				case "==":
+					selected = len(matches) > 0 && Render(matches[0]) == Render(literal)
+				case "!=":
+					selected = len(matches) == 0 || Render(matches[0]) != Render(literal)
+				}
+
+				if selected {
+					results = append(results, element)
+				}
+			}
+		}
+
+		return results, nil
+	}, nil
+}
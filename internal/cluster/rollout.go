@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rollouts are the kinds with a rollout status, mapped to their evaluation - mirroring "kubectl rollout status".
+var rollouts = map[string]func(object Object) (bool, string, error){
+	"Deployment":  deployment,
+	"StatefulSet": statefulset,
+	"DaemonSet":   daemonset,
+}
+
+// Rollout waits for a workload's rollout to complete.
+type Rollout struct {
+	Kind string
+}
+
+func (r Rollout) String() string {
+	return "rollout"
+}
+
+func (r Rollout) Evaluate(object Object) (bool, string, error) {
+	return rollouts[r.Kind](object)
+}
+
+// deployment evaluates a Deployment's rollout from its observedGeneration, updatedReplicas, and availableReplicas.
+func deployment(object Object) (bool, string, error) {
+	name := object.String("name")
+
+	if !(observed(object)) {
+		return false, "waiting for the deployment spec update to be observed", nil
+	}
+
+	status := field(object, "status")
+	conditions, _ := status["conditions"].([]interface{})
+	for _, entry := range conditions {
+		condition, _ := entry.(map[string]interface{})
+		if condition["type"] == "Progressing" && condition["reason"] == "ProgressDeadlineExceeded" {
+			return false, "", fmt.Errorf("deployment %q exceeded its progress deadline", name)
+		}
+	}
+
+	replicas := integer(field(object, "spec"), "replicas", 1)
+	updated, total, available := integer(status, "updatedReplicas", 0), integer(status, "replicas", 0), integer(status, "availableReplicas", 0)
+
+	switch {
+	case updated < replicas:
+		return false, fmt.Sprintf("%d out of %d new replicas have been updated", updated, replicas), nil
+	case total > updated:
+		return false, fmt.Sprintf("%d old replicas are pending termination", total-updated), nil
+	case available < updated:
+		return false, fmt.Sprintf("%d of %d updated replicas are available", available, updated), nil
+	}
+
+	return true, "successfully rolled out", nil
+}
+
+// statefulset evaluates a StatefulSet's rollout.
+func statefulset(object Object) (bool, string, error) {
+	spec, status := field(object, "spec"), field(object, "status")
+
+	strategy, _ := field(spec, "updateStrategy")["type"].(string)
+	if strings.EqualFold(strategy, "OnDelete") {
+		return false, "", fmt.Errorf("statefulset %q uses the OnDelete update strategy, which has no rollout status", object.String("name"))
+	}
+
+	if !(observed(object)) {
+		return false, "waiting for the statefulset spec update to be observed", nil
+	}
+
+	replicas := integer(spec, "replicas", 1)
+	ready := integer(status, "readyReplicas", 0)
+	if ready < replicas {
+		return false, fmt.Sprintf("%d of %d pods are ready", ready, replicas), nil
+	}
+
+	if partition := integer(field(field(spec, "updateStrategy"), "rollingUpdate"), "partition", 0); partition > 0 {
+		if updated := integer(status, "updatedReplicas", 0); updated < replicas-partition {
+			return false, fmt.Sprintf("%d of %d pods above the partition have been updated", updated, replicas-partition), nil
+		}
+
+		return true, fmt.Sprintf("partitioned roll out complete: %d new pods have been updated", replicas-partition), nil
+	}
+
+	if current, update := status["currentRevision"], status["updateRevision"]; current != update {
+		return false, fmt.Sprintf("%d of %d pods have been updated to revision %v", integer(status, "updatedReplicas", 0), replicas, update), nil
+	}
+
+	return true, "successfully rolled out", nil
+}
+
+// daemonset evaluates a DaemonSet's rollout.
+func daemonset(object Object) (bool, string, error) {
+	strategy, _ := field(field(object, "spec"), "updateStrategy")["type"].(string)
+	if strings.EqualFold(strategy, "OnDelete") {
+		return false, "", fmt.Errorf("daemonset %q uses the OnDelete update strategy, which has no rollout status", object.String("name"))
+	}
+
+	if !(observed(object)) {
+		return false, "waiting for the daemonset spec update to be observed", nil
+	}
+
+	status := field(object, "status")
+	desired, updated, available := integer(status, "desiredNumberScheduled", 0), integer(status, "updatedNumberScheduled", 0), integer(status, "numberAvailable", 0)
+
+	switch {
+	case updated < desired:
+		return false, fmt.Sprintf("%d out of %d new pods have been updated", updated, desired), nil
+	case available < desired:
+		return false, fmt.Sprintf("%d of %d updated pods are available", available, desired), nil
+	}
+
+	return true, "successfully rolled out", nil
+}
+
+// observed reports whether an object's controller has observed its latest generation.
+func observed(object Object) bool {
+	return integer(object.Metadata(), "generation", 0) <= integer(field(object, "status"), "observedGeneration", 0)
+}
+
+// field returns a nested map of parent, or nil.
+func field(parent map[string]interface{}, key string) map[string]interface{} {
+	child, _ := parent[key].(map[string]interface{})
+
+	return child
+}
+
+// integer returns a numeric field of parent, or fallback when it's unset.
+func integer(parent map[string]interface{}, key string, fallback int64) int64 {
+	value, valid := parent[key].(float64)
+	if !(valid) {
+		return fallback
+	}
+
+	return int64(value)
+}
@@ -0,0 +1,274 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Condition evaluates whether an object has reached a desired state.
+type Condition interface {
+	// Evaluate reports whether the condition is met, with a description of the object's progress. An error means the
+	// condition can never be met (e.g. a rollout exceeded its progress deadline).
+	Evaluate(object Object) (met bool, progress string, e error)
+
+	// String describes the condition (e.g. "condition=Ready").
+	String() string
+}
+
+// Parse creates a [Condition] from a kubectl-style "--for" value: "condition=<type>[=<status>]" or
+// "jsonpath=<expression>[=<value>]". An empty value waits for the rollout of a Deployment, StatefulSet, or DaemonSet.
+func Parse(kind, value string) (Condition, error) {
+	prefix, expression, _ := strings.Cut(value, "=")
+	switch strings.ToLower(prefix) {
+	case "":
+		if _, supported := rollouts[kind]; !(supported) {
+			return nil, fmt.Errorf("%s has no rollout - specify a condition to wait for (--for condition=<type> or --for jsonpath=<expression>)", kind)
+		}
+
+		return Rollout{Kind: kind}, nil
+	case "condition":
+		name, status, specified := strings.Cut(expression, "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid condition (%s) - expected condition=<type>[=<status>]", value)
+		}
+
+		if !(specified) {
+			status = "True"
+		}
+
+		return Status{Type: name, Status: status}, nil
+	case "jsonpath":
+		path, literal, specified := split(expression)
+		if path == "" {
+			return nil, fmt.Errorf("invalid jsonpath condition (%s) - expected jsonpath=<expression>[=<value>]", value)
+		}
+
+		if _, e := JSONPath(nil, path); e != nil {
+			return nil, e
+		}
+
+		return Path{Expression: path, Value: literal, Compare: specified}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported condition (%s) - expected condition=<type>[=<status>] or jsonpath=<expression>[=<value>]", value)
+}
+
+// split separates a jsonpath expression from its expected value - the first "=" outside of brackets, braces, and
+// quotes that isn't part of a "==" or "!=" operator.
+func split(expression string) (path, value string, specified bool) {
+	var depth int
+	var quote byte
+	for index := 0; index < len(expression); index++ {
+		character := expression[index]
+		switch {
+		case quote != 0:
+			if character == quote {
+				quote = 0
+			}
+		case character == '\'' || character == '"':
+			quote = character
+		case strings.ContainsRune("[({", rune(character)):
+			depth++
+		case strings.ContainsRune("])}", rune(character)):
+			depth--
+		case character == '=' && depth == 0:
+			return expression[:index], expression[index+1:], true
+		}
+	}
+
+	return expression, "", false
+}
+
+// Status waits for an entry of "status.conditions".
+type Status struct {
+	Type   string // Type is the condition's type, matched case-insensitively (e.g. "Ready").
+	Status string // Status is the desired status (e.g. "True").
+}
+
+func (s Status) String() string {
+	return fmt.Sprintf("condition=%s=%s", s.Type, s.Status)
+}
+
+func (s Status) Evaluate(object Object) (bool, string, error) {
+	status, _ := object["status"].(map[string]interface{})
+	conditions, _ := status["conditions"].([]interface{})
+	for _, entry := range conditions {
+		condition, _ := entry.(map[string]interface{})
+		if kind, _ := condition["type"].(string); strings.EqualFold(kind, s.Type) {
+			current, _ := condition["status"].(string)
+			progress := fmt.Sprintf("%s is %s", kind, current)
+			if message, _ := condition["message"].(string); message != "" {
+				progress += ": " + message
+			}
+
+			return strings.EqualFold(current, s.Status), progress, nil
+		}
+	}
+
+	return false, fmt.Sprintf("waiting for condition %s", s.Type), nil
+}
+
+// Path waits for a JSONPath expression to exist or, when Compare is set, to equal Value.
+type Path struct {
+	Expression string
+	Value      string
+	Compare    bool
+}
+
+func (p Path) String() string {
+	if p.Compare {
+		return fmt.Sprintf("jsonpath=%s=%s", p.Expression, p.Value)
+	}
+
+	return fmt.Sprintf("jsonpath=%s", p.Expression)
+}
+
+func (p Path) Evaluate(object Object) (bool, string, error) {
+	values, e := JSONPath(map[string]interface{}(object), p.Expression)
+	if e != nil {
+		return false, "", e
+	}
+
+	if len(values) == 0 {
+		return false, fmt.Sprintf("%s not found", p.Expression), nil
+	}
+
+	rendered := make([]string, len(values))
+	for index, value := range values {
+		rendered[index] = Render(value)
+	}
+
+	progress := fmt.Sprintf("%s is %q", p.Expression, strings.Join(rendered, " "))
+	if !(p.Compare) {
+		return true, progress, nil
+	}
+
+	for _, value := range rendered {
+		if value != p.Value {
+			return false, progress, nil
+		}
+	}
+
+	return true, progress, nil
+}
+
+// Wait blocks until condition is met by a named resource, calling progress (if set) whenever the condition's
+// description of the resource changes. The resource is followed with the watch API, re-listing when the stream ends
+// or its resource version expires, and may not exist yet. The last observed state of the resource is returned along
+// with any error - including ctx's, once it's done.
+func (c *Client) Wait(ctx context.Context, mapping Mapping, namespace, name string, condition Condition, progress func(string)) (Object, error) {
+	var last Object
+	var previous string
+
+	evaluate := func(object Object) (bool, error) {
+		last = object
+
+		met, description, e := condition.Evaluate(object)
+		if description != previous && progress != nil {
+			progress(description)
+		}
+
+		previous = description
+
+		return met, e
+	}
+
+	for {
+		var version string
+
+		object, e := c.Get(ctx, mapping, namespace, name)
+		switch {
+		case NotFound(e):
+			if previous != "waiting for creation" && progress != nil {
+				progress("waiting for creation")
+			}
+
+			previous = "waiting for creation"
+			last = nil
+
+			// --> watch from the collection's version, so that a creation in between isn't missed
+			query := url.Values{}
+			query.Set("fieldSelector", "metadata.name="+name)
+
+			content, e := c.Request(ctx, http.MethodGet, mapping.Path(namespace, ""), query, "", nil)
+			if e != nil {
+				return last, e
+			}
+
+			var list Object
+			if e := json.Unmarshal(content, &list); e != nil {
+				return last, e
+			}
+
+			version = list.String("resourceVersion")
+		case e != nil:
+			return last, e
+		default:
+			if met, e := evaluate(object); met || e != nil {
+				return last, e
+			}
+
+			version = object.String("resourceVersion")
+		}
+
+		watcher, e := c.Watch(ctx, mapping, namespace, name, version)
+		if e != nil {
+			return last, e
+		}
+
+		met, e := follow(watcher, evaluate)
+
+		watcher.Close()
+
+		switch {
+		case met:
+			return last, nil
+		case ctx.Err() != nil:
+			return last, ctx.Err()
+		case e == nil, errors.Is(e, io.EOF), errors.Is(e, io.ErrUnexpectedEOF):
+			// --> the API server closed the stream (e.g. its watch timeout) - re-list, and resume after a pause
+			select {
+			case <-ctx.Done():
+				return last, ctx.Err()
+			case <-time.After(time.Second):
+			}
+		case isExpired(e):
+			// --> the resource version expired - re-list, and resume from a current version
+		default:
+			return last, e
+		}
+	}
+}
+
+// follow evaluates each change of a watch stream until the condition is met or the stream fails.
+func follow(watcher *Watcher, evaluate func(Object) (bool, error)) (bool, error) {
+	for {
+		change, e := watcher.Next()
+		if e != nil {
+			return false, e
+		}
+
+		switch change.Type {
+		case "ADDED", "MODIFIED":
+			if met, e := evaluate(change.Object); met || e != nil {
+				return met, e
+			}
+		case "DELETED":
+			return false, fmt.Errorf("%s was deleted while waiting", change.Object.String("name"))
+		}
+	}
+}
+
+// isExpired reports whether e is a watch's "410 Gone" (expired resource version) error.
+func isExpired(e error) bool {
+	var exception *Error
+
+	return errors.As(e, &exception) && exception.Code == http.StatusGone
+}
@@ -0,0 +1,132 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// pod returns a Pod object whose Ready condition has status.
+func pod(name, status string) Object {
+	return Object{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"status":     map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": status}}},
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		kind     string
+		value    string
+		expected string
+		valid    bool
+	}{
+		{kind: "Deployment", value: "", expected: "rollout", valid: true},
+		{kind: "Pod", value: "", valid: false},
+		{kind: "Pod", value: "condition=Ready", expected: "condition=Ready=True", valid: true},
+		{kind: "Job", value: "condition=Failed=False", expected: "condition=Failed=False", valid: true},
+		{kind: "Pod", value: "condition=", valid: false},
+		{kind: "Pod", value: `jsonpath={.status.conditions[?(@.type=="Ready")].status}=True`, expected: `jsonpath={.status.conditions[?(@.type=="Ready")].status}=True`, valid: true},
+		{kind: "Pod", value: "jsonpath={.status.phase}", expected: "jsonpath={.status.phase}", valid: true},
+		{kind: "Pod", value: "delete", valid: false},
+	}
+
+	for _, test := range tests {
+		condition, e := Parse(test.kind, test.value)
+		if test.valid && (e != nil || condition.String() != test.expected) {
+			t.Errorf("Parse(%s, %q) = (%v, %v), expected %s", test.kind, test.value, condition, e, test.expected)
+		}
+
+		if !(test.valid) && e == nil {
+			t.Errorf("Parse(%s, %q) = %s, expected an error", test.kind, test.value, condition)
+		}
+	}
+}
+
+func TestClientWait(t *testing.T) {
+	mapping := Mapping{Version: "v1", Kind: "Pod", Resource: "pods", Namespaced: true}
+
+	condition := Status{Type: "Ready", Status: "True"}
+
+	tests := []struct {
+		name     string
+		existing Object   // existing is the pod's state before the wait, if any
+		changes  []Change // changes are streamed by the watch
+		progress []string
+		expected error // expected is the wait's error; nil when the condition is met
+	}{
+		{
+			name:     "met",
+			existing: pod("example", "True"),
+			progress: []string{"Ready is True"},
+		},
+		{
+			name:     "watched",
+			existing: pod("example", "False"),
+			changes:  []Change{{Type: "MODIFIED", Object: pod("example", "False")}, {Type: "MODIFIED", Object: pod("example", "True")}},
+			progress: []string{"Ready is False", "Ready is True"},
+		},
+		{
+			name:     "created",
+			changes:  []Change{{Type: "ADDED", Object: pod("example", "True")}},
+			progress: []string{"waiting for creation", "Ready is True"},
+		},
+		{
+			name:     "timeout",
+			existing: pod("example", "False"),
+			progress: []string{"Ready is False"},
+			expected: context.DeadlineExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, client := serve(t)
+
+			if test.existing != nil {
+				server.store("/api/v1/namespaces/default/pods/example", test.existing)
+			}
+
+			server.changes = test.changes
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			var progress []string
+			object, e := client.Wait(ctx, mapping, "default", "example", condition, func(description string) {
+				progress = append(progress, description)
+			})
+
+			if !(errors.Is(e, test.expected)) {
+				t.Fatalf("Wait() = %v, expected %v", e, test.expected)
+			}
+
+			if object == nil || object.String("name") != "example" {
+				t.Errorf("Wait() = %v, expected the last observed state of the pod", object)
+			}
+
+			if !(slices.Equal(progress, test.progress)) {
+				t.Errorf("Wait() progress = %q, expected %q", progress, test.progress)
+			}
+		})
+	}
+}
+
+func TestClientWaitDeleted(t *testing.T) {
+	server, client := serve(t)
+
+	server.store("/api/v1/namespaces/default/pods/example", pod("example", "False"))
+	server.changes = []Change{{Type: "DELETED", Object: pod("example", "False")}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, e := client.Wait(ctx, Mapping{Version: "v1", Kind: "Pod", Resource: "pods", Namespaced: true}, "default", "example", Status{Type: "Ready", Status: "True"}, nil)
+	if e == nil || errors.Is(e, context.DeadlineExceeded) {
+		t.Errorf("Wait() = %v, expected the deletion to be reported", e)
+	}
+}
@@ -0,0 +1,78 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Change represents a single event of a watch stream.
+type Change struct {
+	Type   string `json:"type"` // Type is one of "ADDED", "MODIFIED", "DELETED", or "BOOKMARK".
+	Object Object `json:"object"`
+}
+
+// Watcher represents an open watch stream.
+type Watcher struct {
+	response *http.Response
+	decoder  *json.Decoder
+}
+
+// Watch opens a watch stream of a mapping's resources in namespace, starting after resourceVersion (if set). The
+// stream is limited to a single resource when name is set. Close the [Watcher] once done.
+func (c *Client) Watch(ctx context.Context, mapping Mapping, namespace, name, resourceVersion string) (*Watcher, error) {
+	query := url.Values{}
+	query.Set("watch", "true")
+	query.Set("allowWatchBookmarks", "true")
+	if name != "" {
+		query.Set("fieldSelector", "metadata.name="+name)
+	}
+
+	if resourceVersion != "" {
+		query.Set("resourceVersion", resourceVersion)
+	}
+
+	response, e := c.open(ctx, http.MethodGet, mapping.Path(namespace, ""), query, "", nil)
+	if e != nil {
+		e = fmt.Errorf("unable to watch %s: %w", mapping, e)
+		return nil, e
+	}
+
+	return &Watcher{response: response, decoder: json.NewDecoder(response.Body)}, nil
+}
+
+// Next blocks until the stream's next change. An "ERROR" event (e.g. an expired resource version) is returned as an
+// [*Error], and [io.EOF] once the API server closes the stream.
+func (w *Watcher) Next() (Change, error) {
+	var event struct {
+		Type   string          `json:"type"`
+		Object json.RawMessage `json:"object"`
+	}
+
+	if e := w.decoder.Decode(&event); e != nil {
+		return Change{}, e
+	}
+
+	if event.Type == "ERROR" {
+		exception := &Error{}
+		if e := json.Unmarshal(event.Object, exception); e != nil {
+			return Change{}, e
+		}
+
+		return Change{}, exception
+	}
+
+	change := Change{Type: event.Type}
+	if e := json.Unmarshal(event.Object, &change.Object); e != nil {
+		return Change{}, e
+	}
+
+	return change, nil
+}
+
+// Close closes the stream.
+func (w *Watcher) Close() error {
+	return w.response.Body.Close()
+}
@@ -69,6 +69,8 @@ var Command = &cobra.Command{
 			}
 
 			fmt.Printf("%s", string(buffer))
+		case output.Text:
+			fmt.Printf("name: %s\noutput: %s\n", datum["name"], datum["output"])
 		}
 
 		return nil
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -9,11 +10,13 @@ import (
 	"github.com/x-ethr/ethr-cli/internal/commands/ecdsa"
 	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes"
 	"github.com/x-ethr/ethr-cli/internal/commands/random"
+	"github.com/x-ethr/ethr-cli/internal/diff"
 )
 
-// Execute runs the root command and handles any CLI execution exception. Additionally,
-// all child command(s) are added to the root command.
-func Execute(root *cobra.Command) {
+// Execute runs the root command and handles any CLI execution exception - reported to standard-error - returning the
+// process's exit code. Differences found by a diff aren't reported, but also exit non-zero. Additionally, all child
+// command(s) are added to the root command.
+func Execute(root *cobra.Command) int {
 	// root.AddCommand(example.Command)
 
 	root.AddCommand(kubernetes.Command)
@@ -21,10 +24,18 @@ func Execute(root *cobra.Command) {
 	root.AddCommand(random.Command)
 
 	if e := root.Execute(); e != nil {
+		if errors.Is(e, diff.ErrDifferences) {
+			return 1
+		}
+
 		color.Color().Bold(
 			color.Color().Red("error"),
 		).Default("-").Italic(
 			color.Color().White(e.Error()),
-		).Write(os.Stdout)
+		).Write(os.Stderr)
+
+		return 1
 	}
+
+	return 0
 }
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// capture redirects standard-output and standard-error while fn runs, returning what was written to each.
+func capture(t *testing.T, fn func()) (stdout, stderr string) {
+	t.Helper()
+
+	streams := [2]*os.File{os.Stdout, os.Stderr}
+	defer func() { os.Stdout, os.Stderr = streams[0], streams[1] }()
+
+	var buffers [2]bytes.Buffer
+	var pipes [2]*os.File
+	done := make(chan struct{}, 2)
+	for index := range pipes {
+		reader, writer, e := os.Pipe()
+		if e != nil {
+			t.Fatalf("unable to create pipe: %v", e)
+		}
+
+		pipes[index] = writer
+		go func(index int) {
+			_, _ = io.Copy(&buffers[index], reader)
+			done <- struct{}{}
+		}(index)
+	}
+
+	os.Stdout, os.Stderr = pipes[0], pipes[1]
+
+	fn()
+
+	for _, pipe := range pipes {
+		pipe.Close()
+	}
+
+	<-done
+	<-done
+
+	return buffers[0].String(), buffers[1].String()
+}
+
+func TestExecute(t *testing.T) {
+	directory := t.TempDir()
+
+	manifest := filepath.Join(directory, "deployment.yaml")
+	if e := os.WriteFile(manifest, []byte(strings.Join([]string{
+		"apiVersion: apps/v1",
+		"kind: Deployment",
+		"metadata:",
+		"    name: example",
+		"spec:",
+		"    template:",
+		"        spec:",
+		"            containers:",
+		"                - name: example",
+		"                  image: example:latest",
+	}, "\n")), 0o644); e != nil {
+		t.Fatalf("unable to write manifest: %v", e)
+	}
+
+	tests := []struct {
+		name      string
+		arguments []string
+		code      int
+		stderr    string
+	}{
+		{name: "success", arguments: []string{"kubernetes", "images", "--file", manifest}, code: 0},
+		{name: "failing-lint", arguments: []string{"kubernetes", "lint", "--file", manifest}, code: 1, stderr: "lint failed with 1 error(s)"},
+		{name: "invalid-flag", arguments: []string{"kubernetes", "pss", "check", "--file", manifest, "--level", "privileged"}, code: 1, stderr: "invalid argument"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := &cobra.Command{Use: "ethr", SilenceErrors: true, SilenceUsage: true, TraverseChildren: true}
+			root.SetArgs(test.arguments)
+
+			var code int
+			stdout, stderr := capture(t, func() {
+				code = Execute(root)
+			})
+
+			if code != test.code {
+				t.Errorf("Execute() = %d, expected %d (stdout: %q, stderr: %q)", code, test.code, stdout, stderr)
+			}
+
+			if test.stderr != "" {
+				if !(strings.Contains(stderr, test.stderr)) {
+					t.Errorf("standard-error = %q, expected it to contain %q", stderr, test.stderr)
+				}
+
+				if strings.Contains(stdout, test.stderr) {
+					t.Errorf("standard-output = %q, expected the error to be excluded", stdout)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteDiff(t *testing.T) {
+	directory := t.TempDir()
+
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n    name: example\ndata:\n    level: %s\n"
+
+	paths := make(map[string]string)
+	for _, level := range []string{"info", "debug"} {
+		paths[level] = filepath.Join(directory, level+".yaml")
+		if e := os.WriteFile(paths[level], []byte(fmt.Sprintf(manifest, level)), 0o644); e != nil {
+			t.Fatalf("unable to write manifest: %v", e)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		arguments []string
+		code      int
+	}{
+		{name: "identical", arguments: []string{"kubernetes", "diff", paths["info"], paths["info"]}, code: 0},
+		{name: "different", arguments: []string{"kubernetes", "diff", paths["info"], paths["debug"]}, code: 1},
+		{name: "different-json", arguments: []string{"kubernetes", "diff", paths["info"], paths["debug"], "--output", "json"}, code: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := &cobra.Command{Use: "ethr", SilenceErrors: true, SilenceUsage: true, TraverseChildren: true}
+			root.SetArgs(test.arguments)
+
+			var code int
+			stdout, stderr := capture(t, func() {
+				code = Execute(root)
+			})
+
+			if code != test.code {
+				t.Errorf("Execute() = %d, expected %d (stdout: %q, stderr: %q)", code, test.code, stdout, stderr)
+			}
+
+			// --> differences aren't an error to report
+			if stderr != "" {
+				t.Errorf("standard-error = %q, expected it to be empty", stderr)
+			}
+		})
+	}
+}
+
+func TestExecuteWaitTimeout(t *testing.T) {
+	// --> an API server whose pod never becomes ready, and whose watch never reports a change
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/apis":
+			fmt.Fprint(w, `{"groups": []}`)
+		case r.URL.Path == "/api/v1":
+			fmt.Fprint(w, `{"resources": [{"name": "pods", "singularName": "pod", "kind": "Pod", "namespaced": true}]}`)
+		case r.URL.Path == "/api/v1/namespaces/default/pods/example":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"kind":     "Pod",
+				"metadata": map[string]interface{}{"name": "example", "namespace": "default", "resourceVersion": "1"},
+				"status":   map[string]interface{}{"phase": "Pending", "conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "False"}}},
+			})
+		case r.URL.Query().Get("watch") == "true":
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "code": 404, "reason": "NotFound", "message": "not found"}`)
+		}
+	}))
+
+	defer server.Close()
+
+	configuration := filepath.Join(t.TempDir(), "config")
+	if e := os.WriteFile(configuration, []byte(strings.Join([]string{
+		"apiVersion: v1",
+		"kind: Config",
+		"clusters: [ { name: test, cluster: { server: " + server.URL + " } } ]",
+		"users: [ { name: test, user: {} } ]",
+		"contexts: [ { name: test, context: { cluster: test, user: test } } ]",
+		"current-context: test",
+	}, "\n")), 0o600); e != nil {
+		t.Fatalf("unable to write kubeconfig: %v", e)
+	}
+
+	root := &cobra.Command{Use: "ethr", SilenceErrors: true, SilenceUsage: true, TraverseChildren: true}
+	root.SetArgs([]string{"kubernetes", "wait", "pod/example", "--for", "condition=Ready", "--timeout", "1s", "--kubeconfig", configuration})
+
+	var code int
+	_, stderr := capture(t, func() {
+		code = Execute(root)
+	})
+
+	if code != 1 {
+		t.Errorf("Execute() = %d, expected 1 (stderr: %q)", code, stderr)
+	}
+
+	if expected := "timed out waiting for pod/example (condition=Ready=True) after 1s"; !(strings.Contains(stderr, expected)) {
+		t.Errorf("standard-error = %q, expected it to contain %q", stderr, expected)
+	}
+}
@@ -0,0 +1,219 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/cluster"
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var Command = &cobra.Command{
+	Use:        "apply",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Server-Side Apply",
+	Long:       "Server-side applies manifests through the kubernetes API, using the kubeconfig's current (or --context) context. Resources are applied in dependency order (e.g. namespaces and custom resource definitions first), and each is reported as created, configured, or unchanged. With --prune, resources matching --selector that were previously applied by the same field manager, but are no longer part of the manifests, are deleted.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes apply --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Render, and apply, a kustomization overlay to a specific context"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes apply --kustomize ./test-data/update-image --context staging", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Validate server-side without persisting anything (dry-run)"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes apply --file ./manifests --dry-run", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Delete previously applied resources that were removed from the manifests"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes apply --file ./manifests --prune --selector app.kubernetes.io/part-of=example", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Apply from standard-input"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes kustomization build ./overlays/production | %s kubernetes apply --file -", constants.Name(), constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if prune && selector == "" {
+			return errors.New("--prune requires a --selector, so that only resources belonging to the manifests are deleted")
+		}
+
+		if manager == "" {
+			return errors.New("--field-manager can't be empty")
+		}
+
+		var documents []*manifests.Document
+		if len(files) > 0 {
+			partials, e := manifests.Read(files...)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		if kustomization != "" {
+			partials, e := manifests.Render(kustomization)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		var resources []*manifests.Document
+		for _, document := range documents {
+			if !(document.Empty()) && !(document.Kustomization()) {
+				resources = append(resources, document)
+			}
+		}
+
+		if len(resources) == 0 {
+			return errors.New("no resources to apply")
+		}
+
+		manifests.Sort(resources)
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(resources)))
+
+		client, e := connection.Client()
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Cluster", slog.String("server", client.Server), slog.String("namespace", client.Namespace))
+
+		ctx = context.WithValue(ctx, "documents", resources)
+		ctx = context.WithValue(ctx, "client", client)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		documents, client := ctx.Value("documents").([]*manifests.Document), ctx.Value("client").(*cluster.Client)
+
+		applier := &cluster.Applier{Client: client, Manager: manager, Force: force, DryRun: test}
+
+		var results []cluster.Result
+
+		var failure error
+		for _, document := range documents {
+			result, e := applier.Apply(ctx, document)
+			if e != nil {
+				failure = e
+				break
+			}
+
+			results = append(results, result)
+
+			if format == output.Text {
+				report(result)
+			}
+		}
+
+		// --> never prune after a failed apply, as the applied set is incomplete
+		if prune && failure == nil {
+			pruned, e := applier.Prune(ctx, selector, results)
+			for _, result := range pruned {
+				if format == output.Text {
+					report(result)
+				}
+			}
+
+			results = append(results, pruned...)
+			failure = e
+		}
+
+		switch format {
+		case output.JSON:
+			if results == nil {
+				results = []cluster.Result{}
+			}
+
+			content, e := marshalers.JSON(results)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(results)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		}
+
+		return failure
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+// report writes a result as a single, kubectl-style line.
+func report(result cluster.Result) {
+	line := color.Color().Bold(result.Resource)
+	if result.Namespace != "" {
+		line = line.Dim(fmt.Sprintf("(%s)", result.Namespace))
+	}
+
+	switch result.Action {
+	case cluster.Created:
+		line = line.Green(string(result.Action))
+	case cluster.Configured:
+		line = line.Yellow(string(result.Action))
+	case cluster.Pruned:
+		line = line.Red(string(result.Action))
+	default:
+		line = line.Dim(string(result.Action))
+	}
+
+	if test {
+		line = line.Dim("(server dry run)")
+	}
+
+	line.Write(os.Stdout)
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to apply - \"-\" reads from standard-input")
+	flags.StringVarP(&kustomization, "kustomize", "k", "", "render, and apply, a kustomization (file or directory)")
+	flags.StringVar(&manager, "field-manager", constants.Name(), "the field manager recorded against applied fields")
+	flags.BoolVar(&force, "force-conflicts", false, "take ownership of fields owned by another field manager instead of failing")
+	flags.BoolVar(&test, "dry-run", false, "have the api server validate and report the outcome without persisting it")
+	flags.BoolVar(&prune, "prune", false, "delete resources matching --selector that were applied by the field manager but are no longer part of the manifests")
+	flags.StringVarP(&selector, "selector", "l", "", "the label selector of resources eligible for pruning (e.g. \"app.kubernetes.io/part-of=example\")")
+	flags.Var(&format, "output", "the results' output format")
+
+	connection.Register(Command)
+
+	Command.MarkFlagsOneRequired("file", "kustomize")
+}
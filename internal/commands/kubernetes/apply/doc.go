@@ -0,0 +1,2 @@
+// Package apply provides the server-side apply sub-command.
+package apply
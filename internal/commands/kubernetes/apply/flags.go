@@ -0,0 +1,18 @@
+package apply
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/cluster"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files         []string           // files represents the manifest file(s) or directories to apply
+	kustomization string             // kustomization is an optional kustomization to render and apply
+	connection    cluster.Connection // connection selects the target cluster
+	manager       string             // manager is the server-side apply field manager
+	force         bool               = false
+	test          bool               = false
+	prune         bool               = false
+	selector      string             // selector is the label selector of resources eligible for pruning
+	format        output.Type        = output.Text
+)
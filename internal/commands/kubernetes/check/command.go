@@ -0,0 +1,29 @@
+package check
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/check/references"
+)
+
+var Command = &cobra.Command{
+	Use:                    "check",
+	Short:                  "Manifest Bundle Verification",
+	Long:                   "Verifies a bundle of manifests as a whole, rather than document by document.",
+	Aliases:                []string{},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(references.Command)
+}
@@ -0,0 +1,2 @@
+// Package check provides the manifest bundle verification sub-commands.
+package check
@@ -0,0 +1,152 @@
+package references
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/check"
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/lint"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var Command = &cobra.Command{
+	Use:        "references",
+	Aliases:    []string{"refs"},
+	SuggestFor: nil,
+	Short:      "Broken & Dangling Reference Check",
+	Long:       "Reports every broken or dangling reference between a bundle's resources - Service selectors that match no pod template, target ports that aren't a declared container port, and service accounts, config-maps, secrets, scale targets, ingress backends, and roles that aren't part of the bundle. Resources managed elsewhere can be declared with --external.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes check references --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Render, and check, a kustomization overlay"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes check references --kustomize ./test-data/update-image", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Every secret, and the \"platform\" service account, are managed outside the bundle"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes check references --file ./manifests --external secret --external serviceaccount/platform", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		for _, reference := range external {
+			if kind, name, found := strings.Cut(reference, "/"); kind == "" || (found && name == "") {
+				return fmt.Errorf("invalid external resource - expecting \"<kind>\" or \"<kind>/<name>\": %s", reference)
+			}
+		}
+
+		var documents []*manifests.Document
+		if len(files) > 0 {
+			partials, e := manifests.Read(files...)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		if kustomization != "" {
+			partials, e := manifests.Render(kustomization)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		documents := ctx.Value("documents").([]*manifests.Document)
+
+		references := &check.References{Namespace: namespace, External: external}
+
+		findings := references.Check(documents...)
+
+		switch format {
+		case output.JSON:
+			if findings == nil {
+				findings = []check.Finding{}
+			}
+
+			content, e := marshalers.JSON(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		default:
+			for _, finding := range findings {
+				severity := color.Color().Yellow(string(finding.Severity))
+				if finding.Severity == lint.Error {
+					severity = color.Color().Red(string(finding.Severity))
+				}
+
+				color.Color().Bold(fmt.Sprintf("%s[%d]", finding.File, finding.Document)).Default(finding.Resource).Dim(finding.Path).Default("-").Bold(severity).Default(finding.Message).Dim(fmt.Sprintf("(%s)", finding.Check)).Write(os.Stdout)
+			}
+		}
+
+		var count int
+		for _, finding := range findings {
+			if finding.Severity == lint.Error {
+				count++
+			}
+		}
+
+		if count > 0 {
+			return fmt.Errorf("check failed with %d broken reference(s)", count)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to check - \"-\" reads from standard-input")
+	flags.StringVarP(&kustomization, "kustomize", "k", "", "render, and check, a kustomization (file or directory)")
+	flags.StringVar(&namespace, "namespace", "default", "the namespace of resources that don't declare one")
+	flags.StringArrayVar(&external, "external", nil, "a resource that exists outside the bundle - either a kind (e.g. \"secret\") or \"<kind>/<name>\"")
+	flags.Var(&format, "output", "the findings' output format")
+
+	Command.MarkFlagsOneRequired("file", "kustomize")
+}
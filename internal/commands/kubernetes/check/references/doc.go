@@ -0,0 +1,2 @@
+// Package references provides the cross-resource reference check sub-command.
+package references
@@ -0,0 +1,13 @@
+package references
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files         []string    // files represents the manifest file(s) or directories to check
+	kustomization string      // kustomization is an optional kustomization to render and check
+	namespace     string      // namespace is attributed to resources that don't declare one
+	external      []string    // external represents resources that exist outside the bundle
+	format        output.Type = output.Text
+)
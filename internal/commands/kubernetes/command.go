@@ -3,7 +3,26 @@ package kubernetes
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/apply"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/check"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/compose"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/config"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/deprecations"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/diff"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/env"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/generate"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/images"
 	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/kustomization"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/lint"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/manifests"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/pss"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/rbac"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/render"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/resources"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/secret"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/validate"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/wait"
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
 )
 
 var Command = &cobra.Command{
@@ -26,4 +45,24 @@ var Command = &cobra.Command{
 
 func init() {
 	Command.AddCommand(kustomization.Command)
+	Command.AddCommand(generate.Command)
+	Command.AddCommand(lint.Command)
+	Command.AddCommand(validate.Command)
+	Command.AddCommand(env.Command)
+	Command.AddCommand(resources.Command)
+	Command.AddCommand(secret.Command)
+	Command.AddCommand(manifests.Command)
+	Command.AddCommand(check.Command)
+	Command.AddCommand(compose.Command)
+	Command.AddCommand(apply.Command)
+	Command.AddCommand(wait.Command)
+	Command.AddCommand(config.Command)
+	Command.AddCommand(render.Command)
+	Command.AddCommand(images.Command)
+	Command.AddCommand(diff.Command)
+	Command.AddCommand(deprecations.Command)
+	Command.AddCommand(rbac.Command)
+	Command.AddCommand(pss.Command)
+
+	kubeconfig.Register(Command)
 }
@@ -0,0 +1,184 @@
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/compose"
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/dotenv"
+	"github.com/x-ethr/ethr-cli/internal/git"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "to-compose",
+	Aliases:    []string{"compose"},
+	SuggestFor: nil,
+	Short:      "Convert Manifests to Docker Compose",
+	Long:       "Converts Deployments and StatefulSets, and the Services that select them, into a docker-compose file for local development. Images, commands, ports, environment variables, resources, and probes are converted - downward-API fields become overridable placeholders, ConfigMap and Secret variables are written to \".env\" files alongside the compose file, and probes become healthchecks (http probes assume curl, and tcp probes nc, within the image). Fields that can't be converted are listed as warnings. Existing files are only overwritten with --force.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes to-compose --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Render, and convert, a kustomization overlay"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes to-compose --kustomize ./test-data/update-image --out ./local/docker-compose.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Only write the compose file to standard-output (dry-run)"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes to-compose --file ./manifests --dry-run", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		var documents []*manifests.Document
+		if len(files) > 0 {
+			partials, e := manifests.Read(files...)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		if kustomization != "" {
+			partials, e := manifests.Render(kustomization)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		documents := ctx.Value("documents").([]*manifests.Document)
+
+		converter := &compose.Converter{Namespace: namespace}
+
+		project := converter.Convert(documents...)
+		if len(project.Services.Content) == 0 {
+			return fmt.Errorf("no Deployment or StatefulSet to convert")
+		}
+
+		for _, warning := range project.Warnings {
+			color.Color().Yellow("warning").Default(warning).Write(os.Stderr)
+		}
+
+		var buffer bytes.Buffer
+		if e := project.Write(&buffer); e != nil {
+			return e
+		}
+
+		if test {
+			fmt.Fprintf(os.Stdout, "%s", buffer.String())
+
+			for _, file := range project.Files {
+				color.Color().Dim("skipped").Default(file.Name).Write(os.Stderr)
+			}
+
+			return nil
+		}
+
+		directory := filepath.Dir(out)
+
+		// --> nothing is written unless every file can be
+		targets := []string{out}
+		for _, file := range project.Files {
+			targets = append(targets, filepath.Join(directory, file.Name))
+		}
+
+		for _, path := range targets {
+			if _, e := os.Stat(path); e == nil && !(force) {
+				return fmt.Errorf("file already exists (%s) - use --force to overwrite it", path)
+			}
+		}
+
+		for _, file := range project.Files {
+			path := filepath.Join(directory, file.Name)
+
+			mode := os.FileMode(0o644)
+			if file.Sensitive {
+				exposed, e := git.Guard(ctx, path, force)
+				if e != nil {
+					return e
+				}
+
+				if exposed {
+					color.Color().Yellow("warning").Default(fmt.Sprintf("%s isn't ignored by git - avoid committing its secret(s)", path)).Write(os.Stderr)
+				}
+
+				mode = 0o600
+			}
+
+			var content bytes.Buffer
+			if e := dotenv.Write(&content, file.Variables...); e != nil {
+				return e
+			}
+
+			if e := os.WriteFile(path, content.Bytes(), mode); e != nil {
+				e = fmt.Errorf("unable to write env file: %w", e)
+				return e
+			}
+
+			logger.Log(ctx, log.Debug, "Env File", slog.String("path", path), slog.Int("variables", len(file.Variables)))
+
+			color.Color().Green("wrote").Default(path).Write(os.Stdout)
+		}
+
+		if e := os.WriteFile(out, buffer.Bytes(), 0o644); e != nil {
+			e = fmt.Errorf("unable to write compose file: %w", e)
+			return e
+		}
+
+		color.Color().Green("wrote").Default(out).Write(os.Stdout)
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to convert - \"-\" reads from standard-input")
+	flags.StringVarP(&kustomization, "kustomize", "k", "", "render, and convert, a kustomization (file or directory)")
+	flags.StringVar(&namespace, "namespace", "default", "the namespace of resources that don't declare one")
+	flags.StringVar(&out, "out", "docker-compose.yaml", "the compose file to write - \".env\" files are written to the same directory")
+	flags.BoolVar(&force, "force", false, "overwrite existing file(s) - including secret \".env\" files tracked by git")
+	flags.BoolVar(&test, "dry-run", false, "write the compose file to standard-output, without writing any file")
+
+	Command.MarkFlagsOneRequired("file", "kustomize")
+}
@@ -0,0 +1,2 @@
+// Package compose provides the kubernetes to docker-compose conversion sub-command.
+package compose
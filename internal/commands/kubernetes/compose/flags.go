@@ -0,0 +1,10 @@
+package compose
+
+var (
+	files         []string // files represents the manifest file(s) or directories to convert
+	kustomization string   // kustomization is an optional kustomization to render and convert
+	namespace     string   // namespace is attributed to resources that don't declare one
+	out           string   // out is the compose file to write; ".env" files are written alongside it
+	force         bool     = false
+	test          bool     = false
+)
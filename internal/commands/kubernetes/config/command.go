@@ -0,0 +1,39 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/config/contexts"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/config/flatten"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/config/merge"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/config/minify"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/config/rename"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/config/use"
+)
+
+var Command = &cobra.Command{
+	Use:                    "config",
+	Short:                  "Kubeconfig Management",
+	Long:                   "Lists, switches, renames, merges, minifies, and flattens kubeconfig contexts. As with kubectl, the kubeconfig is --kubeconfig, otherwise every file of the KUBECONFIG list (merged, where the first file to define an entry wins), otherwise ~/.kube/config. Files are always replaced atomically.",
+	Aliases:                []string{"kubeconfig"},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(contexts.Command)
+	Command.AddCommand(use.Command)
+	Command.AddCommand(rename.Command)
+	Command.AddCommand(merge.Command)
+	Command.AddCommand(minify.Command)
+	Command.AddCommand(flatten.Command)
+}
@@ -0,0 +1,130 @@
+package contexts
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+// Entry represents a listed context.
+type Entry struct {
+	Name      string `json:"name" yaml:"name"`
+	Cluster   string `json:"cluster" yaml:"cluster"`
+	User      string `json:"user" yaml:"user"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Current   bool   `json:"current" yaml:"current"`
+}
+
+var Command = &cobra.Command{
+	Use:        "contexts",
+	Aliases:    []string{"get-contexts"},
+	SuggestFor: nil,
+	Short:      "List Contexts",
+	Long:       "Lists the contexts of the (merged) kubeconfig, marking the current-context.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config contexts", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# List only the context names, e.g. for shell completion or scripting"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config contexts --output text", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# List the contexts of a specific file"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config contexts --kubeconfig ./kubeconfig.yaml --output json", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.NoArgs,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		paths := kubeconfig.Paths(kubeconfig.Explicit)
+
+		logger.Log(ctx, log.Debug, "Kubeconfig", slog.Any("paths", paths))
+
+		config, e := kubeconfig.Load(paths...)
+		if e != nil {
+			return e
+		}
+
+		ctx = context.WithValue(ctx, "kubeconfig", config)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		config := ctx.Value("kubeconfig").(*kubeconfig.Config)
+
+		entries := make([]Entry, 0, len(config.Contexts))
+		for _, entry := range config.Contexts {
+			entries = append(entries, Entry{Name: entry.Name, Cluster: entry.Context.Cluster, User: entry.Context.User, Namespace: entry.Context.Namespace, Current: entry.Name == config.CurrentContext})
+		}
+
+		switch format {
+		case output.JSON:
+			content, e := marshalers.JSON(entries)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(entries)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.Text:
+			for _, entry := range entries {
+				fmt.Fprintln(os.Stdout, entry.Name)
+			}
+		default:
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+			fmt.Fprintln(w, "CURRENT\tNAME\tCLUSTER\tUSER\tNAMESPACE")
+			for _, entry := range entries {
+				var marker string
+				if entry.Current {
+					marker = "*"
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", marker, entry.Name, entry.Cluster, entry.User, entry.Namespace)
+			}
+
+			return w.Flush()
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.Var(&format, "output", "the contexts' output format - \"text\" lists only their names")
+}
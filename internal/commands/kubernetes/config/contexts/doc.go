@@ -0,0 +1,2 @@
+// Package contexts provides the sub-command that lists kubeconfig contexts.
+package contexts
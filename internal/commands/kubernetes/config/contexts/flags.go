@@ -0,0 +1,9 @@
+package contexts
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	format output.Type = output.Table
+)
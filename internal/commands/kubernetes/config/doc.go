@@ -0,0 +1,2 @@
+// Package config provides the kubeconfig management sub-commands.
+package config
@@ -0,0 +1,101 @@
+package flatten
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/git"
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+var Command = &cobra.Command{
+	Use:        "flatten",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Merge, and Embed, a Kubeconfig",
+	Long:       "Writes the merged view of every kubeconfig file (the KUBECONFIG list, unless --kubeconfig is set) as a single, self-contained file - the certificate-authority, client-certificate, and client-key files it references are embedded as their \"-data\" counterparts.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config flatten", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Consolidate several kubeconfig files into one"),
+		fmt.Sprintf("  %s", fmt.Sprintf("KUBECONFIG=~/.kube/config:~/.kube/kind.yaml %s kubernetes config flatten --out ~/.kube/merged.yaml", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.NoArgs,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		paths := kubeconfig.Paths(kubeconfig.Explicit)
+
+		logger.Log(ctx, log.Debug, "Kubeconfig", slog.Any("paths", paths))
+
+		config, e := kubeconfig.Load(paths...)
+		if e != nil {
+			return e
+		}
+
+		if out != kubeconfig.Stdout {
+			exposed, e := git.Guard(ctx, out, force)
+			if e != nil {
+				return e
+			}
+
+			if exposed {
+				color.Color().Yellow("warning").Default(fmt.Sprintf("%s isn't ignored by git - avoid committing the kubeconfig", out)).Write(os.Stderr)
+			}
+		}
+
+		ctx = context.WithValue(ctx, "kubeconfig", config)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		config := ctx.Value("kubeconfig").(*kubeconfig.Config)
+
+		if e := config.Flatten(); e != nil {
+			return e
+		}
+
+		if e := kubeconfig.Output(out, config); e != nil {
+			return e
+		}
+
+		if out != kubeconfig.Stdout {
+			color.Color().Green("wrote").Default(out).Write(os.Stdout)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVar(&out, "out", kubeconfig.Stdout, "the file to write the kubeconfig to - \"-\" writes to standard-output")
+	flags.BoolVar(&force, "force", false, "allow writing to a file tracked by git")
+}
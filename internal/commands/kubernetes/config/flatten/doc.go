@@ -0,0 +1,2 @@
+// Package flatten provides the sub-command that merges, and embeds the certificate file(s) of, a kubeconfig.
+package flatten
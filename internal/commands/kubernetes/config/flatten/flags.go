@@ -0,0 +1,6 @@
+package flatten
+
+var (
+	out   string // out is the file to write the flattened kubeconfig to; standard-output if "-"
+	force bool   // force allows writing to a file tracked by git
+)
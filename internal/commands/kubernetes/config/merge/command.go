@@ -0,0 +1,139 @@
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/git"
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+var Command = &cobra.Command{
+	Use:        "merge [flags] <file>...",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Merge Kubeconfig File(s)",
+	Long:       "Merges the clusters, users, and contexts of kubeconfig file(s) into the kubeconfig - --kubeconfig, otherwise the first file of the KUBECONFIG list, otherwise ~/.kube/config. Entries that already exist with identical content are skipped; entries that conflict fail the merge, unless --overwrite is set. The merged file(s)' relative file references are made absolute; --flatten embeds them instead. The kubeconfig is replaced atomically.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config merge ./kind.kubeconfig", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Preview the result, replacing conflicting entries"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config merge ./staging.kubeconfig ./production.kubeconfig --overwrite --out -", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.MinimumNArgs(1),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		paths := kubeconfig.Paths(kubeconfig.Explicit)
+		if len(paths) == 0 {
+			return errors.New("unable to determine the kubeconfig - set --kubeconfig or KUBECONFIG")
+		}
+
+		target := paths[0]
+
+		destination, e := kubeconfig.Decode(target)
+		if errors.Is(e, os.ErrNotExist) {
+			destination = &kubeconfig.Config{APIVersion: "v1", Kind: "Config"}
+		} else if e != nil {
+			e = fmt.Errorf("unable to read kubeconfig: %w", e)
+			return e
+		}
+
+		var sources []*kubeconfig.Config
+		for _, path := range args {
+			source, e := kubeconfig.Read(path)
+			if e != nil {
+				e = fmt.Errorf("unable to read kubeconfig: %w", e)
+				return e
+			}
+
+			if embed {
+				if e := source.Flatten(); e != nil {
+					return e
+				}
+			}
+
+			sources = append(sources, source)
+		}
+
+		if out == "" {
+			out = target
+		}
+
+		if out != kubeconfig.Stdout {
+			exposed, e := git.Guard(ctx, out, force)
+			if e != nil {
+				return e
+			}
+
+			if exposed {
+				color.Color().Yellow("warning").Default(fmt.Sprintf("%s isn't ignored by git - avoid committing the kubeconfig", out)).Write(os.Stderr)
+			}
+		}
+
+		logger.Log(ctx, log.Debug, "Kubeconfig", slog.String("target", target), slog.String("out", out), slog.Any("sources", args))
+
+		ctx = context.WithValue(ctx, "destination", destination)
+		ctx = context.WithValue(ctx, "sources", sources)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		destination, sources := ctx.Value("destination").(*kubeconfig.Config), ctx.Value("sources").([]*kubeconfig.Config)
+
+		var conflicts []string
+		for _, source := range sources {
+			conflicts = append(conflicts, destination.Merge(source, overwrite)...)
+		}
+
+		if len(conflicts) > 0 {
+			return fmt.Errorf("conflicting entries already exist with different content: %s - use --overwrite to replace them, or rename them first", strings.Join(conflicts, ", "))
+		}
+
+		if e := kubeconfig.Output(out, destination); e != nil {
+			return e
+		}
+
+		if out != kubeconfig.Stdout {
+			color.Color().Green("wrote").Default(out).Write(os.Stdout)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.BoolVar(&overwrite, "overwrite", false, "replace existing entries that conflict with merged ones")
+	flags.BoolVar(&embed, "flatten", false, "embed the certificate file(s) of the merged file(s)")
+	flags.StringVar(&out, "out", "", "the file to write the result to - defaults to the kubeconfig; \"-\" writes to standard-output")
+	flags.BoolVar(&force, "force", false, "allow writing to a file tracked by git")
+}
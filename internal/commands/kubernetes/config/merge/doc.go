@@ -0,0 +1,2 @@
+// Package merge provides the sub-command that merges kubeconfig file(s) into another.
+package merge
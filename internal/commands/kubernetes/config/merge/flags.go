@@ -0,0 +1,8 @@
+package merge
+
+var (
+	overwrite bool   // overwrite replaces existing entries that conflict with merged ones
+	embed     bool   // embed embeds the certificate file(s) of the merged file(s)
+	out       string // out is the file to write the result to; the kubeconfig if empty, standard-output if "-"
+	force     bool   // force allows writing to a file tracked by git
+)
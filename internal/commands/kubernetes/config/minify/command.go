@@ -0,0 +1,110 @@
+package minify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/git"
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+var Command = &cobra.Command{
+	Use:        "minify",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Reduce a Kubeconfig to a Single Context",
+	Long:       "Writes a kubeconfig that only contains a single context - the current-context, unless --context is set - and the cluster and user it references, e.g. to hand a CI job access to one cluster. Relative file references are made absolute; --flatten embeds them instead.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config minify", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Write a self-contained kubeconfig of the staging context"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config minify --context staging --flatten --out ./staging.kubeconfig", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.NoArgs,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		paths := kubeconfig.Paths(kubeconfig.Explicit)
+
+		logger.Log(ctx, log.Debug, "Kubeconfig", slog.Any("paths", paths))
+
+		config, e := kubeconfig.Load(paths...)
+		if e != nil {
+			return e
+		}
+
+		if out != kubeconfig.Stdout {
+			exposed, e := git.Guard(ctx, out, force)
+			if e != nil {
+				return e
+			}
+
+			if exposed {
+				color.Color().Yellow("warning").Default(fmt.Sprintf("%s isn't ignored by git - avoid committing the kubeconfig", out)).Write(os.Stderr)
+			}
+		}
+
+		ctx = context.WithValue(ctx, "kubeconfig", config)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		config := ctx.Value("kubeconfig").(*kubeconfig.Config)
+
+		minified, e := config.Minify(name)
+		if e != nil {
+			return e
+		}
+
+		if embed {
+			if e := minified.Flatten(); e != nil {
+				return e
+			}
+		}
+
+		if e := kubeconfig.Output(out, minified); e != nil {
+			return e
+		}
+
+		if out != kubeconfig.Stdout {
+			color.Color().Green("wrote").Default(out).Write(os.Stdout)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVar(&name, "context", "", "the context to retain - defaults to the current-context")
+	flags.BoolVar(&embed, "flatten", false, "embed the retained certificate file(s)")
+	flags.StringVar(&out, "out", kubeconfig.Stdout, "the file to write the kubeconfig to - \"-\" writes to standard-output")
+	flags.BoolVar(&force, "force", false, "allow writing to a file tracked by git")
+}
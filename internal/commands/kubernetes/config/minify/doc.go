@@ -0,0 +1,2 @@
+// Package minify provides the sub-command that reduces a kubeconfig to a single context.
+package minify
@@ -0,0 +1,8 @@
+package minify
+
+var (
+	name  string // name is the context to retain; the current-context if empty
+	embed bool   // embed embeds the retained certificate file(s), as with flatten
+	out   string // out is the file to write the minified kubeconfig to; standard-output if "-"
+	force bool   // force allows writing to a file tracked by git
+)
@@ -0,0 +1,82 @@
+package rename
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+var Command = &cobra.Command{
+	Use:        "rename [flags] <context> <name>",
+	Aliases:    []string{"rename-context"},
+	SuggestFor: nil,
+	Short:      "Rename a Context",
+	Long:       "Renames a context in the kubeconfig file that defines it, updating the current-context if it's the renamed context. The new name must not already be in use by any file of the KUBECONFIG list.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config rename arn:aws:eks:us-east-2:123456789012:cluster/production production", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(2),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if args[1] == "" {
+			return fmt.Errorf("the new name of context %q can't be empty", args[0])
+		}
+
+		paths := kubeconfig.Paths(kubeconfig.Explicit)
+
+		logger.Log(ctx, log.Debug, "Kubeconfig", slog.Any("paths", paths))
+
+		chain, e := kubeconfig.Open(paths...)
+		if e != nil {
+			return e
+		}
+
+		ctx = context.WithValue(ctx, "chain", chain)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		chain := ctx.Value("chain").(*kubeconfig.Chain)
+
+		paths, e := chain.Rename(args[0], args[1])
+		if e != nil {
+			return e
+		}
+
+		if e := chain.Save(); e != nil {
+			return e
+		}
+
+		color.Color().Green("renamed").Default(fmt.Sprintf("context %q to %q", args[0], args[1])).Dim(fmt.Sprintf("(%s)", strings.Join(paths, ", "))).Write(os.Stdout)
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
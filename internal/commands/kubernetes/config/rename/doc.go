@@ -0,0 +1,2 @@
+// Package rename provides the sub-command that renames a kubeconfig context.
+package rename
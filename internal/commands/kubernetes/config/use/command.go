@@ -0,0 +1,78 @@
+package use
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/kubeconfig"
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+var Command = &cobra.Command{
+	Use:        "use [flags] <context>",
+	Aliases:    []string{"use-context"},
+	SuggestFor: nil,
+	Short:      "Switch the Current-Context",
+	Long:       "Sets the kubeconfig's current-context. As with kubectl, the current-context is written to the first file of the KUBECONFIG list that sets one, otherwise to the first file.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes config use staging", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(1),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		paths := kubeconfig.Paths(kubeconfig.Explicit)
+
+		logger.Log(ctx, log.Debug, "Kubeconfig", slog.Any("paths", paths))
+
+		chain, e := kubeconfig.Open(paths...)
+		if e != nil {
+			return e
+		}
+
+		ctx = context.WithValue(ctx, "chain", chain)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		chain := ctx.Value("chain").(*kubeconfig.Chain)
+
+		path, e := chain.Use(args[0])
+		if e != nil {
+			return e
+		}
+
+		if e := chain.Save(); e != nil {
+			return e
+		}
+
+		color.Color().Green("switched").Default(fmt.Sprintf("to context %q", args[0])).Dim(fmt.Sprintf("(%s)", path)).Write(os.Stdout)
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
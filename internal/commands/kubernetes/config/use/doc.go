@@ -0,0 +1,2 @@
+// Package use provides the sub-command that switches the kubeconfig current-context.
+package use
@@ -0,0 +1,232 @@
+package deprecations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/deprecations"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/schema"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+// versions matches a "<major>.<minor>" kubernetes version.
+var versions = regexp.MustCompile(`^\d+\.\d+$`)
+
+var Command = &cobra.Command{
+	Use:        "deprecations",
+	Aliases:    []string{"deprecated"},
+	SuggestFor: nil,
+	Short:      "Deprecated API Version Check",
+	Long:       "Reports every document that uses an API version deprecated, or removed, as of the --target-version of kubernetes - with its replacement, and whether it can be converted automatically. The deprecation table is embedded, and follows the upstream deprecation guide. --convert rewrites the convertible documents in place, including the field restructuring their replacement requires (e.g. an Ingress backend's \"serviceName\" becoming \"service.name\"); comments and field order are retained. The check fails while removed API versions remain.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes deprecations --file ./manifests --target-version 1.30", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Check a rendered kustomization overlay"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes deprecations --kustomize ./overlays/production --target-version 1.30 --output json", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Convert every convertible document in place"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes deprecations --file ./manifests --target-version 1.30 --convert", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.NoArgs,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		target = schema.Normalize(target)
+		if !(versions.MatchString(target)) {
+			return fmt.Errorf("invalid target version - expecting \"<major>.<minor>\" (e.g. \"1.30\"): %s", target)
+		}
+
+		table, e := deprecations.Table()
+		if e != nil {
+			return e
+		}
+
+		if convert {
+			if kustomization != "" {
+				return errors.New("a rendered kustomization can't be converted - convert its resources with --file instead")
+			}
+
+			for _, file := range files {
+				if file == manifests.Stdin {
+					return errors.New("standard-input can't be converted in place")
+				}
+			}
+		}
+
+		var documents []*manifests.Document
+		if len(files) > 0 && !(convert) {
+			partials, e := manifests.Read(files...)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		if kustomization != "" {
+			partials, e := manifests.Render(kustomization)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)), slog.String("target", target), slog.Int("deprecations", len(table)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+		ctx = context.WithValue(ctx, "table", table)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		documents, table := ctx.Value("documents").([]*manifests.Document), ctx.Value("table").([]deprecations.Deprecation)
+
+		var findings []deprecations.Finding
+		if convert {
+			paths, e := manifests.Files(files...)
+			if e != nil {
+				return e
+			}
+
+			for _, path := range paths {
+				documents, e := manifests.Load(path)
+				if e != nil {
+					return e
+				}
+
+				changed := false
+				for _, document := range documents {
+					for _, finding := range deprecations.Check(target, table, document) {
+						if !(finding.Convertible()) {
+							findings = append(findings, finding)
+							continue
+						}
+
+						if e := deprecations.Convert(document, finding.Deprecation); e != nil {
+							return e
+						}
+
+						changed = true
+
+						color.Color().Green("converted").Bold(finding.Resource).Dim(document.Location()).Default(fmt.Sprintf("%s -> %s", finding.APIVersion, finding.Replacement)).Write(os.Stdout)
+					}
+				}
+
+				if changed {
+					if e := manifests.Save(path, documents...); e != nil {
+						return e
+					}
+				}
+			}
+		} else {
+			findings = deprecations.Check(target, table, documents...)
+		}
+
+		switch format {
+		case output.JSON:
+			if findings == nil {
+				findings = []deprecations.Finding{}
+			}
+
+			content, e := marshalers.JSON(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		default:
+			for _, finding := range findings {
+				report(finding)
+			}
+		}
+
+		var count int
+		for _, finding := range findings {
+			if finding.Status == deprecations.Removed {
+				count++
+			}
+		}
+
+		if count > 0 {
+			return fmt.Errorf("check failed with %d API version(s) removed as of kubernetes %s", count, target)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+// report writes a finding as a single line, followed by an indented note when the migration requires manual changes.
+func report(finding deprecations.Finding) {
+	line := color.Color().Bold(fmt.Sprintf("%s[%d]", finding.File, finding.Document)).Default(finding.Resource).Dim(finding.APIVersion).Default("-")
+	if finding.Status == deprecations.Removed {
+		line = line.Red(fmt.Sprintf("removed in %s", finding.Removed))
+	} else {
+		line = line.Yellow(fmt.Sprintf("deprecated in %s, removed in %s", finding.Deprecated, finding.Removed))
+	}
+
+	switch {
+	case finding.Convertible():
+		line = line.Default(fmt.Sprintf("- use %s", finding.Replacement)).Dim("(convertible)")
+	case finding.Replacement != "":
+		line = line.Default(fmt.Sprintf("- use %s", finding.Replacement)).Dim("(manual)")
+	default:
+		line = line.Default("- no replacement")
+	}
+
+	line.Write(os.Stdout)
+
+	if finding.Note != "" {
+		fmt.Fprint(os.Stdout, "    ")
+		color.Color().Dim(finding.Note).Write(os.Stdout)
+	}
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to check - \"-\" reads from standard-input")
+	flags.StringVarP(&kustomization, "kustomize", "k", "", "render, and check, a kustomization (file or directory)")
+	flags.StringVar(&target, "target-version", target, "the kubernetes version to check against (e.g. \"1.30\")")
+	flags.BoolVar(&convert, "convert", false, "rewrite convertible documents to their replacement API version, in place")
+	flags.Var(&format, "output", "the findings' output format")
+
+	Command.MarkFlagsOneRequired("file", "kustomize")
+}
@@ -0,0 +1,2 @@
+// Package deprecations provides the sub-command that reports, and converts, deprecated kubernetes API versions.
+package deprecations
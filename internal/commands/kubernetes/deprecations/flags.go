@@ -0,0 +1,14 @@
+package deprecations
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/schema"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files         []string    // files represents the manifest file(s) or directories to check
+	kustomization string      // kustomization is an optional kustomization to render and check
+	target        string      = schema.Latest()
+	convert       bool        // convert rewrites convertible documents in place
+	format        output.Type = output.Text
+)
@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/diff"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var Command = &cobra.Command{
+	Use:        "diff [flags] <before> <after>",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Semantic Manifest Diff",
+	Long:       "Compares two manifest bundles - file(s), directories, or, with --kustomize, kustomizations - resource by resource. Resources are matched by group, kind, namespace, and name, and compared field by field: ordering, quoting, and formatting don't produce differences, nor do empty values versus absent ones, nor the order of list items identified by a key (e.g. containers by name). Fields maintained by the API server (status, managedFields, generated annotations, ...) are ignored, as are - unless --defaults is set - fields that are only present on one side with their server-side default value; --ignore excludes further fields (e.g. \"spec.replicas\", \"metadata.labels[\\\"app.kubernetes.io/version\\\"]\", \"spec.template.spec.containers[*].image\"). The values of Secrets' data are masked. As with kubectl diff, the exit status is 1 when differences are found.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes diff ./rendered/previous.yaml ./rendered/current.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Compare two kustomization overlays, ignoring replica counts"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes diff --kustomize ./overlays/staging ./overlays/production --ignore spec.replicas", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Compare a live resource against its manifest"),
+		fmt.Sprintf("  %s", fmt.Sprintf("kubectl get deployment example --output yaml | %s kubernetes diff - ./deployment.yaml --output json", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(2),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		for _, expression := range ignore {
+			if _, e := diff.Pattern(expression); e != nil {
+				return e
+			}
+		}
+
+		if args[0] == manifests.Stdin && args[1] == manifests.Stdin {
+			return errors.New("only one of <before> and <after> can be standard-input")
+		}
+
+		var bundles [2][]*manifests.Document
+		for index, path := range args {
+			var e error
+			if kustomize {
+				bundles[index], e = manifests.Render(path)
+			} else {
+				bundles[index], e = manifests.Read(path)
+			}
+
+			if e != nil {
+				return e
+			}
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("before", len(bundles[0])), slog.Int("after", len(bundles[1])))
+
+		ctx = context.WithValue(ctx, "before", bundles[0])
+		ctx = context.WithValue(ctx, "after", bundles[1])
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		before, after := ctx.Value("before").([]*manifests.Document), ctx.Value("after").([]*manifests.Document)
+
+		resources, e := diff.Compare(before, after, diff.Options{Namespace: namespace, Ignore: ignore, Defaults: defaults})
+		if e != nil {
+			return e
+		}
+
+		switch format {
+		case output.JSON:
+			if resources == nil {
+				resources = []diff.Resource{}
+			}
+
+			content, e := marshalers.JSON(resources)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(resources)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		default:
+			for _, resource := range resources {
+				report(resource)
+			}
+
+			if len(resources) == 0 {
+				color.Color().Green("no differences").Write(os.Stdout)
+
+				return nil
+			}
+
+			summary := diff.Summary(resources)
+
+			fmt.Fprintln(os.Stdout)
+			color.Color().Bold(fmt.Sprintf("%d resource(s)", len(resources))).Default("differ -").Yellow(fmt.Sprintf("%d changed,", summary[diff.Changed])).Green(fmt.Sprintf("%d added,", summary[diff.Added])).Red(fmt.Sprintf("%d removed", summary[diff.Removed])).Write(os.Stdout)
+		}
+
+		if len(resources) > 0 {
+			return diff.ErrDifferences
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+// report writes a resource's header, followed by an indented line per field change.
+func report(resource diff.Resource) {
+	line := color.Color()
+	switch resource.Operation {
+	case diff.Added:
+		line = line.Green("+")
+	case diff.Removed:
+		line = line.Red("-")
+	default:
+		line = line.Yellow("~")
+	}
+
+	line = line.Bold(resource.Resource)
+	if resource.Namespace != "" {
+		line = line.Dim(fmt.Sprintf("(%s)", resource.Namespace))
+	}
+
+	line.Default(string(resource.Operation)).Write(os.Stdout)
+
+	for _, change := range resource.Changes {
+		fmt.Fprint(os.Stdout, "    ")
+
+		switch change.Operation {
+		case diff.Added:
+			color.Color().Green("+").Default(fmt.Sprintf("%s:", change.Path)).Green(diff.Format(change.After)).Write(os.Stdout)
+		case diff.Removed:
+			color.Color().Red("-").Default(fmt.Sprintf("%s:", change.Path)).Red(diff.Format(change.Before)).Write(os.Stdout)
+		default:
+			color.Color().Yellow("~").Default(fmt.Sprintf("%s:", change.Path)).Red(diff.Format(change.Before)).Dim("->").Green(diff.Format(change.After)).Write(os.Stdout)
+		}
+	}
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.BoolVarP(&kustomize, "kustomize", "k", false, "render both arguments as kustomizations (file or directory)")
+	flags.StringVar(&namespace, "namespace", "default", "the namespace of resources that don't declare one")
+	flags.StringArrayVar(&ignore, "ignore", nil, "a field expression to exclude from the comparison (e.g. \"spec.replicas\", \"spec.template.spec.containers[*].image\")")
+	flags.BoolVar(&defaults, "defaults", false, "report fields that are only present on one side with their server-side default value")
+	flags.Var(&format, "output", "the differences' output format")
+}
@@ -0,0 +1,2 @@
+// Package diff provides the sub-command that semantically compares two manifest bundles.
+package diff
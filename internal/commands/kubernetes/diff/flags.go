@@ -0,0 +1,13 @@
+package diff
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	kustomize bool        // kustomize renders both arguments as kustomizations
+	namespace string      // namespace is attributed to resources that don't declare one
+	ignore    []string    // ignore represents additional field expressions to exclude
+	defaults  bool        // defaults reports fields that only differ by their server-side default value
+	format    output.Type = output.Text
+)
@@ -0,0 +1,33 @@
+package env
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/env/list"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/env/set"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/env/unset"
+)
+
+var Command = &cobra.Command{
+	Use:                    "env",
+	Short:                  "Container Environment Variable(s)",
+	Long:                   "Lists, sets, and unsets the environment variables of workload containers across manifest file(s), editing them in place.",
+	Aliases:                []string{"environment"},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(list.Command)
+	Command.AddCommand(set.Command)
+	Command.AddCommand(unset.Command)
+}
@@ -0,0 +1,2 @@
+// Package env provides the container environment variable sub-commands.
+package env
@@ -0,0 +1,141 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/environment"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+// entry represents a single container's environment variable(s).
+type entry struct {
+	File      string                 `json:"file" yaml:"file"`
+	Resource  string                 `json:"resource" yaml:"resource"`
+	Container string                 `json:"container" yaml:"container"`
+	Variables []environment.Variable `json:"variables" yaml:"variables"`
+}
+
+var Command = &cobra.Command{
+	Use:        "list",
+	Aliases:    []string{"ls"},
+	SuggestFor: nil,
+	Short:      "List Container Environment Variable(s)",
+	Long:       "Lists the environment variables, and envFrom references, of the selected workload containers.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env list --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Only list a single container's variables as json"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env list --file ./manifests --kind deployment --name example --container example --output json", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if e := selector.Validate(); e != nil {
+			return e
+		}
+
+		documents, e := manifests.Read(files...)
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		documents := ctx.Value("documents").([]*manifests.Document)
+
+		entries := []entry{}
+		for _, document := range documents {
+			for _, container := range selector.Containers(document) {
+				entries = append(entries, entry{File: document.File, Resource: document.String(), Container: container.Name(), Variables: environment.List(container.Node)})
+			}
+		}
+
+		switch format {
+		case output.JSON:
+			content, e := marshalers.JSON(entries)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(entries)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		default:
+			for _, entry := range entries {
+				color.Color().Bold(entry.Resource).Dim(fmt.Sprintf("(%s)", entry.Container)).Write(os.Stdout)
+
+				for _, variable := range entry.Variables {
+					if variable.From {
+						name := "envFrom"
+						if variable.Name != "" {
+							name = fmt.Sprintf("envFrom (prefix %s)", variable.Name)
+						}
+
+						fmt.Fprintf(os.Stdout, "    %s\n", color.Color().Default(name).Dim(fmt.Sprintf("%s/%s", variable.Source, variable.Value)))
+
+						continue
+					}
+
+					fmt.Fprintf(os.Stdout, "    %s\n", color.Color().Default(fmt.Sprintf("%s=%s", variable.Name, variable.String())))
+				}
+			}
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to read - \"-\" reads from standard-input")
+	flags.Var(&format, "output", "the variables' output format")
+
+	selector.Register(Command)
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package list provides the environment variable list sub-command.
+package list
@@ -0,0 +1,12 @@
+package list
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files    []string // files represents the manifest file(s) or directories to read
+	selector manifests.Selector
+	format   output.Type = output.Text
+)
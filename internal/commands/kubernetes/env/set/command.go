@@ -0,0 +1,160 @@
+package set
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/environment"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "set [NAME=VALUE ...]",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Set Container Environment Variable(s)",
+	Long:       "Adds or updates environment variables of the selected workload containers. Existing entries are updated in place, and the manifest file(s)' comments are retained.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env set --file ./test-data/update-image/application.yaml LOG_LEVEL=debug PORT=8080", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Reference a secret's key, a configmap's key, and the pod's namespace"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env set --file ./test-data/update-image/application.yaml --secret-key-ref PASSWORD=database/password --configmap-key-ref REGION=settings/region --field-ref NAMESPACE=metadata.namespace", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Add an envFrom reference to the containers of deployments labeled \"tier=backend\""),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env set --file ./manifests --kind deployment --selector tier=backend --env-from configmap/settings --prefix SETTINGS_", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Only write content to standard-output (dry-run)"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env set --file ./test-data/update-image/application.yaml --container test-service-2-alpha-derivative-2 LOG_LEVEL=debug --dry-run", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if e := selector.Validate(); e != nil {
+			return e
+		}
+
+		var variables []environment.Variable
+
+		groups := []struct {
+			source environment.Source
+			pairs  []string
+		}{
+			{environment.Value, args},
+			{environment.Secret, secrets},
+			{environment.ConfigMap, configmaps},
+			{environment.Field, fields},
+		}
+
+		for _, group := range groups {
+			for _, pair := range group.pairs {
+				variable, e := environment.ParseVariable(group.source, pair)
+				if e != nil {
+					return e
+				}
+
+				variables = append(variables, variable)
+			}
+		}
+
+		for _, source := range sources {
+			variable, e := environment.ParseReference(source, prefix)
+			if e != nil {
+				return e
+			}
+
+			variables = append(variables, variable)
+		}
+
+		if len(variables) == 0 {
+			return errors.New("at least one environment variable or --env-from reference is required")
+		}
+
+		if prefix != "" && len(sources) == 0 {
+			return errors.New("--prefix requires --env-from")
+		}
+
+		logger.Log(ctx, log.Debug, "Variables", slog.Int("total", len(variables)))
+
+		ctx = context.WithValue(ctx, "variables", variables)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		variables := ctx.Value("variables").([]environment.Variable)
+
+		changes, e := manifests.Edit(os.Stdout, test, &selector, func(container *yaml.Node) ([]string, error) {
+			return environment.Set(container, variables...), nil
+		}, files...)
+
+		if e != nil {
+			return e
+		}
+
+		// --> avoid mixing the report with document(s) written to standard-output
+		writer := os.Stdout
+		if test || (len(files) == 1 && files[0] == manifests.Stdin) {
+			writer = os.Stderr
+		}
+
+		if len(changes) == 0 {
+			color.Color().Dim("No container(s) changed").Write(writer)
+
+			return nil
+		}
+
+		for _, change := range changes {
+			color.Color().Bold(change.File).Default(change.Resource).Dim(fmt.Sprintf("(%s)", change.Container)).Default("-").Green("set").Default(strings.Join(change.Fields, ", ")).Write(writer)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to edit - \"-\" reads from standard-input")
+	flags.StringArrayVar(&secrets, "secret-key-ref", nil, "set a variable from a secret's key (e.g. \"PASSWORD=database/password\")")
+	flags.StringArrayVar(&configmaps, "configmap-key-ref", nil, "set a variable from a configmap's key (e.g. \"REGION=settings/region\")")
+	flags.StringArrayVar(&fields, "field-ref", nil, "set a variable from a downward-api field (e.g. \"NAMESPACE=metadata.namespace\")")
+	flags.StringArrayVar(&sources, "env-from", nil, "add an envFrom reference (e.g. \"secret/credentials\", \"configmap/settings\")")
+	flags.StringVar(&prefix, "prefix", "", "the prefix of the --env-from reference(s)")
+	flags.BoolVar(&test, "dry-run", false, "write updated contents to standard-output instead of file")
+
+	selector.Register(Command)
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
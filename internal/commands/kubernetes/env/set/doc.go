@@ -0,0 +1,2 @@
+// Package set provides the environment variable set sub-command.
+package set
@@ -0,0 +1,16 @@
+package set
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var (
+	files      []string // files represents the manifest file(s) or directories to edit
+	secrets    []string // secrets represents "<name>=<secret>/<key>" key reference(s)
+	configmaps []string // configmaps represents "<name>=<configmap>/<key>" key reference(s)
+	fields     []string // fields represents "<name>=<field-path>" downward-api reference(s)
+	sources    []string // sources represents "secret/<name>" or "configmap/<name>" envFrom reference(s)
+	prefix     string   // prefix is an optional prefix of the envFrom reference(s)
+	selector   manifests.Selector
+	test       bool = false
+)
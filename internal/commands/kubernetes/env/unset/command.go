@@ -0,0 +1,131 @@
+package unset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/environment"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "unset [NAME ...]",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Unset Container Environment Variable(s)",
+	Long:       "Removes environment variables, and envFrom references, from the selected workload containers. The manifest file(s)' comments are retained.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env unset --file ./test-data/update-image/application.yaml LOG_LEVEL PORT", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Remove an envFrom reference from a single deployment's containers"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env unset --file ./manifests --kind deployment --name example --env-from configmap/settings", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Only write content to standard-output (dry-run)"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes env unset --file ./test-data/update-image/application.yaml LOG_LEVEL --dry-run", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if e := selector.Validate(); e != nil {
+			return e
+		}
+
+		var variables []environment.Variable
+		for _, name := range args {
+			variables = append(variables, environment.Variable{Name: name})
+		}
+
+		for _, source := range sources {
+			variable, e := environment.ParseReference(source, "")
+			if e != nil {
+				return e
+			}
+
+			variables = append(variables, variable)
+		}
+
+		if len(variables) == 0 {
+			return errors.New("at least one environment variable name or --env-from reference is required")
+		}
+
+		logger.Log(ctx, log.Debug, "Variables", slog.Int("total", len(variables)))
+
+		ctx = context.WithValue(ctx, "variables", variables)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		variables := ctx.Value("variables").([]environment.Variable)
+
+		changes, e := manifests.Edit(os.Stdout, test, &selector, func(container *yaml.Node) ([]string, error) {
+			return environment.Unset(container, variables...), nil
+		}, files...)
+
+		if e != nil {
+			return e
+		}
+
+		// --> avoid mixing the report with document(s) written to standard-output
+		writer := os.Stdout
+		if test || (len(files) == 1 && files[0] == manifests.Stdin) {
+			writer = os.Stderr
+		}
+
+		if len(changes) == 0 {
+			color.Color().Dim("No container(s) changed").Write(writer)
+
+			return nil
+		}
+
+		for _, change := range changes {
+			color.Color().Bold(change.File).Default(change.Resource).Dim(fmt.Sprintf("(%s)", change.Container)).Default("-").Red("unset").Default(strings.Join(change.Fields, ", ")).Write(writer)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to edit - \"-\" reads from standard-input")
+	flags.StringArrayVar(&sources, "env-from", nil, "remove an envFrom reference (e.g. \"secret/credentials\", \"configmap/settings\")")
+	flags.BoolVar(&test, "dry-run", false, "write updated contents to standard-output instead of file")
+
+	selector.Register(Command)
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package unset provides the environment variable unset sub-command.
+package unset
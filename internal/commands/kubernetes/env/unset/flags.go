@@ -0,0 +1,12 @@
+package unset
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var (
+	files    []string // files represents the manifest file(s) or directories to edit
+	sources  []string // sources represents "secret/<name>" or "configmap/<name>" envFrom reference(s) to remove
+	selector manifests.Selector
+	test     bool = false
+)
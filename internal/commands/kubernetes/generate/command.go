@@ -0,0 +1,39 @@
+package generate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/generate/hpa"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/generate/istio"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/generate/namespace"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/generate/networkpolicy"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/generate/pdb"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/generate/service"
+)
+
+var Command = &cobra.Command{
+	Use:                    "generate",
+	Short:                  "Kubernetes Resource Generator(s)",
+	Long:                   "Deterministically generates kubernetes resources from concise specification(s) so that output can be committed and diffed.",
+	Aliases:                []string{"gen"},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(service.Command)
+	Command.AddCommand(hpa.Command)
+	Command.AddCommand(pdb.Command)
+	Command.AddCommand(networkpolicy.Command)
+	Command.AddCommand(istio.Command)
+	Command.AddCommand(namespace.Command)
+}
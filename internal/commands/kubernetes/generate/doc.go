@@ -0,0 +1,2 @@
+// Package generate provides the kubernetes resource generator sub-commands.
+package generate
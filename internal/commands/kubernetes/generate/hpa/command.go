@@ -0,0 +1,112 @@
+package hpa
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "hpa",
+	Aliases:    []string{"horizontalpodautoscaler"},
+	SuggestFor: nil,
+	Short:      "HorizontalPodAutoscaler Generator",
+	Long:       "Generates a HorizontalPodAutoscaler whose scaleTargetRef references a workload read from a manifest file. Resource metrics accept either a utilization percentage or an average value quantity.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate hpa --file ./test-data/update-image/application.yaml --target deployment/test-service-2-alpha-derivative-2 --min 2 --max 5 --cpu 50", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Append to the target's manifest file, or write a new file and register it in a kustomization"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate hpa --file ./test-data/update-image/application.yaml --target deployment/test-service-2-alpha-derivative-2 --max 10 --memory 512Mi --metric requests_per_second=100 --append", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		kind, name, e := manifests.Target(target)
+		if e != nil {
+			return e
+		}
+
+		documents, e := manifests.Read(file)
+		if e != nil {
+			return e
+		}
+
+		document := manifests.Find(documents, kind, name)
+		if document == nil {
+			return fmt.Errorf("target not found in %s: %s", file, target)
+		}
+
+		logger.Log(ctx, log.Debug, "Target", slog.String("reference", document.String()), slog.String("location", document.Location()))
+
+		ctx = context.WithValue(ctx, "target", document)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		document := ctx.Value("target").(*manifests.Document)
+
+		resource, e := autoscaler.Generate(document)
+		if e != nil {
+			return e
+		}
+
+		destination := manifests.Output{File: out, Kustomization: kustomization}
+		if appending {
+			destination.Append = file
+		}
+
+		return destination.Write(resource)
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     false,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVarP(&file, "file", "f", "", "the manifest file containing the target workload")
+	flags.StringVar(&target, "target", "", "the target workload (e.g. \"deployment/example\")")
+	flags.IntVar(&autoscaler.Min, "min", 1, "the minimum replica count")
+	flags.IntVar(&autoscaler.Max, "max", 0, "the maximum replica count")
+	flags.StringVar(&autoscaler.CPU, "cpu", "", "cpu target - a utilization percentage (e.g. \"80\") or average value (e.g. \"500m\")")
+	flags.StringVar(&autoscaler.Memory, "memory", "", "memory target - a utilization percentage (e.g. \"75\") or average value (e.g. \"512Mi\")")
+	flags.StringArrayVar(&autoscaler.Metrics, "metric", nil, "a custom metric target (e.g. \"requests_per_second=100\", \"external:queue_depth=30\")")
+
+	flags.BoolVar(&appending, "append", false, "append the generated resource to the target's manifest file")
+	flags.StringVar(&out, "out", "", "write the generated resource to a new manifest file")
+	flags.StringVar(&kustomization, "kustomization", "", "register the --out file as a resource of a kustomization (file or directory)")
+
+	Command.MarkFlagsMutuallyExclusive("append", "out")
+
+	for _, flag := range []string{"file", "target", "max"} {
+		if e := Command.MarkFlagRequired(flag); e != nil {
+			if exception := Command.Help(); exception != nil {
+				panic(exception)
+			}
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package hpa provides the HorizontalPodAutoscaler generator sub-command.
+package hpa
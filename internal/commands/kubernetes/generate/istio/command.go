@@ -0,0 +1,123 @@
+package istio
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "istio",
+	Aliases:    []string{"virtualservice", "destinationrule"},
+	SuggestFor: nil,
+	Short:      "Istio Traffic Resources Generator",
+	Long:       "Generates a VirtualService and DestinationRule for a Service read from a manifest file. The DestinationRule enforces mTLS, and its subsets are derived from the \"version\" label of the workloads the Service selects - weights split traffic between them (e.g. for a canary). Optionally, an istio Gateway or a Gateway API HTTPRoute exposes the Service to external host(s).",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate istio --file ./test-data/update-image/application.yaml --target service/test-service-2-alpha-derivative-2", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Canary - route 10% of traffic to the v2 subset"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate istio --file ./manifests/application.yaml --target service/example --weight v1=90 --weight v2=10", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Expose the service through a new istio gateway, and register the output in a kustomization"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate istio --file ./manifests/application.yaml --target service/example --gateway example --host example.company.com --out ./manifests/istio.yaml --kustomization ./manifests", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Bind an HTTPRoute to an existing Gateway API gateway"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate istio --file ./manifests/application.yaml --target service/example --http-route istio-system/gateway --host example.company.com", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		kind, name, e := manifests.Target(target)
+		if e != nil {
+			return e
+		}
+
+		documents, e := manifests.Read(file)
+		if e != nil {
+			return e
+		}
+
+		document := manifests.Find(documents, kind, name)
+		if document == nil {
+			return fmt.Errorf("target not found in %s: %s", file, target)
+		}
+
+		logger.Log(ctx, log.Debug, "Target", slog.String("reference", document.String()), slog.String("location", document.Location()))
+
+		ctx = context.WithValue(ctx, "target", document)
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		document, documents := ctx.Value("target").(*manifests.Document), ctx.Value("documents").([]*manifests.Document)
+
+		resources, e := traffic.Generate(document, documents)
+		if e != nil {
+			return e
+		}
+
+		destination := manifests.Output{File: out, Kustomization: kustomization}
+		if appending {
+			destination.Append = file
+		}
+
+		return destination.Write(resources...)
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVarP(&file, "file", "f", "", "the manifest file containing the target service and the workloads it selects")
+	flags.StringVar(&target, "target", "", "the target service (e.g. \"service/example\")")
+	flags.StringArrayVar(&traffic.Weights, "weight", nil, "a subset's share of traffic (e.g. \"v1=90\") - weights must sum to 100")
+	flags.StringVar(&traffic.Label, "subset-label", "version", "the pod label subsets are derived from")
+	flags.StringVar(&traffic.Mode, "tls-mode", "ISTIO_MUTUAL", "the destination rule's tls mode (DISABLE, SIMPLE, MUTUAL, ISTIO_MUTUAL)")
+	flags.IntVar(&traffic.Port, "port", 0, "the service port to route to - defaults to the service's first port")
+	flags.StringVar(&traffic.Gateway, "gateway", "", "generate an istio gateway of the given name, and bind the virtual service to it")
+	flags.StringVar(&traffic.Selector, "gateway-selector", "istio=ingressgateway", "the ingress-gateway workload selector of a generated gateway")
+	flags.StringVar(&traffic.Route, "http-route", "", "generate a gateway api http-route bound to the given \"[<namespace>/]<gateway>\"")
+	flags.StringArrayVar(&traffic.Hosts, "host", nil, "an external host accepted by the gateway or http-route")
+
+	flags.BoolVar(&appending, "append", false, "append the generated resources to the target's manifest file")
+	flags.StringVar(&out, "out", "", "write the generated resources to a new manifest file")
+	flags.StringVar(&kustomization, "kustomization", "", "register the --out file as a resource of a kustomization (file or directory)")
+
+	Command.MarkFlagsMutuallyExclusive("append", "out")
+	Command.MarkFlagsMutuallyExclusive("gateway", "http-route")
+
+	for _, flag := range []string{"file", "target"} {
+		if e := Command.MarkFlagRequired(flag); e != nil {
+			if exception := Command.Help(); exception != nil {
+				panic(exception)
+			}
+		}
+	}
+}
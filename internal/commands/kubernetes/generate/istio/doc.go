@@ -0,0 +1,2 @@
+// Package istio provides the istio traffic resources generator sub-command.
+package istio
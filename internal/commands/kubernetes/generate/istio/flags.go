@@ -0,0 +1,14 @@
+package istio
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/generate"
+)
+
+var (
+	file          string // file represents the manifest file containing the target service and its workloads
+	target        string // target is the "service/<name>" reference of the service to route to
+	traffic              = generate.Traffic{}
+	appending     bool   = false
+	out           string // out is an optional new manifest file to write the resources to
+	kustomization string // kustomization is an optional kustomization to register the new manifest file in
+)
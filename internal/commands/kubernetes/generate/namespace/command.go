@@ -0,0 +1,173 @@
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/generate"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/system"
+)
+
+var Command = &cobra.Command{
+	Use:        "namespace [flags] <name>",
+	Aliases:    []string{"ns"},
+	SuggestFor: nil,
+	Short:      "Team Namespace Bundle Generator",
+	Long:       "Generates a team namespace's bootstrap bundle: the Namespace (labelled for istio sidecar injection), a ResourceQuota and LimitRange sized by --profile, a default-deny NetworkPolicy, the \"default\" ServiceAccount's image pull secret reference, and a RoleBinding granting the team's group a ClusterRole - along with a kustomization of the bundle's files. The built-in profiles are small, medium, and large; a --config file adds profiles, or replaces them by name, and may set the pull secret and team role. Output is deterministic, and can be committed and diffed.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage - writes the bundle to ./payments"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate namespace payments --team payments-engineers --profile medium", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Use organization-specific profiles, and preview the bundle"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate namespace payments --team payments-engineers --profile large --config ./profiles.yaml --out -", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Example configuration"),
+		fmt.Sprintf("  %s", "#"),
+		fmt.Sprintf("  %s", "#     pullSecret: registry-credentials"),
+		fmt.Sprintf("  %s", "#     role: edit"),
+		fmt.Sprintf("  %s", "#     profiles:"),
+		fmt.Sprintf("  %s", "#         xlarge:"),
+		fmt.Sprintf("  %s", "#             quota: { requests.cpu: \"64\", requests.memory: 256Gi, pods: \"500\" }"),
+		fmt.Sprintf("  %s", "#             limits: { default: { cpu: \"2\", memory: 4Gi }, defaultRequest: { cpu: 500m, memory: 1Gi } }"),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(1),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		profiles, e := generate.LoadProfiles(configuration)
+		if e != nil {
+			return e
+		}
+
+		preset, exists := profiles.Profiles[profile]
+		if !(exists) {
+			return fmt.Errorf("unknown profile (%s) - expecting one of: %s", profile, strings.Join(profiles.Names(), ", "))
+		}
+
+		namespace := &generate.Namespace{Name: args[0], Team: team, Role: profiles.Role, PullSecret: profiles.PullSecret, Istio: istio, Profile: preset}
+		if cmd.Flags().Changed("role") {
+			namespace.Role = role
+		}
+
+		if cmd.Flags().Changed("pull-secret") {
+			namespace.PullSecret = secret
+		}
+
+		if e := namespace.Validate(); e != nil {
+			return e
+		}
+
+		if out == "" {
+			out = namespace.Name
+		}
+
+		logger.Log(ctx, log.Debug, "Namespace", slog.String("name", namespace.Name), slog.String("profile", profile), slog.String("out", out))
+
+		ctx = context.WithValue(ctx, "namespace", namespace)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		namespace := ctx.Value("namespace").(*generate.Namespace)
+
+		documents, e := namespace.Generate()
+		if e != nil {
+			return e
+		}
+
+		if out == manifests.Stdin {
+			return manifests.Write(os.Stdout, documents...)
+		}
+
+		// --> one file per resource (e.g. "resourcequota.yaml"), followed by the kustomization
+		var names []string
+		files := make(map[string]*yaml.Node)
+		for _, document := range documents {
+			name := fmt.Sprintf("%s.yaml", strings.ToLower(manifests.Scalar(document, "kind")))
+
+			names = append(names, name)
+			files[name] = document
+		}
+
+		files["kustomization.yaml"] = generate.Kustomization(names...)
+		names = append(names, "kustomization.yaml")
+
+		if !(force) {
+			for _, name := range names {
+				if path := filepath.Join(out, name); system.Exists(path) {
+					return fmt.Errorf("%s already exists - use --force to overwrite the bundle", path)
+				}
+			}
+		}
+
+		if e := os.MkdirAll(out, 0o755); e != nil {
+			e = fmt.Errorf("unable to create directory: %w", e)
+			return e
+		}
+
+		for _, name := range names {
+			var buffer bytes.Buffer
+			if e := manifests.Write(&buffer, files[name]); e != nil {
+				return e
+			}
+
+			path := filepath.Join(out, name)
+			if e := os.WriteFile(path, buffer.Bytes(), 0o644); e != nil {
+				e = fmt.Errorf("unable to write file: %w", e)
+				return e
+			}
+
+			color.Color().Green("wrote").Default(path).Write(os.Stdout)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVar(&team, "team", "", "the team's group, granted the team role within the namespace")
+	flags.StringVar(&profile, "profile", "small", "the namespace's resource budget preset (e.g. \"small\", \"medium\", \"large\")")
+	flags.StringVar(&configuration, "config", "", "a yaml file of additional (or replacement) profiles, and pull secret and team role settings")
+	flags.StringVar(&role, "role", "", "the ClusterRole bound to the team - overrides the configuration (default \"edit\")")
+	flags.StringVar(&secret, "pull-secret", "", "the image pull secret referenced by the \"default\" ServiceAccount - overrides the configuration (default \"registry-credentials\"); empty omits it")
+	flags.BoolVar(&istio, "istio", true, "label the namespace for istio sidecar injection")
+	flags.StringVar(&out, "out", "", "the directory to write the bundle to - defaults to the namespace's name; \"-\" writes the resources to standard-output")
+	flags.BoolVar(&force, "force", false, "overwrite existing bundle file(s)")
+
+	if e := Command.MarkFlagRequired("team"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
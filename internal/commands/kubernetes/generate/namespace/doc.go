@@ -0,0 +1,2 @@
+// Package namespace provides the team namespace bootstrap bundle generator sub-command.
+package namespace
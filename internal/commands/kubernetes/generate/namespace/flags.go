@@ -0,0 +1,12 @@
+package namespace
+
+var (
+	team          string // team is the group granted the role within the namespace
+	profile       string // profile is the name of the namespace's resource budget preset
+	configuration string // configuration is an optional path to a profiles configuration file
+	role          string // role is an optional override of the configuration's team role
+	secret        string // secret is an optional override of the configuration's image pull secret
+	istio         bool   // istio enables istio's sidecar injection
+	out           string // out is the directory to write the bundle to ("-" for standard-output)
+	force         bool   // force overwrites existing bundle file(s)
+)
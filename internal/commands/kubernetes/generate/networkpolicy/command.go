@@ -0,0 +1,191 @@
+package networkpolicy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/generate"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "networkpolicy",
+	Aliases:    []string{"netpol"},
+	SuggestFor: nil,
+	Short:      "NetworkPolicy Generator",
+	Long:       "Generates a NetworkPolicy for each workload that allows ingress on its declared container port(s). Allowed peers are provided via flags or a dependencies file, with built-in egress presets for cluster dns (\"dns\") and the istio control-plane (\"istio\").",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate networkpolicy --file ./test-data/update-image/application.yaml --preset dns --preset istio", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Allow ingress from a namespace's pods, and egress to a database"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate networkpolicy --file ./test-data/update-image/application.yaml --ingress-from namespace:gateway/app=gateway --egress-to namespace:database/app=postgres@5432", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Read peers from a dependencies file, and include a default-deny policy for the namespace"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate networkpolicy --file ./test-data/update-image/application.yaml --dependencies ./dependencies.yaml --default-deny", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Example dependencies file"),
+		fmt.Sprintf("  %s", "#"),
+		fmt.Sprintf("  %s", "#     ingress:"),
+		fmt.Sprintf("  %s", "#         - { namespace: gateway, pods: { app: gateway } }"),
+		fmt.Sprintf("  %s", "#     egress:"),
+		fmt.Sprintf("  %s", "#         - { namespace: database, pods: { app: postgres }, ports: [ \"5432\" ] }"),
+		fmt.Sprintf("  %s", "#         - { cidr: 0.0.0.0/0, except: [ 10.0.0.0/8 ], ports: [ \"443\" ] }"),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		options := generate.NetworkPolicy{Presets: presets}
+
+		if dependencies != "" {
+			content, e := os.ReadFile(dependencies)
+			if e != nil {
+				e = fmt.Errorf("unable to read dependencies: %w", e)
+				return e
+			}
+
+			var peers generate.Dependencies
+
+			decoder := yaml.NewDecoder(bytes.NewReader(content))
+			decoder.KnownFields(true)
+			if e := decoder.Decode(&peers); e != nil {
+				e = fmt.Errorf("unable to unmarshal dependencies (%s): %w", dependencies, e)
+				return e
+			}
+
+			options.Ingress, options.Egress = peers.Ingress, peers.Egress
+		}
+
+		for _, value := range ingress {
+			peer, e := generate.ParsePeer(value)
+			if e != nil {
+				return e
+			}
+
+			options.Ingress = append(options.Ingress, peer)
+		}
+
+		for _, value := range egress {
+			peer, e := generate.ParsePeer(value)
+			if e != nil {
+				return e
+			}
+
+			options.Egress = append(options.Egress, peer)
+		}
+
+		ctx = context.WithValue(ctx, "options", options)
+
+		documents, e := manifests.Read(files...)
+		if e != nil {
+			return e
+		}
+
+		var workloads []*manifests.Document
+		if target != "" {
+			kind, name, e := manifests.Target(target)
+			if e != nil {
+				return e
+			}
+
+			document := manifests.Find(documents, kind, name)
+			if document == nil {
+				return fmt.Errorf("target not found: %s", target)
+			}
+
+			workloads = append(workloads, document)
+		} else {
+			for _, document := range documents {
+				if document.Workload() {
+					workloads = append(workloads, document)
+				}
+			}
+		}
+
+		if len(workloads) == 0 && !(deny) {
+			return fmt.Errorf("no workloads found in %s", strings.Join(files, ", "))
+		}
+
+		logger.Log(ctx, log.Debug, "Workloads", slog.Int("total", len(workloads)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+		ctx = context.WithValue(ctx, "workloads", workloads)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		options := ctx.Value("options").(generate.NetworkPolicy)
+		documents, workloads := ctx.Value("documents").([]*manifests.Document), ctx.Value("workloads").([]*manifests.Document)
+
+		var resources []*yaml.Node
+		if deny {
+			name := namespace
+			if name == "" && len(workloads) > 0 {
+				name = workloads[0].Namespace()
+			}
+
+			resources = append(resources, generate.DefaultDeny(name))
+		}
+
+		for _, workload := range workloads {
+			resource, e := options.Generate(workload, documents...)
+			if e != nil {
+				return e
+			}
+
+			resources = append(resources, resource)
+		}
+
+		destination := manifests.Output{File: out, Kustomization: kustomization}
+
+		return destination.Write(resources...)
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     false,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories containing the service(s) and workload(s)")
+	flags.StringVar(&target, "target", "", "generate a policy for a single workload (e.g. \"deployment/example\") - defaults to every workload")
+	flags.StringArrayVar(&ingress, "ingress-from", nil, "an allowed ingress peer (e.g. \"namespace:gateway/app=gateway\", \"pods:app=frontend\", \"cidr:10.0.0.0/8\")")
+	flags.StringArrayVar(&egress, "egress-to", nil, "an allowed egress peer, with optional port(s) (e.g. \"namespace:database/app=postgres@5432\")")
+	flags.StringVar(&dependencies, "dependencies", "", "a yaml file of allowed \"ingress\" and \"egress\" peers")
+	flags.StringArrayVar(&presets, "preset", nil, "a built-in egress peer - \"dns\" or \"istio\"")
+	flags.BoolVar(&deny, "default-deny", false, "include a default-deny policy for the namespace")
+	flags.StringVar(&namespace, "namespace", "", "the namespace of the default-deny policy - defaults to the workloads' namespace")
+	flags.StringVar(&out, "out", "", "write the generated resource(s) to a new manifest file")
+	flags.StringVar(&kustomization, "kustomization", "", "register the --out file as a resource of a kustomization (file or directory)")
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
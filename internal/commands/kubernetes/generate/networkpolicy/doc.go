@@ -0,0 +1,2 @@
+// Package networkpolicy provides the NetworkPolicy generator sub-command.
+package networkpolicy
@@ -0,0 +1,14 @@
+package networkpolicy
+
+var (
+	files         []string // files represents the manifest file(s) containing the Service(s) and workload(s)
+	target        string   // target is an optional "<kind>/<name>" reference of a single workload
+	ingress       []string // ingress represents the peers allowed to reach the workload(s)
+	egress        []string // egress represents the peers the workload(s) may reach
+	dependencies  string   // dependencies is an optional file of ingress and egress peers
+	presets       []string // presets represents built-in egress peers (e.g. "dns", "istio")
+	deny          bool     // deny includes a default-deny policy for the namespace
+	namespace     string   // namespace of the default-deny policy; defaults to the workloads' namespace
+	out           string   // out is an optional new manifest file to write the resource(s) to
+	kustomization string   // kustomization is an optional kustomization to register the new manifest file in
+)
@@ -0,0 +1,2 @@
+// Package pdb provides the PodDisruptionBudget generator sub-command.
+package pdb
@@ -0,0 +1,14 @@
+package pdb
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/generate"
+)
+
+var (
+	file          string // file represents the manifest file containing the target workload
+	target        string // target is the "<kind>/<name>" reference of the workload to protect
+	budget        generate.DisruptionBudget
+	appending     bool   = false
+	out           string // out is an optional new manifest file to write the resource to
+	kustomization string // kustomization is an optional kustomization to register the new manifest file in
+)
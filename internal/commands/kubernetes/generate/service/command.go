@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/generate"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "service",
+	Aliases:    []string{"svc"},
+	SuggestFor: nil,
+	Short:      "Service Manifest Generator",
+	Long:       "Generates a consistently labelled Service, ServiceAccount, and Deployment from a small yaml specification. Output is deterministic, and can be committed and diffed.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate service --file ./spec.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Write the generated manifests to a file"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes generate service --file ./spec.yaml --out ./application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Example specification"),
+		fmt.Sprintf("  %s", "#"),
+		fmt.Sprintf("  %s", "#     name: example"),
+		fmt.Sprintf("  %s", "#     image: service:latest"),
+		fmt.Sprintf("  %s", "#     replicas: 2"),
+		fmt.Sprintf("  %s", "#     ports: [ { name: http, port: 8080 } ]"),
+		fmt.Sprintf("  %s", "#     probes: { liveness: { path: /health }, readiness: { path: /health } }"),
+		fmt.Sprintf("  %s", "#     env: { CI: \"true\" }"),
+		fmt.Sprintf("  %s", "#     downward: { LOCAL_POD_IP: status.podIP, NAMESPACE: metadata.namespace }"),
+		fmt.Sprintf("  %s", "#     resources: { requests: { cpu: 100m, memory: 128Mi }, limits: { memory: 256Mi } }"),
+		fmt.Sprintf("  %s", "#     istio: { inject: true }"),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		content, e := os.ReadFile(file)
+		if e != nil {
+			e = fmt.Errorf("unable to read specification: %w", e)
+			return e
+		}
+
+		var specification generate.Service
+
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if e := decoder.Decode(&specification); e != nil {
+			e = fmt.Errorf("unable to unmarshal specification (%s): %w", file, e)
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Specification", slog.String("file", file), slog.String("name", specification.Name))
+
+		ctx = context.WithValue(ctx, "specification", &specification)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		specification := ctx.Value("specification").(*generate.Service)
+
+		documents, e := specification.Generate()
+		if e != nil {
+			return e
+		}
+
+		var buffer bytes.Buffer
+		if e := manifests.Write(&buffer, documents...); e != nil {
+			return e
+		}
+
+		if target == "" {
+			fmt.Fprintf(os.Stdout, "%s", buffer.String())
+
+			return nil
+		}
+
+		return os.WriteFile(target, buffer.Bytes(), 0o644)
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     false,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVarP(&file, "file", "f", "", "the service specification file")
+	flags.StringVar(&target, "out", "", "write the generated manifest(s) to a file instead of standard-output")
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
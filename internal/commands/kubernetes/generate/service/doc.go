@@ -0,0 +1,2 @@
+// Package service provides the spec-driven Service, ServiceAccount, and Deployment generator sub-command.
+package service
@@ -0,0 +1,6 @@
+package service
+
+var (
+	file   string // file represents the relative or full-system path to the service specification
+	target string // target is an optional file to write the generated manifest(s) to
+)
@@ -0,0 +1,161 @@
+package images
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/api/types"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/images"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var Command = &cobra.Command{
+	Use:        "images",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "List Container Images",
+	Long:       "Lists every container image a manifest bundle references - the containers, init-containers, and ephemeral-containers of workloads (CronJob templates included), and the \"image\" fields of any other resource (e.g. custom resources). Kustomization image overrides (\"images\") are applied, including to custom resources, which kustomize itself doesn't transform. Each image is listed once, with the resource(s) that reference it. The text output is tab-separated - the image, then its sources - for use with cut, xargs, and the like.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes images --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Pre-pull every image of a rendered overlay"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes images --kustomize ./overlays/production | cut -f 1 | xargs -n 1 docker pull", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Structured output, for scanners and SBOMs"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes images --kustomize ./overlays/production --output json", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.NoArgs,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		var documents []*manifests.Document
+		var overrides []types.Image
+		if len(files) > 0 {
+			partials, e := manifests.Read(files...)
+			if e != nil {
+				return e
+			}
+
+			partial, e := images.Overrides(partials...)
+			if e != nil {
+				return e
+			}
+
+			documents, overrides = append(documents, partials...), append(overrides, partial...)
+		}
+
+		if kustomization != "" {
+			partials, e := manifests.Render(kustomization)
+			if e != nil {
+				return e
+			}
+
+			// --> rendering consumes the kustomization itself; its overrides are read separately
+			path, e := manifests.Kustomization(kustomization)
+			if e != nil {
+				return e
+			}
+
+			source, e := manifests.Read(path)
+			if e != nil {
+				return e
+			}
+
+			partial, e := images.Overrides(source...)
+			if e != nil {
+				return e
+			}
+
+			documents, overrides = append(documents, partials...), append(overrides, partial...)
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)), slog.Int("overrides", len(overrides)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+		ctx = context.WithValue(ctx, "overrides", overrides)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		documents, overrides := ctx.Value("documents").([]*manifests.Document), ctx.Value("overrides").([]types.Image)
+
+		references := images.Collect(overrides, documents...)
+
+		switch format {
+		case output.JSON:
+			content, e := marshalers.JSON(references)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(references)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.Table:
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+			fmt.Fprintln(w, "IMAGE\tRESOURCE\tNAMESPACE\tFIELD")
+			for _, reference := range references {
+				for _, source := range reference.Sources {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", reference.Image, source.Resource, source.Namespace, source.Path)
+				}
+			}
+
+			return w.Flush()
+		default:
+			for _, reference := range references {
+				sources := make([]string, 0, len(reference.Sources))
+				for _, source := range reference.Sources {
+					sources = append(sources, source.String())
+				}
+
+				fmt.Fprintf(os.Stdout, "%s\t%s\n", reference.Image, strings.Join(sources, ", "))
+			}
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to read - \"-\" reads from standard-input")
+	flags.StringVarP(&kustomization, "kustomize", "k", "", "render, and read, a kustomization (file or directory)")
+	flags.Var(&format, "output", "the images' output format - \"text\" is tab-separated, one image per line")
+
+	Command.MarkFlagsOneRequired("file", "kustomize")
+}
@@ -0,0 +1,2 @@
+// Package images provides the sub-command that lists the container images of a manifest bundle.
+package images
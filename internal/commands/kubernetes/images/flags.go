@@ -0,0 +1,11 @@
+package images
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files         []string    // files represents the manifest file(s) or directories to read
+	kustomization string      // kustomization is an optional kustomization to render
+	format        output.Type = output.Text
+)
@@ -16,6 +16,7 @@ import (
     "sigs.k8s.io/kustomize/api/types"
 
     "github.com/x-ethr/ethr-cli/internal/constants"
+    "github.com/x-ethr/ethr-cli/internal/git"
     "github.com/x-ethr/ethr-cli/internal/log"
 )
 
@@ -69,6 +70,14 @@ var Command = &cobra.Command{
 
         ctx = context.WithValue(ctx, "path", path)
 
+        repository, e := vcs.Prepare(ctx, path)
+        if e != nil {
+            e = fmt.Errorf("unable to prepare version-control: %w", e)
+            return e
+        }
+
+        ctx = context.WithValue(ctx, "repository", repository)
+
         content, e := os.ReadFile(path)
         if e != nil {
             e = fmt.Errorf("unable to read file: %w", e)
@@ -136,7 +145,20 @@ var Command = &cobra.Command{
             return nil
         }
 
-        return os.WriteFile(path, output, 0o644)
+        if e := os.WriteFile(path, output, 0o644); e != nil {
+            return e
+        }
+
+        repository, _ := ctx.Value("repository").(*git.Repository)
+
+        return vcs.Apply(ctx, repository, git.Change{
+            Command: cmd.Name(),
+            Summary: fmt.Sprintf("Update Build Label to %s", build),
+            Files:   []string{path},
+            Values: map[string]string{
+                "build": build,
+            },
+        })
     },
     TraverseChildren: true,
     Hidden:           false,
@@ -151,6 +173,8 @@ func init() {
     flags.StringVar(&build, "build", "", "the target build version")
     flags.BoolVar(&test, "dry-run", false, "write updated contents to standard-output instead of file")
 
+    vcs.Register(Command)
+
     if e := Command.MarkFlagRequired("file"); e != nil {
         if exception := Command.Help(); exception != nil {
             panic(exception)
@@ -1,7 +1,13 @@
 package build
 
+import (
+	"github.com/x-ethr/ethr-cli/internal/git"
+)
+
 var (
 	file  string // the relative file path
 	build string // build represents the user-provided build version
 	test  bool   = false
+
+	vcs git.Options // vcs represents the optional version-control (commit, branch, push) flags
 )
@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/kustomize/api/types"
 
 	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/git"
 	"github.com/x-ethr/ethr-cli/internal/log"
 )
 
@@ -33,6 +34,9 @@ var Command = &cobra.Command{
 		"",
 		fmt.Sprintf("  %s", "# Only write content to standard-output (dry-run)"),
 		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes kustomization update image --file ./test-data/update-image/kustomization.yaml --image service:latest --name example --tag 1.0.0 --registry private.registry.io --dry-run", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Commit the change to a new branch and push it to a remote"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes kustomization update image --file ./test-data/update-image/kustomization.yaml --image service:latest --name example --tag 1.0.0 --registry private.registry.io --commit --branch \"release/{{ .Values.tag }}\" --message \"{{ .Summary }} [skip ci]\" --push origin", constants.Name())),
 	}, "\n"),
 	ValidArgs:              nil,
 	ValidArgsFunction:      nil,
@@ -68,6 +72,14 @@ var Command = &cobra.Command{
 
 		ctx = context.WithValue(ctx, "path", path)
 
+		repository, e := vcs.Prepare(ctx, path)
+		if e != nil {
+			e = fmt.Errorf("unable to prepare version-control: %w", e)
+			return e
+		}
+
+		ctx = context.WithValue(ctx, "repository", repository)
+
 		content, e := os.ReadFile(path)
 		if e != nil {
 			e = fmt.Errorf("unable to read file: %w", e)
@@ -133,7 +145,24 @@ var Command = &cobra.Command{
 			return nil
 		}
 
-		return os.WriteFile(path, output, 0o644)
+		if e := os.WriteFile(path, output, 0o644); e != nil {
+			return e
+		}
+
+		repository, _ := ctx.Value("repository").(*git.Repository)
+
+		return vcs.Apply(ctx, repository, git.Change{
+			Command: cmd.Name(),
+			Summary: fmt.Sprintf("Update Image (%s) to %s:%s", image, new, tag),
+			Files:   []string{path},
+			Values: map[string]string{
+				"image":    image,
+				"name":     name,
+				"tag":      tag,
+				"registry": registry,
+				"newName":  new,
+			},
+		})
 	},
 	TraverseChildren: true,
 	Hidden:           false,
@@ -152,6 +181,8 @@ func init() {
 
 	flags.BoolVar(&test, "dry-run", false, "write updated contents to standard-output instead of file")
 
+	vcs.Register(Command)
+
 	if e := Command.MarkFlagRequired("file"); e != nil {
 		if exception := Command.Help(); exception != nil {
 			panic(exception)
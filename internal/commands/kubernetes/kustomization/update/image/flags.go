@@ -1,5 +1,9 @@
 package image
 
+import (
+	"github.com/x-ethr/ethr-cli/internal/git"
+)
+
 var (
 	file     string // the relative file path
 	image    string // the updated image name
@@ -7,4 +11,6 @@ var (
 	tag      string
 	registry string
 	test     bool = false
+
+	vcs git.Options // vcs represents the optional version-control (commit, branch, push) flags
 )
@@ -0,0 +1,164 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/lint"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var Command = &cobra.Command{
+	Use:        "lint",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Kubernetes Manifest Linter",
+	Long:       "Evaluates multi-document kubernetes manifests against a configurable set of rules. Findings can be suppressed inline with \"# ethr-lint:ignore <rule>\" comments, which apply to the annotated node and its descendants.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes lint --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Lint every manifest in a directory, and output findings as json"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes lint --file ./test-data --output json", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Override rule severities"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes lint --file ./test-data --rule latest-image-tag=warning --rule missing-security-context=off", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# List the available rules"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes lint --list", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		settings := &lint.Configuration{}
+		if configuration != "" {
+			var e error
+			if settings, e = lint.Load(configuration); e != nil {
+				return e
+			}
+		}
+
+		if e := settings.Override(rules...); e != nil {
+			return e
+		}
+
+		linter, e := lint.New(settings)
+		if e != nil {
+			e = fmt.Errorf("invalid lint configuration: %w", e)
+			return e
+		}
+
+		ctx = context.WithValue(ctx, "linter", linter)
+
+		if list {
+			cmd.SetContext(ctx)
+
+			return nil
+		}
+
+		if len(files) == 0 {
+			return fmt.Errorf("at least one --file is required")
+		}
+
+		documents, e := manifests.Read(files...)
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if list {
+			for _, rule := range lint.Rules() {
+				fmt.Fprintf(os.Stdout, "%-28s %-8s %s\n", rule.ID, rule.Severity, rule.Description)
+			}
+
+			return nil
+		}
+
+		linter, documents := ctx.Value("linter").(*lint.Linter), ctx.Value("documents").([]*manifests.Document)
+
+		findings := linter.Lint(documents...)
+
+		switch format {
+		case output.JSON:
+			if findings == nil {
+				findings = []lint.Finding{}
+			}
+
+			content, e := marshalers.JSON(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		default:
+			for _, finding := range findings {
+				severity := color.Color().Blue(string(finding.Severity))
+				switch finding.Severity {
+				case lint.Error:
+					severity = color.Color().Red(string(finding.Severity))
+				case lint.Warning:
+					severity = color.Color().Yellow(string(finding.Severity))
+				}
+
+				color.Color().Bold(fmt.Sprintf("%s[%d]", finding.File, finding.Document)).Default(finding.Resource).Dim(finding.Path).Default("-").Bold(severity).Default(finding.Message).Dim(fmt.Sprintf("(%s)", finding.Rule)).Write(os.Stdout)
+			}
+		}
+
+		if count := lint.Count(findings, lint.Error); count > 0 {
+			return fmt.Errorf("lint failed with %d error(s)", count)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to lint - \"-\" reads from standard-input")
+	flags.StringVar(&configuration, "config", "", "a yaml configuration file mapping rule ids to severities")
+	flags.StringArrayVar(&rules, "rule", nil, "override a rule's severity (e.g. \"latest-image-tag=warning\", \"host-path-volume=off\")")
+	flags.Var(&format, "output", "the findings' output format")
+	flags.BoolVar(&list, "list", false, "list the available rules and their default severities")
+}
@@ -0,0 +1,2 @@
+// Package lint provides the rule-based kubernetes manifest linter sub-command.
+package lint
@@ -0,0 +1,13 @@
+package lint
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files         []string    // files represents the manifest file(s) or directories to lint
+	configuration string      // configuration is an optional path to a lint configuration file
+	rules         []string    // rules represents "<rule>=<severity>" override(s)
+	format        output.Type = output.Text
+	list          bool        = false
+)
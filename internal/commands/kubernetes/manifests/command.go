@@ -0,0 +1,33 @@
+package manifests
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/manifests/merge"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/manifests/sort"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/manifests/split"
+)
+
+var Command = &cobra.Command{
+	Use:                    "manifests",
+	Short:                  "Multi-Document Manifest Stream(s)",
+	Long:                   "Splits, merges, and reorders multi-document manifest streams, preserving their comments.",
+	Aliases:                []string{"manifest"},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(split.Command)
+	Command.AddCommand(merge.Command)
+	Command.AddCommand(sort.Command)
+}
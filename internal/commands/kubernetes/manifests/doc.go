@@ -0,0 +1,2 @@
+// Package manifests provides the multi-document manifest stream sub-commands.
+package manifests
@@ -0,0 +1,107 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "merge",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Merge Manifest File(s) into a Single Stream",
+	Long:       "Concatenates manifest file(s) into a single multi-document stream. Identical resources are only included once, while resources that share a group, kind, namespace, and name but differ are reported as conflicts.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes manifests merge --file ./base --file ./extras.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Merge, sort into apply order, and write to a file"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes manifests merge --file ./resources --sort --out ./bundle.yaml", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		documents, e := manifests.Load(files...)
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		documents := ctx.Value("documents").([]*manifests.Document)
+
+		merged, e := manifests.Merge(documents...)
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Merged", slog.Int("total", len(merged)), slog.Int("duplicates", len(documents)-len(merged)))
+
+		if ordered {
+			manifests.Sort(merged)
+		}
+
+		var writer io.Writer = os.Stdout
+		if out != "" {
+			file, e := os.Create(out)
+			if e != nil {
+				return e
+			}
+
+			defer file.Close()
+
+			writer = file
+		}
+
+		return manifests.Stream(writer, merged...)
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to merge - \"-\" reads from standard-input")
+	flags.StringVar(&out, "out", "", "write the merged stream to a file rather than standard-output")
+	flags.BoolVar(&ordered, "sort", false, "sort the merged stream into apply order")
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package merge provides the manifest stream merge sub-command.
+package merge
@@ -0,0 +1,7 @@
+package merge
+
+var (
+	files   []string // files represents the manifest file(s) or directories to merge
+	out     string   // out is an optional file to write the merged stream to
+	ordered bool     // ordered additionally sorts the merged stream into apply order
+)
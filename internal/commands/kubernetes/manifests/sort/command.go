@@ -0,0 +1,148 @@
+package sort
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "sort",
+	Aliases:    []string{"order"},
+	SuggestFor: nil,
+	Short:      "Sort Manifest(s) into Apply Order",
+	Long:       "Reorders documents into a safe apply order: Namespaces, CustomResourceDefinitions, ServiceAccounts and RBAC, ConfigMaps and Secrets, Services, then workloads - followed by the resources that reference workloads, custom resources, and admission webhooks. Documents of the same kind retain their relative order.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes manifests sort --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Sort each file's documents in place"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes manifests sort --file ./resources --in-place", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if place {
+			for _, file := range files {
+				if file == manifests.Stdin {
+					return fmt.Errorf("standard-input can't be sorted in place")
+				}
+			}
+
+			return nil
+		}
+
+		documents, e := manifests.Load(files...)
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if place {
+			paths, e := manifests.Files(files...)
+			if e != nil {
+				return e
+			}
+
+			for _, path := range paths {
+				documents, e := manifests.Load(path)
+				if e != nil {
+					return e
+				}
+
+				sorted := make([]*manifests.Document, len(documents))
+				copy(sorted, documents)
+
+				manifests.Sort(sorted)
+
+				changed := false
+				for index := range sorted {
+					if sorted[index] != documents[index] {
+						changed = true
+						break
+					}
+				}
+
+				if !(changed) {
+					continue
+				}
+
+				if e := manifests.Save(path, sorted...); e != nil {
+					return e
+				}
+
+				color.Color().Green("sorted").Default(path).Write(os.Stdout)
+			}
+
+			return nil
+		}
+
+		documents := ctx.Value("documents").([]*manifests.Document)
+
+		manifests.Sort(documents)
+
+		var writer io.Writer = os.Stdout
+		if out != "" {
+			file, e := os.Create(out)
+			if e != nil {
+				return e
+			}
+
+			defer file.Close()
+
+			writer = file
+		}
+
+		return manifests.Stream(writer, documents...)
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to sort - \"-\" reads from standard-input")
+	flags.StringVar(&out, "out", "", "write the sorted stream to a file rather than standard-output")
+	flags.BoolVar(&place, "in-place", false, "sort each file's documents in place, rather than writing a single stream")
+
+	Command.MarkFlagsMutuallyExclusive("out", "in-place")
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package sort provides the manifest stream apply-order sort sub-command.
+package sort
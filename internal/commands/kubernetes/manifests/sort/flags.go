@@ -0,0 +1,7 @@
+package sort
+
+var (
+	files []string // files represents the manifest file(s) or directories to sort
+	out   string   // out is an optional file to write the sorted stream to
+	place bool     // place sorts each file's documents in place
+)
@@ -0,0 +1,159 @@
+package split
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// unsafe matches characters that are replaced in generated file names (e.g. the ":" of "system:controller").
+var unsafe = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+var Command = &cobra.Command{
+	Use:        "split",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Split a Manifest Stream into File(s)",
+	Long:       "Writes each document of a multi-document stream to its own \"<kind>-<name>.yaml\" file. Resources of the same kind and name in different namespaces are written to \"<kind>-<namespace>-<name>.yaml\".",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes manifests split --file ./test-data/update-image/application.yaml --out-dir ./resources", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Split a stream from standard-input, and register the file(s) in an existing kustomization"),
+		fmt.Sprintf("  %s", fmt.Sprintf("cat ./bundle.yaml | %s kubernetes manifests split --file - --out-dir ./base --kustomization ./base", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		documents, e := manifests.Read(files...)
+		if e != nil {
+			return e
+		}
+
+		// --> resources that share a kind and name are disambiguated by their namespace
+		occurrences := make(map[string]int)
+		for _, document := range documents {
+			if document.Kind() == "" || document.Name() == "" {
+				return fmt.Errorf("%s: a document without a kind or name can't be named", document.Location())
+			}
+
+			occurrences[document.Kind()+"/"+document.Name()]++
+		}
+
+		paths := make(map[string]*manifests.Document, len(documents))
+
+		var order []string
+		for _, document := range documents {
+			partials := []string{document.Kind(), document.Name()}
+			if occurrences[document.Kind()+"/"+document.Name()] > 1 && document.Namespace() != "" {
+				partials = []string{document.Kind(), document.Namespace(), document.Name()}
+			}
+
+			for index := range partials {
+				partials[index] = strings.Trim(unsafe.ReplaceAllString(strings.ToLower(partials[index]), "-"), "-")
+			}
+
+			path := filepath.Join(directory, strings.Join(partials, "-")+".yaml")
+			if previous, exists := paths[path]; exists {
+				return fmt.Errorf("%s and %s would both be written to %s - merge the stream(s) first to remove duplicates", previous.Location(), document.Location(), path)
+			}
+
+			if _, e := os.Stat(path); e == nil && !(force) {
+				return fmt.Errorf("file already exists (%s) - use --force to overwrite it", path)
+			}
+
+			paths[path] = document
+			order = append(order, path)
+		}
+
+		if len(order) == 0 {
+			return errors.New("no document(s) to split")
+		}
+
+		logger.Log(ctx, log.Debug, "Files", slog.Int("total", len(order)))
+
+		ctx = context.WithValue(ctx, "paths", paths)
+		ctx = context.WithValue(ctx, "order", order)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		paths, order := ctx.Value("paths").(map[string]*manifests.Document), ctx.Value("order").([]string)
+
+		if e := os.MkdirAll(directory, 0o755); e != nil {
+			e = fmt.Errorf("unable to create output directory: %w", e)
+			return e
+		}
+
+		for _, path := range order {
+			file, e := os.Create(path)
+			if e != nil {
+				return e
+			}
+
+			if e := manifests.Stream(file, paths[path]); e != nil {
+				file.Close()
+				return e
+			}
+
+			if e := file.Close(); e != nil {
+				return e
+			}
+
+			if kustomization != "" {
+				if _, e := manifests.Register(kustomization, path); e != nil {
+					return fmt.Errorf("unable to register resource in kustomization: %w", e)
+				}
+			}
+
+			color.Color().Green("wrote").Default(path).Dim(fmt.Sprintf("(%s)", paths[path].Location())).Write(os.Stdout)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to split - \"-\" reads from standard-input")
+	flags.StringVar(&directory, "out-dir", ".", "the directory to write the file(s) to")
+	flags.BoolVar(&force, "force", false, "overwrite existing file(s)")
+	flags.StringVar(&kustomization, "kustomization", "", "register the file(s) as resources of a kustomization (file or directory)")
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
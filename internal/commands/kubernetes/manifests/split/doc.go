@@ -0,0 +1,2 @@
+// Package split provides the manifest stream split sub-command.
+package split
@@ -0,0 +1,8 @@
+package split
+
+var (
+	files         []string // files represents the manifest file(s) or directories to split
+	directory     string   // directory is the output directory of the split file(s)
+	force         bool     // force allows overwriting existing file(s)
+	kustomization string   // kustomization is an optional kustomization to register the split file(s) in
+)
@@ -0,0 +1,145 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/pss"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var Command = &cobra.Command{
+	Use:        "check",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Pod Security Standards Check",
+	Long:       "Evaluates every pod-bearing resource (Pod, Deployment, StatefulSet, DaemonSet, ReplicaSet, Job, and CronJob) against the controls of a Pod Security Standards --level: privileged containers, capabilities, host namespaces, ports, and paths, AppArmor, SELinux, seccomp, sysctls, /proc mounts, volume types, privilege escalation, and running as non-root. Violations are reported per container - or per pod, for pod-level fields - with the control's ID, as pod security admission would at admission time. The check fails while violations remain.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes pss check --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Check a rendered kustomization overlay against the baseline level"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes pss check --kustomize ./overlays/production --level baseline --output json", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# List the restricted level's controls"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes pss check --list --level restricted", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.NoArgs,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if list {
+			return nil
+		}
+
+		if len(files) == 0 && kustomization == "" {
+			return fmt.Errorf("at least one --file, or a --kustomize target, is required")
+		}
+
+		var documents []*manifests.Document
+		if len(files) > 0 {
+			partials, e := manifests.Read(files...)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		if kustomization != "" {
+			partials, e := manifests.Render(kustomization)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)), slog.String("level", string(level)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if list {
+			for _, control := range pss.Controls(level) {
+				fmt.Fprintf(os.Stdout, "%-26s %-11s %s\n", control.ID, control.Level, control.Description)
+			}
+
+			return nil
+		}
+
+		documents := ctx.Value("documents").([]*manifests.Document)
+
+		findings := pss.Check(level, documents...)
+
+		switch format {
+		case output.JSON:
+			if findings == nil {
+				findings = []pss.Finding{}
+			}
+
+			content, e := marshalers.JSON(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		default:
+			for _, finding := range findings {
+				color.Color().Bold(fmt.Sprintf("%s[%d]", finding.File, finding.Document)).Default(finding.Resource).Dim(finding.Path).Default("-").Red(finding.Control).Default(finding.Message).Write(os.Stdout)
+			}
+		}
+
+		if count := len(findings); count > 0 {
+			return fmt.Errorf("check failed with %d violation(s) of the %s pod security standard", count, level)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to check - \"-\" reads from standard-input")
+	flags.StringVarP(&kustomization, "kustomize", "k", "", "render, and check, a kustomization (file or directory)")
+	flags.Var(&level, "level", "the pod security standard to enforce")
+	flags.Var(&format, "output", "the findings' output format")
+	flags.BoolVar(&list, "list", false, "list the level's controls")
+}
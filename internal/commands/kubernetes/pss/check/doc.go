@@ -0,0 +1,2 @@
+// Package check provides the Pod Security Standards evaluation sub-command.
+package check
@@ -0,0 +1,14 @@
+package check
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/pss"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files         []string    // files represents the manifest file(s) or directories to check
+	kustomization string      // kustomization is an optional kustomization to render
+	level         pss.Level   = pss.Restricted
+	format        output.Type = output.Text
+	list          bool        = false
+)
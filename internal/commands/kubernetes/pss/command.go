@@ -0,0 +1,29 @@
+package pss
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/pss/check"
+)
+
+var Command = &cobra.Command{
+	Use:                    "pss",
+	Short:                  "Pod Security Standards",
+	Long:                   "Evaluates manifests against the Pod Security Standards - the \"baseline\" and \"restricted\" policies that pod security admission enforces - without a cluster.",
+	Aliases:                []string{"pod-security"},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(check.Command)
+}
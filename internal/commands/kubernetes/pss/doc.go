@@ -0,0 +1,2 @@
+// Package pss provides the Pod Security Standards sub-commands.
+package pss
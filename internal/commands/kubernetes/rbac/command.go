@@ -0,0 +1,31 @@
+package rbac
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/rbac/generate"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/rbac/whocan"
+)
+
+var Command = &cobra.Command{
+	Use:                    "rbac",
+	Short:                  "RBAC Generation & Analysis",
+	Long:                   "Generates Roles, ClusterRoles, and their bindings from concise permission specifications, and analyzes the RBAC resources of manifests - without a cluster - so that RBAC changes can be audited during review.",
+	Aliases:                []string{},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(generate.Command)
+	Command.AddCommand(whocan.Command)
+}
@@ -0,0 +1,2 @@
+// Package rbac provides the kubernetes RBAC sub-commands.
+package rbac
@@ -0,0 +1,101 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/rbac"
+)
+
+var Command = &cobra.Command{
+	Use:        "generate [flags] <name>",
+	Aliases:    []string{"gen"},
+	SuggestFor: nil,
+	Short:      "Role & Binding Generator",
+	Long:       "Generates a Role (or, with --cluster, a ClusterRole) from a concise permission specification, and a binding that grants it to a ServiceAccount. The specification is a \";\"-separated list of \"<resource>[,<resource>...]:<verb>[,<verb>...]\" entries; a resource may be qualified by its API group (\"certificates.cert-manager.io\"), a subresource (\"pods/log\"), and a resource name (\"secrets/database\"). Common resources (e.g. \"deployments\", \"ingresses\") resolve to their API group, and any other unqualified resource is a core resource. Entries are consolidated into as few rules as possible.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes rbac generate example --namespace development --rules \"pods:get,list;secrets/database:get\"", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Include the ServiceAccount, and register the new manifest in a kustomization"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes rbac generate example --namespace development --rules \"deployments,deployments/scale:get,patch\" --create-service-account --out ./rbac.yaml --kustomization .", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Grant a cluster-wide permission to an existing ServiceAccount"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes rbac generate node-reader --namespace monitoring --service-account prometheus --cluster --rules \"nodes,namespaces:get,list,watch\"", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(1),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		rules, e := rbac.ParseRules(specification)
+		if e != nil {
+			return e
+		}
+
+		if account == "" {
+			account = args[0]
+		}
+
+		permissions := rbac.Permissions{Name: args[0], Namespace: namespace, Cluster: cluster, Account: account, Create: create, Rules: rules}
+
+		logger.Log(ctx, log.Debug, "Permissions", slog.String("name", permissions.Name), slog.String("service-account", permissions.Account), slog.Int("rules", len(rules)))
+
+		ctx = context.WithValue(ctx, "permissions", permissions)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		permissions := ctx.Value("permissions").(rbac.Permissions)
+
+		resources, e := permissions.Generate()
+		if e != nil {
+			return e
+		}
+
+		destination := manifests.Output{File: out, Kustomization: kustomization}
+
+		return destination.Write(resources...)
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVar(&specification, "rules", "", "the permission specification (e.g. \"pods:get,list;pods/log:get;secrets/database:get\")")
+	flags.StringVar(&namespace, "namespace", "default", "the namespace of the ServiceAccount, and of the Role and RoleBinding")
+	flags.BoolVar(&cluster, "cluster", false, "generate a ClusterRole and ClusterRoleBinding rather than a Role and RoleBinding")
+	flags.StringVar(&account, "service-account", "", "the ServiceAccount granted the role - defaults to the role's name")
+	flags.BoolVar(&create, "create-service-account", false, "include the ServiceAccount")
+	flags.StringVar(&out, "out", "", "write the generated resource(s) to a new manifest file")
+	flags.StringVar(&kustomization, "kustomization", "", "register the --out file as a resource of a kustomization (file or directory)")
+
+	if e := Command.MarkFlagRequired("rules"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package generate provides the RBAC Role and binding generator sub-command.
+package generate
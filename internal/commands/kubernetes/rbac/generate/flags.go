@@ -0,0 +1,11 @@
+package generate
+
+var (
+	specification string // specification represents the permission specification (e.g. "pods:get,list;secrets/name:get")
+	namespace     string // namespace of the Role, RoleBinding, and ServiceAccount
+	cluster       bool   // cluster generates a ClusterRole and ClusterRoleBinding
+	account       string // account is the ServiceAccount's name; defaults to the role's name
+	create        bool   // create includes the ServiceAccount itself
+	out           string // out is an optional new manifest file to write the resource(s) to
+	kustomization string // kustomization is an optional kustomization to register the new manifest file in
+)
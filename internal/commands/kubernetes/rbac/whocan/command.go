@@ -0,0 +1,159 @@
+package whocan
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/rbac"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var Command = &cobra.Command{
+	Use:        "who-can [flags] <verb> <resource>",
+	Aliases:    []string{"whocan"},
+	SuggestFor: nil,
+	Short:      "RBAC Permission Analysis",
+	Long:       "Lists the subjects that the Roles, ClusterRoles, RoleBindings, and ClusterRoleBindings of a manifest bundle grant a verb on a resource - without a cluster - along with the binding and role that grant it, and the namespace it applies to (\"*\" for cluster-wide). The resource may be qualified by its API group (\"certificates.cert-manager.io\"), a subresource (\"pods/exec\"), and a resource name (\"secrets/database\"). Matching follows the API server: wildcards, \"*/<subresource>\" resources, resource names, and ClusterRole aggregation. Roles that aren't part of the bundle - other than the built-in cluster-admin - can't be evaluated, and are reported as warnings.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes rbac who-can get secrets --file ./manifests", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Audit a rendered overlay's exec access within a single namespace"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes rbac who-can create pods/exec --kustomize ./overlays/production --namespace payments", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# A named resource of a non-core API group"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes rbac who-can update deployments.apps/api --file ./manifests --output json", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(2),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		verb := strings.ToLower(args[0])
+		if verb == "*" || !(rbac.Verb(verb)) {
+			return fmt.Errorf("invalid verb: %s", args[0])
+		}
+
+		resource := rbac.ParseResource(args[1])
+		if resource.Resource == "" {
+			return fmt.Errorf("invalid resource: %s", args[1])
+		}
+
+		var documents []*manifests.Document
+		if len(files) > 0 {
+			partials, e := manifests.Read(files...)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		if kustomization != "" {
+			partials, e := manifests.Render(kustomization)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)), slog.String("verb", verb), slog.String("resource", resource.String()))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+		ctx = context.WithValue(ctx, "verb", verb)
+		ctx = context.WithValue(ctx, "resource", resource)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		documents := ctx.Value("documents").([]*manifests.Document)
+		verb, resource := ctx.Value("verb").(string), ctx.Value("resource").(rbac.Resource)
+
+		grants, unresolved := rbac.Analyze(fallback, documents...).WhoCan(verb, resource, namespace, fallback)
+		for _, reference := range unresolved {
+			color.Color().Yellow("warning").Default(fmt.Sprintf("%s isn't part of the manifests, and wasn't evaluated", reference)).Write(os.Stderr)
+		}
+
+		switch format {
+		case output.JSON:
+			if grants == nil {
+				grants = []rbac.Grant{}
+			}
+
+			content, e := marshalers.JSON(grants)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(grants)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.Text:
+			for _, grant := range grants {
+				fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\n", grant.Subject, grant.Scope, grant.Binding, grant.Role)
+			}
+		default:
+			if len(grants) == 0 {
+				color.Color().Dim(fmt.Sprintf("no subject can %s %s", verb, resource)).Write(os.Stdout)
+
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+			fmt.Fprintln(w, "KIND\tNAME\tNAMESPACE\tSCOPE\tBINDING\tROLE")
+			for _, grant := range grants {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", grant.Subject.Kind, grant.Subject.Name, grant.Subject.Namespace, grant.Scope, grant.Binding, grant.Role)
+			}
+
+			return w.Flush()
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to analyze - \"-\" reads from standard-input")
+	flags.StringVarP(&kustomization, "kustomize", "k", "", "render, and analyze, a kustomization (file or directory)")
+	flags.StringVar(&namespace, "namespace", "", "limit the analysis to a namespace - cluster-wide bindings always apply; defaults to every namespace")
+	flags.StringVar(&fallback, "default-namespace", "default", "the namespace of namespaced resources that don't declare one")
+	flags.Var(&format, "output", "the subjects' output format")
+
+	Command.MarkFlagsOneRequired("file", "kustomize")
+}
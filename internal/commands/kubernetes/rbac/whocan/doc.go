@@ -0,0 +1,2 @@
+// Package whocan provides the RBAC permission analysis sub-command.
+package whocan
@@ -0,0 +1,13 @@
+package whocan
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files         []string    // files represents the manifest file(s) or directories to analyze
+	kustomization string      // kustomization is an optional kustomization to render
+	namespace     string      // namespace limits the analysis to a single namespace's RoleBindings
+	fallback      string      // fallback is the namespace of namespaced resources that don't declare one
+	format        output.Type = output.Table
+)
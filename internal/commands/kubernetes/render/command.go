@@ -0,0 +1,138 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/envsubst"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "render-template",
+	Aliases:    []string{"envsubst"},
+	SuggestFor: nil,
+	Short:      "Substitute Manifest Placeholders",
+	Long:       "Substitutes envsubst-style placeholders (\"$VAR\", \"${VAR}\") in manifests, with shell-style defaults (\"${VAR:-default}\"), alternates (\"${VAR:+alternate}\"), and required variables (\"${VAR:?message}\"); \"$$\" is a literal \"$\". Values come from the environment, then --env-file file(s), then --values file(s) - the first to set a variable wins, and later files take precedence over earlier ones. Unlike envsubst, unset variables are reported, and --strict fails on them. Substitution is YAML-aware: values can't change a document's structure, and are quoted as needed. Quoted placeholders (\"${VERSION}\") always produce strings, while an unquoted, lone placeholder (replicas: ${REPLICAS}) takes the type its value has in YAML.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("IMAGE_TAG=1.2.3 %s kubernetes render-template --file ./manifests", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Fail on any unresolved variable, taking values from files"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes render-template --file ./manifests --values ./values.yaml --env-file .env --strict --out ./rendered.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Render standard-input"),
+		fmt.Sprintf("  %s", fmt.Sprintf("cat deployment.yaml | %s kubernetes render-template --file - | kubectl apply --filename -", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.NoArgs,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		sources := []envsubst.Variables{envsubst.Environment()}
+		for index := len(environments) - 1; index >= 0; index-- {
+			variables, e := envsubst.Dotenv(environments[index])
+			if e != nil {
+				return e
+			}
+
+			sources = append(sources, variables)
+		}
+
+		for index := len(values) - 1; index >= 0; index-- {
+			variables, e := envsubst.Values(values[index])
+			if e != nil {
+				return e
+			}
+
+			sources = append(sources, variables)
+		}
+
+		documents, e := manifests.Load(files...)
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)), slog.Int("sources", len(sources)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+		ctx = context.WithValue(ctx, "substituter", &envsubst.Substituter{Lookup: envsubst.Chain(sources...), Strict: strict})
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		documents, substituter := ctx.Value("documents").([]*manifests.Document), ctx.Value("substituter").(*envsubst.Substituter)
+
+		for _, document := range documents {
+			if e := substituter.Node(document.Node); e != nil {
+				e = fmt.Errorf("unable to render %s: %w", document.File, e)
+				return e
+			}
+		}
+
+		for _, name := range substituter.Missing() {
+			color.Color().Yellow("warning").Default(fmt.Sprintf("%s isn't set - substituted with an empty value", name)).Write(os.Stderr)
+		}
+
+		var buffer bytes.Buffer
+		if e := manifests.Stream(&buffer, documents...); e != nil {
+			return e
+		}
+
+		if out == "" {
+			_, e := buffer.WriteTo(os.Stdout)
+
+			return e
+		}
+
+		if e := os.WriteFile(out, buffer.Bytes(), 0644); e != nil {
+			e = fmt.Errorf("unable to write file: %w", e)
+			return e
+		}
+
+		color.Color().Green("wrote").Default(out).Write(os.Stdout)
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to render - \"-\" reads from standard-input")
+	flags.StringSliceVar(&values, "values", nil, "YAML file(s) of top-level \"<name>: <value>\" variables")
+	flags.StringSliceVar(&environments, "env-file", nil, "\".env\" file(s) of variables")
+	flags.BoolVar(&strict, "strict", false, "fail on any variable that isn't set and has no default")
+	flags.StringVar(&out, "out", "", "write the rendered manifests to a file rather than standard-output")
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package render provides the sub-command that substitutes "${VAR}" placeholders in manifests.
+package render
@@ -0,0 +1,9 @@
+package render
+
+var (
+	files        []string // files represents the manifest file(s) or directories to render
+	values       []string // values represents YAML file(s) of "<name>: <value>" variables
+	environments []string // environments represents ".env" file(s) of variables
+	strict       bool     // strict fails on any variable that can't be resolved
+	out          string   // out is an optional file to write the rendered manifests to; standard-output otherwise
+)
@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/resources/report"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/resources/set"
+)
+
+var Command = &cobra.Command{
+	Use:                    "resources",
+	Short:                  "Container Resource Requests & Limits",
+	Long:                   "Sets the cpu and memory requests and limits of workload containers, and reports the aggregate resource footprint of manifests for capacity planning.",
+	Aliases:                []string{"resource"},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(set.Command)
+	Command.AddCommand(report.Command)
+}
@@ -0,0 +1,2 @@
+// Package resources provides the container resource requests and limits sub-commands.
+package resources
@@ -0,0 +1,194 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/resources"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var Command = &cobra.Command{
+	Use:        "report",
+	Aliases:    []string{"footprint"},
+	SuggestFor: nil,
+	Short:      "Resource Footprint Report",
+	Long:       "Sums the cpu and memory requests and limits of every workload, multiplied by its replicas, per workload, per namespace, and overall. Limits marked with an asterisk are a lower bound, as at least one container doesn't declare a limit.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes resources report --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Render, and report on, a kustomization overlay before promoting it"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes resources report --kustomize ./test-data/update-image", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Assume daemon-sets run on 12 nodes, and output json"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes resources report --file ./manifests --nodes 12 --output json", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if nodes < 1 {
+			return errors.New("--nodes must be at least 1")
+		}
+
+		var documents []*manifests.Document
+		if len(files) > 0 {
+			partials, e := manifests.Read(files...)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		if kustomization != "" {
+			partials, e := manifests.Render(kustomization)
+			if e != nil {
+				return e
+			}
+
+			documents = append(documents, partials...)
+		}
+
+		logger.Log(ctx, log.Debug, "Documents", slog.Int("total", len(documents)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		documents := ctx.Value("documents").([]*manifests.Document)
+
+		report, e := resources.Aggregate(documents, namespace, nodes)
+		if e != nil {
+			return e
+		}
+
+		switch format {
+		case output.JSON:
+			content, e := marshalers.JSON(report)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(report)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		default:
+			return table(os.Stdout, report)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+// table writes the report's workloads, namespaces, and total as aligned columns.
+func table(writer io.Writer, report *resources.Report) error {
+	w := tabwriter.NewWriter(writer, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tREPLICAS\tCPU REQUESTS\tCPU LIMITS\tMEMORY REQUESTS\tMEMORY LIMITS")
+	for _, workload := range report.Workloads {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", workload.Namespace, workload.Resource, workload.Replicas, columns(workload.Total))
+	}
+
+	if e := w.Flush(); e != nil {
+		return e
+	}
+
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(w, "NAMESPACE\tWORKLOADS\tREPLICAS\tCPU REQUESTS\tCPU LIMITS\tMEMORY REQUESTS\tMEMORY LIMITS")
+	for _, namespace := range report.Namespaces {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", namespace.Name, namespace.Workloads, replicas(report, namespace.Name), columns(namespace.Total))
+	}
+
+	fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", "TOTAL", len(report.Workloads), replicas(report, ""), columns(report.Total))
+
+	if e := w.Flush(); e != nil {
+		return e
+	}
+
+	if report.Total.Unbounded() {
+		fmt.Fprintln(writer, "\n* at least one container doesn't declare a limit")
+	}
+
+	return nil
+}
+
+// columns formats a footprint's requests and limits as tab-separated columns.
+func columns(footprint resources.Footprint) string {
+	marker := func(unbounded bool) string {
+		if unbounded {
+			return "*"
+		}
+
+		return ""
+	}
+
+	return strings.Join([]string{
+		footprint.Requests.CPU.CPU(),
+		footprint.Limits.CPU.CPU() + marker(footprint.CPUUnbounded),
+		footprint.Requests.Memory.Memory(),
+		footprint.Limits.Memory.Memory() + marker(footprint.MemoryUnbounded),
+	}, "\t")
+}
+
+// replicas sums the replicas of a namespace's workloads, or of every workload if namespace is empty.
+func replicas(report *resources.Report, namespace string) string {
+	var total int
+	for _, workload := range report.Workloads {
+		if namespace == "" || workload.Namespace == namespace {
+			total += workload.Replicas
+		}
+	}
+
+	return strconv.Itoa(total)
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to report on - \"-\" reads from standard-input")
+	flags.StringVarP(&kustomization, "kustomize", "k", "", "render, and report on, a kustomization (file or directory)")
+	flags.StringVar(&namespace, "namespace", "default", "the namespace of workloads that don't declare one")
+	flags.IntVar(&nodes, "nodes", 1, "the number of nodes daemon-sets are assumed to run on")
+	flags.Var(&format, "output", "the report's output format")
+
+	Command.MarkFlagsOneRequired("file", "kustomize")
+}
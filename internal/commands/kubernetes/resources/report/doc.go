@@ -0,0 +1,2 @@
+// Package report provides the resource footprint report sub-command.
+package report
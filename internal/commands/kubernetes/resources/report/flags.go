@@ -0,0 +1,13 @@
+package report
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files         []string    // files represents the manifest file(s) or directories to report on
+	kustomization string      // kustomization is an optional kustomization to render and report on
+	namespace     string      // namespace is attributed to workloads that don't declare one
+	nodes         int         // nodes is the number of nodes daemon-sets are assumed to run on
+	format        output.Type = output.Table
+)
@@ -0,0 +1,138 @@
+package set
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/resources"
+)
+
+var Command = &cobra.Command{
+	Use:        "set",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Set Container Resource Requests & Limits",
+	Long:       "Applies cpu and memory (or any other resource's) requests and limits to the selected workload containers. Equivalent existing values (e.g. \"0.5\" and \"500m\") are left untouched, and the manifest file(s)' comments are retained.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes resources set --file ./test-data/update-image/application.yaml --requests cpu=100m,memory=128Mi --limits cpu=500m,memory=256Mi", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Only update a single container of the deployments labeled \"tier=backend\""),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes resources set --file ./manifests --kind deployment --selector tier=backend --container api --limits memory=1Gi", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Only write content to standard-output (dry-run)"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes resources set --file ./test-data/update-image/application.yaml --requests cpu=250m --dry-run", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		if e := selector.Validate(); e != nil {
+			return e
+		}
+
+		groups := []struct {
+			field string
+			pairs []string
+		}{
+			{"requests", requests},
+			{"limits", limits},
+		}
+
+		var assignments []resources.Assignment
+		for _, group := range groups {
+			for _, pair := range group.pairs {
+				assignment, e := resources.ParseAssignment(group.field, pair)
+				if e != nil {
+					return e
+				}
+
+				assignments = append(assignments, assignment)
+			}
+		}
+
+		if len(assignments) == 0 {
+			return errors.New("at least one of --requests or --limits is required")
+		}
+
+		logger.Log(ctx, log.Debug, "Assignments", slog.Int("total", len(assignments)))
+
+		ctx = context.WithValue(ctx, "assignments", assignments)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		assignments := ctx.Value("assignments").([]resources.Assignment)
+
+		changes, e := manifests.Edit(os.Stdout, test, &selector, func(container *yaml.Node) ([]string, error) {
+			return resources.Set(container, assignments...)
+		}, files...)
+
+		if e != nil {
+			return e
+		}
+
+		// --> avoid mixing the report with document(s) written to standard-output
+		writer := os.Stdout
+		if test || (len(files) == 1 && files[0] == manifests.Stdin) {
+			writer = os.Stderr
+		}
+
+		if len(changes) == 0 {
+			color.Color().Dim("No container(s) changed").Write(writer)
+
+			return nil
+		}
+
+		for _, change := range changes {
+			color.Color().Bold(change.File).Default(change.Resource).Dim(fmt.Sprintf("(%s)", change.Container)).Default("-").Green("set").Default(strings.Join(change.Fields, ", ")).Write(writer)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to edit - \"-\" reads from standard-input")
+	flags.StringSliceVar(&requests, "requests", nil, "the resource request(s) to set (e.g. \"cpu=100m,memory=128Mi\")")
+	flags.StringSliceVar(&limits, "limits", nil, "the resource limit(s) to set (e.g. \"cpu=500m,memory=256Mi\")")
+	flags.BoolVar(&test, "dry-run", false, "write updated contents to standard-output instead of file")
+
+	selector.Register(Command)
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package set provides the container resource requests and limits set sub-command.
+package set
@@ -0,0 +1,13 @@
+package set
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var (
+	files    []string // files represents the manifest file(s) or directories to edit
+	requests []string // requests represents "<resource>=<quantity>" request(s)
+	limits   []string // limits represents "<resource>=<quantity>" limit(s)
+	selector manifests.Selector
+	test     bool = false
+)
@@ -0,0 +1,31 @@
+package secret
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/secret/create"
+	"github.com/x-ethr/ethr-cli/internal/commands/kubernetes/secret/registry"
+)
+
+var Command = &cobra.Command{
+	Use:                    "secret",
+	Short:                  "Secret Manifest(s)",
+	Long:                   "Creates kubernetes Secret manifests from literals, files, and dotenv files - without hand-encoding their values.",
+	Aliases:                []string{"secrets"},
+	SuggestFor:             nil,
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	SilenceErrors:          true,
+	TraverseChildren:       true,
+}
+
+func init() {
+	Command.AddCommand(create.Command)
+	Command.AddCommand(registry.Command)
+}
@@ -0,0 +1,140 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/generate"
+	"github.com/x-ethr/ethr-cli/internal/git"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "create <name>",
+	Aliases:    []string{"generic"},
+	SuggestFor: nil,
+	Short:      "Create a Secret Manifest",
+	Long:       "Creates a Secret manifest from literal(s), file(s), and dotenv file(s), with base64-encoded \"data\" - or plain \"stringData\" with --plain. Writing to a file tracked by git is refused unless --force is provided.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes secret create database --from-literal username=admin --from-literal password=example", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# From a dotenv file and a file's contents, with a namespace and label(s)"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes secret create application --from-env-file .env --from-file key.pem=./private-key.pem --namespace development --label app=example", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# A tls secret, written to a new file that's registered in a kustomization"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes secret create certificate --type kubernetes.io/tls --from-file tls.crt=./certificate.pem --from-file tls.key=./key.pem --out ./secret.yaml --kustomization .", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(1),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		secret := &generate.Secret{Name: args[0], Namespace: namespace, Type: kind, Plain: plain, Labels: make(map[string]string)}
+
+		for _, label := range labels {
+			key, value, valid := strings.Cut(label, "=")
+			if !(valid) || key == "" {
+				return fmt.Errorf("invalid label - expecting \"<key>=<value>\": %s", label)
+			}
+
+			secret.Labels[key] = value
+		}
+
+		for _, path := range environments {
+			if e := secret.EnvFile(path); e != nil {
+				return e
+			}
+		}
+
+		for _, source := range files {
+			if e := secret.File(source); e != nil {
+				return e
+			}
+		}
+
+		for _, pair := range literals {
+			if e := secret.Literal(pair); e != nil {
+				return e
+			}
+		}
+
+		if e := secret.Validate(); e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Secret", slog.String("name", secret.Name), slog.String("type", secret.Type))
+
+		ctx = context.WithValue(ctx, "secret", secret)
+
+		if out != "" {
+			// --> the new file's directory may not exist yet (e.g. "--out overlays/development/secret.yaml")
+			if e := os.MkdirAll(filepath.Dir(out), 0o755); e != nil {
+				return fmt.Errorf("unable to create output directory: %w", e)
+			}
+
+			exposed, e := git.Guard(ctx, out, force)
+			if e != nil {
+				return e
+			}
+
+			if exposed {
+				color.Color().Yellow("warning").Default(fmt.Sprintf("%s isn't ignored by git - avoid committing the secret", out)).Write(os.Stderr)
+			}
+		}
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		secret := ctx.Value("secret").(*generate.Secret)
+
+		resource, e := secret.Generate()
+		if e != nil {
+			return e
+		}
+
+		destination := manifests.Output{File: out, Kustomization: kustomization}
+
+		return destination.Write(resource)
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringArrayVar(&environments, "from-env-file", nil, "a dotenv file whose assignments become key(s)")
+	flags.StringArrayVar(&files, "from-file", nil, "a file whose contents become a key - \"[<key>=]<path>\", where the key defaults to the file's base name")
+	flags.StringArrayVar(&literals, "from-literal", nil, "a literal key and value (e.g. \"username=admin\")")
+	flags.StringVar(&kind, "type", generate.Opaque, fmt.Sprintf("the secret's type - %q or %q", generate.Opaque, generate.TLS))
+	flags.BoolVar(&plain, "plain", false, "write plain-text \"stringData\" rather than base64-encoded \"data\"")
+	flags.StringVarP(&namespace, "namespace", "n", "", "the secret's namespace")
+	flags.StringSliceVar(&labels, "label", nil, "the secret's label(s) (e.g. \"app=example,tier=backend\")")
+	flags.StringVar(&out, "out", "", "write the generated resource to a new manifest file")
+	flags.StringVar(&kustomization, "kustomization", "", "register the --out file as a resource of a kustomization (file or directory)")
+	flags.BoolVar(&force, "force", false, "allow writing to a file tracked by git")
+}
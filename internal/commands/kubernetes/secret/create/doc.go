@@ -0,0 +1,2 @@
+// Package create provides the generic and tls Secret create sub-command.
+package create
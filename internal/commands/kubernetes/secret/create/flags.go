@@ -0,0 +1,14 @@
+package create
+
+var (
+	environments  []string // environments represents ".env" file(s) whose assignments become key(s)
+	files         []string // files represents "[<key>=]<path>" file(s) whose contents become key(s)
+	literals      []string // literals represents "<key>=<value>" pair(s)
+	kind          string   // kind is the Secret's type - "Opaque" or "kubernetes.io/tls"
+	plain         bool     // plain writes "stringData" rather than base64-encoded "data"
+	namespace     string   // namespace is the Secret's namespace
+	labels        []string // labels represents "<key>=<value>" label(s) of the Secret
+	out           string   // out is an optional new manifest file to write the resource to
+	kustomization string   // kustomization is an optional kustomization to register the new manifest file in
+	force         bool     // force allows writing to a file tracked by git
+)
@@ -0,0 +1,2 @@
+// Package secret provides the Secret manifest sub-commands.
+package secret
@@ -0,0 +1,224 @@
+package registry
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/generate"
+	"github.com/x-ethr/ethr-cli/internal/git"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+var Command = &cobra.Command{
+	Use:        "docker-registry <name>",
+	Aliases:    []string{"registry", "pull-secret"},
+	SuggestFor: nil,
+	Short:      "Create an Image Pull Secret Manifest",
+	Long:       "Creates a kubernetes.io/dockerconfigjson Secret from registry credential(s) - merging any number of registries, including entries imported from a docker client configuration. Optionally, a patch adding the Secret to ServiceAccount(s)' \"imagePullSecrets\" is also generated.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes secret docker-registry registry --server private.registry.io --username ci --password-stdin < ./token.txt", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Merge several registries, and patch the namespace's default service-account"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes secret docker-registry registry --namespace development --server private.registry.io --username ci --token \"${TOKEN}\" --registry \"ghcr.io=bot:${GITHUB_TOKEN}\" --service-account default", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Import a registry from ~/.docker/config.json, writing the secret and patch to new files registered in a kustomization"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes secret docker-registry registry --from-docker-config --import private.registry.io --service-account default --out ./registry.yaml --patch-out ./service-account.yaml --kustomization .", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(1),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		credentials := generate.Registries{}
+
+		if stdin {
+			reader := bufio.NewReader(os.Stdin)
+
+			line, e := reader.ReadString('\n')
+			if e != nil && line == "" {
+				e = fmt.Errorf("unable to read password from standard-input: %w", e)
+				return e
+			}
+
+			password = strings.TrimRight(line, "\r\n")
+		}
+
+		if token != "" {
+			password = token
+		}
+
+		if username != "" || password != "" {
+			if e := credentials.Add(server, username, password, email); e != nil {
+				return e
+			}
+		}
+
+		for _, registry := range registries {
+			if e := credentials.ParseRegistry(registry); e != nil {
+				return e
+			}
+		}
+
+		if configuration != "" {
+			skipped, e := credentials.Import(configuration, imports...)
+			if e != nil {
+				return e
+			}
+
+			for _, server := range skipped {
+				color.Color().Yellow("warning").Default(fmt.Sprintf("the credentials of %s are held by a credential helper and can't be imported", server)).Write(os.Stderr)
+			}
+		} else if len(imports) > 0 {
+			return errors.New("--import requires --from-docker-config")
+		}
+
+		if len(credentials) == 0 {
+			return errors.New("at least one registry is required - via --username, --registry, or --from-docker-config")
+		}
+
+		logger.Log(ctx, log.Debug, "Registries", slog.Int("total", len(credentials)))
+
+		payload, e := credentials.JSON()
+		if e != nil {
+			e = fmt.Errorf("unable to marshal docker configuration: %w", e)
+			return e
+		}
+
+		secret := &generate.Secret{Name: args[0], Namespace: namespace, Type: generate.DockerConfigJSON, Plain: plain, Labels: make(map[string]string)}
+		for _, label := range labels {
+			key, value, valid := strings.Cut(label, "=")
+			if !(valid) || key == "" {
+				return fmt.Errorf("invalid label - expecting \"<key>=<value>\": %s", label)
+			}
+
+			secret.Labels[key] = value
+		}
+
+		if e := secret.Add(".dockerconfigjson", payload); e != nil {
+			return e
+		}
+
+		if e := secret.Validate(); e != nil {
+			return e
+		}
+
+		if patch != "" && len(accounts) == 0 {
+			return errors.New("--patch-out requires --service-account")
+		}
+
+		if out != "" {
+			exposed, e := git.Guard(ctx, out, force)
+			if e != nil {
+				return e
+			}
+
+			if exposed {
+				color.Color().Yellow("warning").Default(fmt.Sprintf("%s isn't ignored by git - avoid committing the secret", out)).Write(os.Stderr)
+			}
+		}
+
+		ctx = context.WithValue(ctx, "secret", secret)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		secret := ctx.Value("secret").(*generate.Secret)
+
+		resource, e := secret.Generate()
+		if e != nil {
+			return e
+		}
+
+		var patches []*yaml.Node
+		for _, account := range accounts {
+			patches = append(patches, generate.PullSecretPatch(account, namespace, secret.Name))
+		}
+
+		resources := []*yaml.Node{resource}
+		if patch == "" {
+			resources = append(resources, patches...)
+		}
+
+		destination := manifests.Output{File: out, Kustomization: kustomization}
+		if patch != "" && out == "" {
+			// --> the patch file may be registered on its own
+			destination.Kustomization = ""
+		}
+
+		if e := destination.Write(resources...); e != nil {
+			return e
+		}
+
+		if patch == "" {
+			return nil
+		}
+
+		if e := (&manifests.Output{File: patch}).Write(patches...); e != nil {
+			return e
+		}
+
+		if kustomization != "" {
+			if _, e := manifests.RegisterPatch(kustomization, patch); e != nil {
+				return fmt.Errorf("unable to register patch in kustomization: %w", e)
+			}
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVar(&server, "server", generate.DockerHub, "the registry server of the --username credentials")
+	flags.StringVar(&username, "username", "", "the registry's username")
+	flags.StringVar(&password, "password", "", "the registry's password")
+	flags.StringVar(&token, "token", "", "an access token, used in place of --password")
+	flags.BoolVar(&stdin, "password-stdin", false, "read the password (or token) from standard-input")
+	flags.StringVar(&email, "email", "", "the registry's email")
+	flags.StringArrayVar(&registries, "registry", nil, "an additional registry - \"<server>=<username>:<password>\"")
+	flags.StringVar(&configuration, "from-docker-config", "", "import registries from a docker client configuration - defaults to ~/.docker/config.json when given without a value")
+	flags.StringSliceVar(&imports, "import", nil, "only import the given server(s) from the docker client configuration")
+	flags.StringArrayVar(&accounts, "service-account", nil, "generate a patch adding the secret to the service-account's \"imagePullSecrets\"")
+	flags.StringVar(&patch, "patch-out", "", "write the service-account patch(es) to a new manifest file - otherwise they follow the secret")
+	flags.BoolVar(&plain, "plain", false, "write plain-text \"stringData\" rather than base64-encoded \"data\"")
+	flags.StringVarP(&namespace, "namespace", "n", "", "the secret's (and service-account patches') namespace")
+	flags.StringSliceVar(&labels, "label", nil, "the secret's label(s) (e.g. \"app=example,tier=backend\")")
+	flags.StringVar(&out, "out", "", "write the generated resource to a new manifest file")
+	flags.StringVar(&kustomization, "kustomization", "", "register the --out file as a resource, and the --patch-out file as a patch, of a kustomization (file or directory)")
+	flags.BoolVar(&force, "force", false, "allow writing to a file tracked by git")
+
+	if path, e := generate.Configuration(); e == nil {
+		flags.Lookup("from-docker-config").NoOptDefVal = path
+	}
+
+	Command.MarkFlagsMutuallyExclusive("password", "token", "password-stdin")
+}
@@ -0,0 +1,2 @@
+// Package registry provides the image pull (docker-registry) Secret sub-command.
+package registry
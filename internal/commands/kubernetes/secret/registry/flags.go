@@ -0,0 +1,21 @@
+package registry
+
+var (
+	server        string   // server is the registry server of the --username and --password credentials
+	username      string   // username is the registry's username
+	password      string   // password is the registry's password
+	token         string   // token is an access token, used in place of a password
+	stdin         bool     // stdin reads the password from standard-input
+	email         string   // email is the registry's optional email
+	registries    []string // registries represents additional "<server>=<username>:<password>" registries
+	configuration string   // configuration is the path of a docker client configuration to import entries from
+	imports       []string // imports represents the server(s) to import from the docker client configuration
+	accounts      []string // accounts represents the ServiceAccount(s) to patch with the Secret
+	patch         string   // patch is an optional new manifest file to write the ServiceAccount patch(es) to
+	plain         bool     // plain writes "stringData" rather than base64-encoded "data"
+	namespace     string   // namespace is the Secret's namespace
+	labels        []string // labels represents "<key>=<value>" label(s) of the Secret
+	out           string   // out is an optional new manifest file to write the resource to
+	kustomization string   // kustomization is an optional kustomization to register the new manifest file(s) in
+	force         bool     // force allows writing to a file tracked by git
+)
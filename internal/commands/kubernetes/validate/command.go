@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/marshalers"
+	"github.com/x-ethr/ethr-cli/internal/schema"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+// finding represents a single validation result.
+type finding struct {
+	File     string `json:"file" yaml:"file"`
+	Document int    `json:"document" yaml:"document"`
+	Resource string `json:"resource" yaml:"resource"`
+	Path     string `json:"path" yaml:"path"`
+	Severity string `json:"severity" yaml:"severity"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+var Command = &cobra.Command{
+	Use:        "validate",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Offline Kubernetes Manifest Schema Validation",
+	Long:       fmt.Sprintf("Validates each document of the given manifest(s) against an embedded kubernetes openapi v3 specification - reporting unknown fields, incorrect types, and missing required fields without cluster access. Custom resources are validated against the served versions of any CustomResourceDefinitions found. Embedded kubernetes versions: %s.", strings.Join(schema.Versions(), ", ")),
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# General command usage"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes validate --file ./test-data/update-image/application.yaml", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Validate against a specific kubernetes version"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes validate --file ./test-data --kubernetes-version 1.29", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Validate against openapi v3 specification file(s) (e.g. kubernetes/api/openapi-spec/v3)"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes validate --file ./test-data --schema-dir ./openapi-spec/v3", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Validate custom resources against a directory of CustomResourceDefinitions, failing on unknown kinds"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes validate --file ./test-data --crd-dir ./crds --strict", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   nil,
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		var specification *schema.Specification
+		var e error
+
+		if directory != "" {
+			specification, e = schema.Directory(directory)
+		} else {
+			specification, e = schema.Embedded(version)
+		}
+
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Specification", slog.String("version", specification.Version), slog.Int("schemas", len(specification.Schemas)))
+
+		ctx = context.WithValue(ctx, "specification", specification)
+
+		documents, e := manifests.Read(files...)
+		if e != nil {
+			return e
+		}
+
+		definitions, e := manifests.Read(crds...)
+		if e != nil {
+			return e
+		}
+
+		registered, e := specification.Definitions(append(definitions, documents...)...)
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Custom Resource Definitions", slog.Int("versions", len(registered)))
+
+		ctx = context.WithValue(ctx, "documents", documents)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		specification, documents := ctx.Value("specification").(*schema.Specification), ctx.Value("documents").([]*manifests.Document)
+
+		findings := []finding{}
+		for _, document := range documents {
+			if document.Kustomization() {
+				continue
+			}
+
+			result := func(severity, path, message string) {
+				findings = append(findings, finding{File: document.File, Document: document.Index, Resource: document.String(), Path: path, Severity: severity, Message: message})
+			}
+
+			apiVersion, kind := document.APIVersion(), document.Kind()
+			if apiVersion == "" || kind == "" {
+				result("error", "", "document is missing \"apiVersion\" or \"kind\"")
+				continue
+			}
+
+			target := specification.Lookup(apiVersion, kind)
+			if target == nil {
+				if specification.Custom(apiVersion) {
+					result("error", "", fmt.Sprintf("%s isn't a served version of the %s custom resource definition", apiVersion, kind))
+				} else if specification.Known(apiVersion) {
+					result("error", "", fmt.Sprintf("%s %s isn't served by %s", apiVersion, kind, specification.Source))
+				} else if strict {
+					result("error", "", fmt.Sprintf("no schema or custom resource definition for %s %s", apiVersion, kind))
+				} else {
+					result("warning", "", fmt.Sprintf("no schema or custom resource definition for %s %s - skipping", apiVersion, kind))
+				}
+
+				continue
+			}
+
+			for _, violation := range specification.Validate(document.Node, target) {
+				result("error", violation.Path, violation.Message)
+			}
+		}
+
+		switch format {
+		case output.JSON:
+			content, e := marshalers.JSON(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		case output.YAML:
+			content, e := marshalers.YAML(findings)
+			if e != nil {
+				return e
+			}
+
+			fmt.Fprintf(os.Stdout, "%s\n", content)
+		default:
+			for _, finding := range findings {
+				severity := color.Color().Yellow(finding.Severity)
+				if finding.Severity == "error" {
+					severity = color.Color().Red(finding.Severity)
+				}
+
+				color.Color().Bold(fmt.Sprintf("%s[%d]", finding.File, finding.Document)).Default(finding.Resource).Dim(finding.Path).Default("-").Bold(severity).Default(finding.Message).Write(os.Stdout)
+			}
+		}
+
+		var errors int
+		for _, finding := range findings {
+			if finding.Severity == "error" {
+				errors++
+			}
+		}
+
+		if errors > 0 {
+			return fmt.Errorf("validation failed with %d error(s)", errors)
+		}
+
+		return nil
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringSliceVarP(&files, "file", "f", nil, "manifest file(s) or directories to validate - \"-\" reads from standard-input")
+	flags.StringVar(&version, "kubernetes-version", version, fmt.Sprintf("the target kubernetes version (%s)", strings.Join(schema.Versions(), ", ")))
+	flags.StringVar(&directory, "schema-dir", "", "a directory of openapi v3 specification file(s) (*.json, *.json.gz) to use instead of the embedded specifications")
+	flags.StringSliceVar(&crds, "crd-dir", nil, "file(s) or directories containing CustomResourceDefinitions to validate custom resources against - definitions within --file are always included")
+	flags.BoolVar(&strict, "strict", false, "report resources without a schema or custom resource definition as errors rather than warnings")
+	flags.Var(&format, "output", "the findings' output format")
+
+	if e := Command.MarkFlagRequired("file"); e != nil {
+		if exception := Command.Help(); exception != nil {
+			panic(exception)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package validate provides offline, schema-based validation of kubernetes manifests.
+package validate
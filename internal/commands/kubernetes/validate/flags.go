@@ -0,0 +1,15 @@
+package validate
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/schema"
+	"github.com/x-ethr/ethr-cli/internal/types/output"
+)
+
+var (
+	files     []string    // files represents the manifest file(s) or directories to validate
+	version   string      = schema.Latest()
+	directory string      // directory is an optional override of the embedded openapi specification(s)
+	crds      []string    // crds represents file(s) or directories containing CustomResourceDefinitions
+	strict    bool        = false
+	format    output.Type = output.Text
+)
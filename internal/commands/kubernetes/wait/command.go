@@ -0,0 +1,219 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/x-ethr/color"
+
+	"github.com/x-ethr/ethr-cli/internal/cluster"
+	"github.com/x-ethr/ethr-cli/internal/constants"
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+var Command = &cobra.Command{
+	Use:        "wait [flags] <kind>/<name>",
+	Aliases:    []string{},
+	SuggestFor: nil,
+	Short:      "Wait for a Rollout or Condition",
+	Long:       "Blocks until a resource's rollout completes (Deployment, StatefulSet, DaemonSet), or until a condition (--for) is met by a resource of any kind. The resource is followed with the watch API. On timeout, or a failed rollout, the resource's pods that aren't ready - including their container statuses - and its recent events are reported, and the command fails.",
+	Example: strings.Join([]string{
+		fmt.Sprintf("  %s", "# Wait for a Deployment's rollout to complete"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes wait deployment/example --timeout 5m", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Wait for a Pod's Ready condition"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes wait pod/example --for condition=Ready --namespace development", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Wait for a Job to complete"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes wait job/migration --for condition=Complete --timeout 10m", constants.Name())),
+		"",
+		fmt.Sprintf("  %s", "# Wait for a custom resource's field to reach a value"),
+		fmt.Sprintf("  %s", fmt.Sprintf("%s kubernetes wait certificate.cert-manager.io/example --for 'jsonpath={.status.conditions[?(@.type==\"Ready\")].status}=True'", constants.Name())),
+	}, "\n"),
+	ValidArgs:              nil,
+	ValidArgsFunction:      nil,
+	Args:                   cobra.ExactArgs(1),
+	ArgAliases:             nil,
+	BashCompletionFunction: "",
+	Deprecated:             "",
+	Annotations:            nil,
+	Version:                "",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		logger := slog.With(slog.String("command", cmd.Name()))
+
+		resource, name, valid := strings.Cut(args[0], "/")
+		if !(valid) || resource == "" || name == "" {
+			return fmt.Errorf("invalid resource (%s) - expected <kind>/<name>, e.g. deployment/example", args[0])
+		}
+
+		if timeout <= 0 {
+			return errors.New("--timeout must be positive")
+		}
+
+		client, e := connection.Client()
+		if e != nil {
+			return e
+		}
+
+		mapping, e := client.Find(ctx, resource)
+		if e != nil {
+			return e
+		}
+
+		expectation, e := cluster.Parse(mapping.Kind, condition)
+		if e != nil {
+			return e
+		}
+
+		logger.Log(ctx, log.Debug, "Resource", slog.String("mapping", mapping.String()), slog.String("name", name), slog.String("condition", expectation.String()))
+
+		ctx = context.WithValue(ctx, "client", client)
+		ctx = context.WithValue(ctx, "mapping", mapping)
+		ctx = context.WithValue(ctx, "name", name)
+		ctx = context.WithValue(ctx, "condition", expectation)
+
+		cmd.SetContext(ctx)
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		client, mapping := ctx.Value("client").(*cluster.Client), ctx.Value("mapping").(cluster.Mapping)
+		name, expectation := ctx.Value("name").(string), ctx.Value("condition").(cluster.Condition)
+
+		namespace := client.Namespace
+		if !(mapping.Namespaced) {
+			namespace = ""
+		}
+
+		reference := mapping.String() + "/" + name
+
+		deadline, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		object, e := client.Wait(deadline, mapping, namespace, name, expectation, func(progress string) {
+			color.Color().Bold(reference).Default(progress).Write(os.Stdout)
+		})
+
+		if e == nil {
+			// --> a rollout's final progress ("successfully rolled out") already reports its completion
+			if _, rollout := expectation.(cluster.Rollout); !(rollout) {
+				color.Color().Bold(reference).Green("condition met").Write(os.Stdout)
+			}
+
+			return nil
+		}
+
+		if errors.Is(e, context.DeadlineExceeded) {
+			e = fmt.Errorf("timed out waiting for %s (%s) after %s", reference, expectation, timeout)
+		}
+
+		if object != nil {
+			// --> the wait's deadline has likely passed - allow the diagnosis its own
+			diagnostics, cancel := context.WithTimeout(ctx, 30*time.Second)
+			defer cancel()
+
+			diagnosis, exception := client.Diagnose(diagnostics, mapping, object, limit)
+			if exception != nil {
+				color.Color().Yellow("warning").Default(fmt.Sprintf("unable to diagnose %s: %s", reference, exception)).Write(os.Stderr)
+			} else {
+				report(diagnosis)
+			}
+		}
+
+		return e
+	},
+	TraverseChildren: true,
+	Hidden:           false,
+	SilenceErrors:    true,
+	SilenceUsage:     true,
+}
+
+// report writes the pods that aren't ready, and the recent events, of a diagnosis.
+func report(diagnosis *cluster.Diagnosis) {
+	if len(diagnosis.Pods) > 0 {
+		color.Color().Bold("Pods not ready:").Write(os.Stderr)
+	}
+
+	for _, pod := range diagnosis.Pods {
+		fmt.Fprint(os.Stderr, "  ")
+
+		line := color.Color().Default(fmt.Sprintf("pod/%s", pod.Name)).Dim(fmt.Sprintf("(%s)", pod.Phase))
+		if pod.Reason != "" {
+			line = line.Yellow(pod.Reason)
+		}
+
+		line.Write(os.Stderr)
+
+		for _, container := range pod.Containers {
+			kind := "container"
+			if container.Init {
+				kind = "init-container"
+			}
+
+			fmt.Fprint(os.Stderr, "    ")
+
+			line := color.Color().Default(fmt.Sprintf("%s %s:", kind, container.Name))
+			switch {
+			case container.Ready:
+				line = line.Green("ready")
+			case container.State == "running":
+				line = line.Yellow("running, not ready")
+			default:
+				line = line.Red(strings.TrimSpace(container.State + " " + container.Reason))
+			}
+
+			line = line.Dim(fmt.Sprintf("restarts=%d", container.Restarts))
+			if container.Last != "" {
+				line = line.Dim(fmt.Sprintf("last=%s", container.Last))
+			}
+
+			if container.Message != "" {
+				line = line.Default("- " + container.Message)
+			}
+
+			line.Write(os.Stderr)
+		}
+	}
+
+	if len(diagnosis.Events) > 0 {
+		color.Color().Bold("Recent events:").Write(os.Stderr)
+	}
+
+	for _, event := range diagnosis.Events {
+		fmt.Fprint(os.Stderr, "  ")
+
+		line := color.Color().Dim(event.Time)
+		if event.Type == "Warning" {
+			line = line.Yellow(event.Type)
+		} else {
+			line = line.Default(event.Type)
+		}
+
+		line = line.Default(event.Reason).Dim(event.Object).Default(event.Message)
+		if event.Count > 1 {
+			line = line.Dim(fmt.Sprintf("(x%d)", event.Count))
+		}
+
+		line.Write(os.Stderr)
+	}
+}
+
+func init() {
+	flags := Command.Flags()
+
+	flags.StringVar(&condition, "for", "", "the condition to wait for - \"condition=<type>[=<status>]\" or \"jsonpath=<expression>[=<value>]\"; defaults to the rollout of a Deployment, StatefulSet, or DaemonSet")
+	flags.DurationVar(&timeout, "timeout", 5*time.Minute, "the maximum duration to wait")
+	flags.IntVar(&limit, "events", 15, "the maximum number of recent events reported on failure")
+
+	connection.Register(Command)
+}
@@ -0,0 +1,2 @@
+// Package wait provides the sub-command that blocks until a resource's rollout completes, or a condition is met.
+package wait
@@ -0,0 +1,14 @@
+package wait
+
+import (
+	"time"
+
+	"github.com/x-ethr/ethr-cli/internal/cluster"
+)
+
+var (
+	condition  string             // condition is the kubectl-style "--for" condition; a rollout when empty
+	connection cluster.Connection // connection selects the target cluster
+	timeout    time.Duration      = 5 * time.Minute
+	limit      int                = 15
+)
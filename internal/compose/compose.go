@@ -0,0 +1,571 @@
+package compose
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/dotenv"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// variable matches a valid environment variable name.
+var variable = regexp.MustCompile(`^[-._a-zA-Z][-._a-zA-Z0-9]*$`)
+
+// convertible are the workload kinds converted into compose service(s).
+var convertible = map[string]bool{"Deployment": true, "StatefulSet": true}
+
+// consumed are the kinds whose content is folded into the converted workloads rather than being converted themselves.
+var consumed = map[string]bool{"Service": true, "ConfigMap": true, "Secret": true}
+
+// File represents a generated ".env" file.
+type File struct {
+	Name      string            // Name is the file's name, relative to the compose file.
+	Sensitive bool              // Sensitive reports whether the file's variables are sourced from Secret(s).
+	Variables []dotenv.Variable // Variables are the file's assignments, in order.
+}
+
+// set assigns a variable, replacing the value of an existing key in place.
+func (f *File) set(key, value string) {
+	for index := range f.Variables {
+		if f.Variables[index].Key == key {
+			f.Variables[index].Value = value
+			return
+		}
+	}
+
+	f.Variables = append(f.Variables, dotenv.Variable{Key: key, Value: value})
+}
+
+// Project represents the result of a conversion.
+type Project struct {
+	Services *yaml.Node // Services is the compose file's "services" mapping.
+	Files    []*File    // Files are the ".env" files the services reference.
+	Warnings []string   // Warnings list the resource(s) and field(s) that couldn't be converted.
+}
+
+// Write encodes the project's compose file.
+func (p *Project) Write(writer io.Writer) error {
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(manifests.Indent)
+
+	if e := encoder.Encode(manifests.Object("services", p.Services)); e != nil {
+		return fmt.Errorf("unable to marshal compose file: %w", e)
+	}
+
+	return encoder.Close()
+}
+
+// Converter converts kubernetes manifests into a compose [Project].
+type Converter struct {
+	Namespace string // Namespace is attributed to resources that don't declare one.
+
+	project   *Project
+	resources map[string]*manifests.Document
+	published map[string]string
+}
+
+// warn records a warning about a resource.
+func (c *Converter) warn(resource fmt.Stringer, message string, arguments ...interface{}) {
+	c.project.Warnings = append(c.project.Warnings, fmt.Sprintf("%s: %s", resource, fmt.Sprintf(message, arguments...)))
+}
+
+// namespace returns the document's namespace, or the fallback if it doesn't declare one.
+func (c *Converter) namespace(document *manifests.Document) string {
+	if namespace := document.Namespace(); namespace != "" {
+		return namespace
+	}
+
+	return c.Namespace
+}
+
+// lookup returns the bundle's resource of kind, namespace, and name, or nil.
+func (c *Converter) lookup(kind, namespace, name string) *manifests.Document {
+	return c.resources[fmt.Sprintf("%s/%s/%s", kind, namespace, name)]
+}
+
+// Convert converts the documents' Deployment(s) and StatefulSet(s) into compose service(s). Each workload's first
+// container becomes a service named after the workload; additional containers share its network, and init-containers
+// run to completion before it starts. Services publish their ports on the workload they select, and ConfigMap and
+// Secret environment variables are written to ".env" files.
+func (c *Converter) Convert(documents ...*manifests.Document) *Project {
+	c.project = &Project{Services: manifests.Mapping()}
+	c.resources = make(map[string]*manifests.Document)
+	c.published = make(map[string]string)
+
+	var workloads, services []*manifests.Document
+	for _, document := range documents {
+		if document.Empty() || document.Kustomization() {
+			continue
+		}
+
+		c.resources[fmt.Sprintf("%s/%s/%s", document.Kind(), c.namespace(document), document.Name())] = document
+
+		switch {
+		case convertible[document.Kind()]:
+			workloads = append(workloads, document)
+		case document.Kind() == "Service":
+			services = append(services, document)
+		case !(consumed[document.Kind()]):
+			c.warn(document, "%s resources aren't converted", document.Kind())
+		}
+	}
+
+	selected := make(map[*manifests.Document][]*manifests.Document)
+	for _, service := range services {
+		if manifests.Scalar(service.Root(), "spec", "type") == "ExternalName" {
+			c.warn(service, "external-name services aren't converted")
+			continue
+		}
+
+		selector := manifests.Map(manifests.Lookup(service.Root(), "spec", "selector"))
+
+		var matched bool
+		for _, workload := range workloads {
+			if len(selector) > 0 && c.namespace(workload) == c.namespace(service) && manifests.Matches(selector, labels(workload)) {
+				selected[workload] = append(selected[workload], service)
+				matched = true
+			}
+		}
+
+		if !(matched) {
+			c.warn(service, "selector doesn't match a converted workload - its ports aren't published")
+		}
+	}
+
+	for _, workload := range workloads {
+		c.workload(workload, selected[workload])
+	}
+
+	return c.project
+}
+
+// labels returns a workload's pod template labels.
+func labels(document *manifests.Document) map[string]string {
+	template, _ := document.PodTemplate()
+
+	return manifests.Map(manifests.Value(template, "labels"))
+}
+
+// pod are the pod specification fields that are converted (or safely ignored).
+var pod = map[string]bool{
+	"containers":                    true,
+	"initContainers":                true,
+	"hostname":                      true,
+	"restartPolicy":                 true,
+	"terminationGracePeriodSeconds": true,
+	"dnsPolicy":                     true,
+	"schedulerName":                 true,
+	"securityContext":               true,
+}
+
+// workload converts a workload's containers into compose service(s), publishing the selecting services' ports.
+func (c *Converter) workload(document *manifests.Document, services []*manifests.Document) {
+	spec, _ := document.PodSpec()
+
+	containers := manifests.Items(manifests.Value(spec, "containers"))
+	if len(containers) == 0 {
+		c.warn(document, "no containers to convert")
+		return
+	}
+
+	for _, field := range manifests.Keys(spec) {
+		if !(pod[field]) {
+			c.warn(document, "pod field %s isn't supported", field)
+		} else if field == "securityContext" && len(manifests.Keys(manifests.Value(spec, field))) > 0 {
+			c.warn(document, "pod field %s isn't supported", field)
+		}
+	}
+
+	if replicas := document.Replicas(); replicas > 1 {
+		c.warn(document, "replicas (%d) are reduced to a single instance", replicas)
+	}
+
+	name := document.Name()
+
+	// --> init-containers run sequentially, each waiting on the previous one's successful completion
+	var previous string
+	for _, container := range manifests.Items(manifests.Value(spec, "initContainers")) {
+		service := fmt.Sprintf("%s-%s", name, manifests.Scalar(container, "name"))
+
+		node := c.container(document, service, container, "no", containers)
+		if previous != "" {
+			manifests.Set(node, "depends_on", manifests.Object(previous, manifests.Object("condition", "service_completed_successfully")))
+		}
+
+		manifests.Set(c.project.Services, service, node)
+
+		previous = service
+	}
+
+	for index, container := range containers {
+		service := name
+		if index > 0 {
+			service = fmt.Sprintf("%s-%s", name, manifests.Scalar(container, "name"))
+		}
+
+		node := c.container(document, service, container, "unless-stopped", containers)
+
+		if index == 0 {
+			ports, aliases := c.ports(document, services, containers)
+			if len(ports) > 0 {
+				manifests.Set(node, "ports", manifests.Node(ports))
+			}
+
+			if len(aliases) > 0 {
+				manifests.Set(node, "networks", manifests.Object("default", manifests.Object("aliases", aliases)))
+			}
+
+			if previous != "" {
+				manifests.Set(node, "depends_on", manifests.Object(previous, manifests.Object("condition", "service_completed_successfully")))
+			}
+
+			if hostname := manifests.Scalar(spec, "hostname"); hostname != "" {
+				manifests.Set(node, "hostname", manifests.String(hostname))
+			}
+
+			if grace := manifests.Scalar(spec, "terminationGracePeriodSeconds"); grace != "" {
+				manifests.Set(node, "stop_grace_period", manifests.String(grace+"s"))
+			}
+		} else {
+			// --> additional containers share the first container's network namespace, as they would within a pod
+			manifests.Set(node, "network_mode", manifests.String("service:"+name))
+		}
+
+		manifests.Set(c.project.Services, service, node)
+	}
+}
+
+// ports returns the "<host>:<container>" port mapping(s) of the services selecting a workload, along with the
+// service name(s) that differ from the workload's, which become network aliases.
+func (c *Converter) ports(document *manifests.Document, services []*manifests.Document, containers []*yaml.Node) (ports []string, aliases []string) {
+	for _, service := range services {
+		if service.Name() != document.Name() {
+			aliases = append(aliases, service.Name())
+		}
+
+		for _, port := range manifests.Items(manifests.Lookup(service.Root(), "spec", "ports")) {
+			published := manifests.Scalar(port, "port")
+
+			target := manifests.Scalar(port, "targetPort")
+			if target == "" {
+				target = published
+			}
+
+			resolved, found := resolve(containers, target)
+			if !(found) {
+				c.warn(service, "target port %s isn't declared by %s - it isn't published", target, document)
+				continue
+			}
+
+			protocol := strings.ToLower(manifests.Scalar(port, "protocol"))
+			if protocol == "" {
+				protocol = "tcp"
+			}
+
+			mapping := fmt.Sprintf("%s:%s", published, resolved)
+			if protocol != "tcp" {
+				mapping = fmt.Sprintf("%s/%s", mapping, protocol)
+			}
+
+			key := fmt.Sprintf("%s/%s", published, protocol)
+			if owner, exists := c.published[key]; exists {
+				c.warn(service, "host port %s is already published by %s - it isn't published", published, owner)
+				continue
+			}
+
+			c.published[key] = service.String()
+
+			ports = append(ports, mapping)
+		}
+	}
+
+	return ports, aliases
+}
+
+// resolve returns the container port number of a numeric or named port, reporting whether the port was found. A
+// numeric port is always resolved, as it's reachable whether or not a container declares it.
+func resolve(containers []*yaml.Node, port string) (string, bool) {
+	if strings.Trim(port, "0123456789") == "" {
+		return port, true
+	}
+
+	for _, container := range containers {
+		for _, declaration := range manifests.Items(manifests.Value(container, "ports")) {
+			if manifests.Scalar(declaration, "name") == port {
+				return manifests.Scalar(declaration, "containerPort"), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// fields are the container fields that are converted (or safely ignored).
+var fields = map[string]bool{
+	"name":                     true,
+	"image":                    true,
+	"imagePullPolicy":          true,
+	"command":                  true,
+	"args":                     true,
+	"workingDir":               true,
+	"ports":                    true,
+	"env":                      true,
+	"envFrom":                  true,
+	"livenessProbe":            true,
+	"readinessProbe":           true,
+	"startupProbe":             true,
+	"resources":                true,
+	"stdin":                    true,
+	"tty":                      true,
+	"terminationMessagePath":   true,
+	"terminationMessagePolicy": true,
+}
+
+// policies maps kubernetes image pull policies to their compose equivalent.
+var policies = map[string]string{"Always": "always", "IfNotPresent": "missing", "Never": "never"}
+
+// container converts a single container into a compose service named service.
+func (c *Converter) container(document *manifests.Document, service string, container *yaml.Node, restart string, siblings []*yaml.Node) *yaml.Node {
+	name := manifests.Scalar(container, "name")
+
+	for _, field := range manifests.Keys(container) {
+		if !(fields[field]) {
+			c.warn(document, "container %s field %s isn't supported", name, field)
+		}
+	}
+
+	var command, args []string
+	for _, field := range []struct {
+		name   string
+		target *[]string
+	}{{"command", &command}, {"args", &args}} {
+		for _, item := range manifests.Items(manifests.Value(container, field.name)) {
+			if strings.Contains(item.Value, "$(") {
+				c.warn(document, "container %s %s references a variable, which isn't expanded", name, field.name)
+			}
+
+			*field.target = append(*field.target, escape(item.Value))
+		}
+	}
+
+	policy := manifests.String(restart)
+
+	environment, files := c.environment(document, service, container)
+
+	// --> only enabled flags are emitted
+	var stdin, tty *bool
+	if enabled := manifests.Scalar(container, "stdin") == "true"; enabled {
+		stdin = &enabled
+	}
+
+	if enabled := manifests.Scalar(container, "tty") == "true"; enabled {
+		tty = &enabled
+	}
+
+	return manifests.Object(
+		"image", manifests.Scalar(container, "image"),
+		"pull_policy", policies[manifests.Scalar(container, "imagePullPolicy")],
+		"entrypoint", command,
+		"command", args,
+		"working_dir", manifests.Scalar(container, "workingDir"),
+		"env_file", files,
+		"environment", environment,
+		"healthcheck", c.healthcheck(document, container, siblings),
+		"deploy", c.deploy(document, container),
+		"stdin_open", stdin,
+		"tty", tty,
+		"restart", policy,
+	)
+}
+
+// escape prevents compose from interpolating a literal value's "$" character(s).
+func escape(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
+
+// placeholders maps downward-API field paths to a local stand-in value.
+var placeholders = map[string]func(document *manifests.Document, namespace string) string{
+	"metadata.name":           func(document *manifests.Document, _ string) string { return document.Name() },
+	"metadata.namespace":      func(_ *manifests.Document, namespace string) string { return namespace },
+	"metadata.uid":            func(*manifests.Document, string) string { return "00000000-0000-0000-0000-000000000000" },
+	"spec.nodeName":           func(*manifests.Document, string) string { return "localhost" },
+	"spec.serviceAccountName": account,
+	"status.podIP":            func(*manifests.Document, string) string { return "127.0.0.1" },
+	"status.podIPs":           func(*manifests.Document, string) string { return "127.0.0.1" },
+	"status.hostIP":           func(*manifests.Document, string) string { return "127.0.0.1" },
+	"status.hostIPs":          func(*manifests.Document, string) string { return "127.0.0.1" },
+}
+
+// account returns the service account a workload's pods run as.
+func account(document *manifests.Document, _ string) string {
+	spec, _ := document.PodSpec()
+	if name := manifests.Scalar(spec, "serviceAccountName"); name != "" {
+		return name
+	}
+
+	return "default"
+}
+
+// environment converts a container's env and envFrom. Plain values and downward-API placeholders - which may be
+// overridden from the host's environment - are returned as the service's environment, and ConfigMap and Secret
+// values are written to the service's ".env" file(s), whose names are returned.
+func (c *Converter) environment(document *manifests.Document, service string, container *yaml.Node) (*yaml.Node, []string) {
+	namespace := c.namespace(document)
+
+	configuration := &File{Name: service + ".env"}
+	secrets := &File{Name: service + ".secret.env", Sensitive: true}
+
+	for _, source := range manifests.Items(manifests.Value(container, "envFrom")) {
+		prefix := manifests.Scalar(source, "prefix")
+
+		for _, reference := range []struct {
+			field, kind string
+			file        *File
+		}{{"configMapRef", "ConfigMap", configuration}, {"secretRef", "Secret", secrets}} {
+			node := manifests.Value(source, reference.field)
+			if node == nil {
+				continue
+			}
+
+			data, found := c.data(document, reference.kind, namespace, manifests.Scalar(node, "name"))
+			if !(found) {
+				continue
+			}
+
+			for _, key := range sorted(data) {
+				if !(variable.MatchString(prefix + key)) {
+					c.warn(document, "%s key %s isn't a valid variable name - it isn't converted", manifests.Reference(reference.kind, manifests.Scalar(node, "name")), prefix+key)
+					continue
+				}
+
+				reference.file.set(prefix+key, data[key])
+			}
+		}
+	}
+
+	environment := manifests.Mapping()
+	for _, variable := range manifests.Items(manifests.Value(container, "env")) {
+		key := manifests.Scalar(variable, "name")
+
+		source := manifests.Value(variable, "valueFrom")
+		if source == nil {
+			value := manifests.Scalar(variable, "value")
+			if strings.Contains(value, "$(") {
+				c.warn(document, "variable %s references another variable, which isn't expanded", key)
+			}
+
+			manifests.Set(environment, key, manifests.String(escape(value)))
+
+			continue
+		}
+
+		switch {
+		case manifests.Value(source, "fieldRef") != nil:
+			path := manifests.Scalar(source, "fieldRef", "fieldPath")
+
+			placeholder := path
+			if resolver, found := placeholders[path]; found {
+				placeholder = resolver(document, namespace)
+			} else if label, found := strings.CutPrefix(path, "metadata.labels['"); found {
+				placeholder = labels(document)[strings.TrimSuffix(label, "']")]
+			} else if annotation, found := strings.CutPrefix(path, "metadata.annotations['"); found {
+				template, _ := document.PodTemplate()
+				placeholder = manifests.Map(manifests.Value(template, "annotations"))[strings.TrimSuffix(annotation, "']")]
+			}
+
+			manifests.Set(environment, key, manifests.String(fmt.Sprintf("${%s:-%s}", key, escape(placeholder))))
+		case manifests.Value(source, "resourceFieldRef") != nil:
+			resource := manifests.Scalar(source, "resourceFieldRef", "resource")
+
+			kind, field, _ := strings.Cut(resource, ".")
+			value := manifests.Scalar(container, "resources", kind, field)
+
+			manifests.Set(environment, key, manifests.String(fmt.Sprintf("${%s:-%s}", key, value)))
+		case manifests.Value(source, "configMapKeyRef") != nil, manifests.Value(source, "secretKeyRef") != nil:
+			kind, field, file := "ConfigMap", "configMapKeyRef", configuration
+			if manifests.Value(source, "secretKeyRef") != nil {
+				kind, field, file = "Secret", "secretKeyRef", secrets
+			}
+
+			name, entry := manifests.Scalar(source, field, "name"), manifests.Scalar(source, field, "key")
+
+			data, found := c.data(document, kind, namespace, name)
+			if !(found) {
+				manifests.Set(environment, key, manifests.String(fmt.Sprintf("${%s:-}", key)))
+				continue
+			}
+
+			value, exists := data[entry]
+			if !(exists) {
+				c.warn(document, "%s doesn't contain key %s (variable %s)", manifests.Reference(kind, name), entry, key)
+				continue
+			}
+
+			file.set(key, value)
+		default:
+			c.warn(document, "variable %s has an unsupported source", key)
+		}
+	}
+
+	var files []string
+	for _, file := range []*File{configuration, secrets} {
+		if len(file.Variables) > 0 {
+			c.project.Files = append(c.project.Files, file)
+			files = append(files, file.Name)
+		}
+	}
+
+	return environment, files
+}
+
+// data returns the decoded data of a ConfigMap or Secret, reporting whether the resource is part of the bundle.
+func (c *Converter) data(document *manifests.Document, kind, namespace, name string) (map[string]string, bool) {
+	resource := c.lookup(kind, namespace, name)
+	if resource == nil {
+		c.warn(document, "%s isn't part of the bundle - its variable(s) are left to the host's environment", manifests.Reference(kind, name))
+		return nil, false
+	}
+
+	data := make(map[string]string)
+	for key, value := range manifests.Map(manifests.Value(resource.Root(), "data")) {
+		if kind == "Secret" {
+			decoded, e := base64.StdEncoding.DecodeString(value)
+			if e != nil {
+				c.warn(resource, "key %s isn't valid base64 - it isn't converted", key)
+				continue
+			}
+
+			value = string(decoded)
+		}
+
+		data[key] = value
+	}
+
+	for key, value := range manifests.Map(manifests.Value(resource.Root(), "stringData")) {
+		data[key] = value
+	}
+
+	if manifests.Value(resource.Root(), "binaryData") != nil {
+		c.warn(resource, "binaryData isn't converted")
+	}
+
+	return data, true
+}
+
+// sorted returns a map's keys in lexical order.
+func sorted(mapping map[string]string) []string {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
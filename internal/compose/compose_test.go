@@ -0,0 +1,166 @@
+package compose
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/dotenv"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// bundle is a workload, the Service that selects it, and the configuration it references.
+const bundle = `apiVersion: apps/v1
+kind: Deployment
+metadata: { name: api }
+spec:
+    replicas: 2
+    template:
+        metadata:
+            labels: { app: api }
+        spec:
+            initContainers:
+                - { name: migrate, image: "api:1.0.0", args: [ migrate ] }
+            containers:
+                - name: api
+                  image: api:1.0.0
+                  imagePullPolicy: IfNotPresent
+                  ports: [ { name: http, containerPort: 8080 } ]
+                  env:
+                      - { name: GREETING, value: "cost: $5" }
+                      - { name: POD_IP, valueFrom: { fieldRef: { fieldPath: status.podIP } } }
+                      - { name: PASSWORD, valueFrom: { secretKeyRef: { name: credentials, key: password } } }
+                  envFrom: [ { configMapRef: { name: settings } } ]
+                  readinessProbe: { httpGet: { path: health, port: http }, periodSeconds: 5 }
+                  resources: { limits: { cpu: 500m, memory: 512Mi } }
+                - { name: proxy, image: "proxy:1.0.0" }
+---
+apiVersion: v1
+kind: Service
+metadata: { name: backend }
+spec:
+    selector: { app: api }
+    ports: [ { port: 80, targetPort: http } ]
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: { name: settings }
+data: { LEVEL: info }
+---
+apiVersion: v1
+kind: Secret
+metadata: { name: credentials }
+data: { password: c2VjcmV0 }
+`
+
+// convert decodes content and converts its documents.
+func convert(t *testing.T, content string) *Project {
+	t.Helper()
+
+	documents, e := manifests.Decode("test.yaml", strings.NewReader(content))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	return (&Converter{Namespace: "default"}).Convert(documents...)
+}
+
+func TestConverterConvert(t *testing.T) {
+	project := convert(t, bundle)
+
+	var buffer strings.Builder
+	if e := project.Write(&buffer); e != nil {
+		t.Fatalf("Write() returned an unexpected error: %v", e)
+	}
+
+	expected := `services:
+    api-migrate:
+        image: api:1.0.0
+        command:
+            - migrate
+        restart: "no"
+    api:
+        image: api:1.0.0
+        pull_policy: missing
+        env_file:
+            - api.env
+            - api.secret.env
+        environment:
+            GREETING: "cost: $$5"
+            POD_IP: ${POD_IP:-127.0.0.1}
+        healthcheck:
+            test:
+                - CMD-SHELL
+                - curl -fsS -o /dev/null http://localhost:8080/health || exit 1
+            interval: 5s
+            timeout: 1s
+            retries: 3
+        deploy:
+            resources:
+                limits:
+                    cpus: "0.5"
+                    memory: 512m
+        restart: unless-stopped
+        ports:
+            - 80:8080
+        networks:
+            default:
+                aliases:
+                    - backend
+        depends_on:
+            api-migrate:
+                condition: service_completed_successfully
+    api-proxy:
+        image: proxy:1.0.0
+        restart: unless-stopped
+        network_mode: service:api
+`
+	if buffer.String() != expected {
+		t.Errorf("Convert() =\n%s\nexpected\n%s", buffer.String(), expected)
+	}
+
+	if expected := []string{"deployment/api: replicas (2) are reduced to a single instance"}; !(slices.Equal(project.Warnings, expected)) {
+		t.Errorf("Convert() warnings = %q, expected %q", project.Warnings, expected)
+	}
+
+	if len(project.Files) != 2 {
+		t.Fatalf("Convert() = %d env files, expected 2", len(project.Files))
+	}
+
+	if configuration := project.Files[0]; configuration.Sensitive || !(slices.Equal(configuration.Variables, []dotenv.Variable{{Key: "LEVEL", Value: "info"}})) {
+		t.Errorf("Convert() %s = %+v, expected a non-sensitive LEVEL=info", configuration.Name, configuration)
+	}
+
+	if secrets := project.Files[1]; !(secrets.Sensitive) || !(slices.Equal(secrets.Variables, []dotenv.Variable{{Key: "PASSWORD", Value: "secret"}})) {
+		t.Errorf("Convert() %s = %+v, expected a sensitive, decoded PASSWORD=secret", secrets.Name, secrets)
+	}
+}
+
+func TestConverterConvertSelector(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  string
+		published bool
+	}{
+		{name: "matching", selector: "{ app: api }", published: true},
+		{name: "mismatched", selector: "{ app: other }"},
+		{name: "missing-label", selector: `{ app: api, canary: "" }`},
+		{name: "empty", selector: "{}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			project := convert(t, strings.Replace(bundle, "selector: { app: api }", "selector: "+test.selector, 1))
+
+			published := manifests.Lookup(project.Services, "api", "ports") != nil
+			if published != test.published {
+				t.Errorf("Convert() published ports = %t, expected %t", published, test.published)
+			}
+
+			warned := slices.Contains(project.Warnings, "service/backend: selector doesn't match a converted workload - its ports aren't published")
+			if warned == test.published {
+				t.Errorf("Convert() warnings = %q, expected a warning: %t", project.Warnings, !(test.published))
+			}
+		})
+	}
+}
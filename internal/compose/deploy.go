@@ -0,0 +1,59 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/resources"
+)
+
+// deploy converts a container's resource limits and requests into compose's "deploy.resources" limits and
+// reservations.
+func (c *Converter) deploy(document *manifests.Document, container *yaml.Node) *yaml.Node {
+	convert := func(field string) *yaml.Node {
+		values := manifests.Lookup(container, "resources", field)
+
+		var cpus, memory string
+		if value := manifests.Scalar(values, "cpu"); value != "" {
+			if quantity, e := resources.ParseQuantity(value); e != nil {
+				c.warn(document, "container %s cpu %s (%s) isn't a valid quantity", manifests.Scalar(container, "name"), field, value)
+			} else {
+				cpus = strconv.FormatFloat(float64(quantity.Millicores())/1_000, 'f', -1, 64)
+			}
+		}
+
+		if value := manifests.Scalar(values, "memory"); value != "" {
+			if quantity, e := resources.ParseQuantity(value); e != nil {
+				c.warn(document, "container %s memory %s (%s) isn't a valid quantity", manifests.Scalar(container, "name"), field, value)
+			} else {
+				memory = bytes(quantity.Bytes())
+			}
+		}
+
+		return manifests.Object("cpus", cpus, "memory", memory)
+	}
+
+	node := manifests.Object("limits", convert("limits"), "reservations", convert("requests"))
+	if len(node.Content) == 0 {
+		return nil
+	}
+
+	return manifests.Object("resources", node)
+}
+
+// bytes formats a byte count with the largest of compose's binary units it's a whole multiple of (e.g. "512m").
+func bytes(value int64) string {
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{{"g", 1 << 30}, {"m", 1 << 20}, {"k", 1 << 10}} {
+		if value >= unit.multiplier && value%unit.multiplier == 0 {
+			return fmt.Sprintf("%d%s", value/unit.multiplier, unit.suffix)
+		}
+	}
+
+	return fmt.Sprintf("%db", value)
+}
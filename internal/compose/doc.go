@@ -0,0 +1,2 @@
+// Package compose converts kubernetes workloads and services into a docker-compose project for local development.
+package compose
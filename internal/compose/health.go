@@ -0,0 +1,126 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// healthcheck converts a container's readiness probe - or, failing that, its liveness or startup probe - into a compose
+// healthcheck. Named probe ports are resolved against the pod's containers. Timing fields fall back to kubernetes'
+// defaults, which differ from compose's.
+func (c *Converter) healthcheck(document *manifests.Document, container *yaml.Node, siblings []*yaml.Node) *yaml.Node {
+	var probe *yaml.Node
+	var field string
+	var skipped []string
+	for _, candidate := range []string{"readinessProbe", "livenessProbe", "startupProbe"} {
+		node := manifests.Value(container, candidate)
+		if node == nil {
+			continue
+		}
+
+		if probe == nil {
+			probe, field = node, candidate
+		} else {
+			skipped = append(skipped, candidate)
+		}
+	}
+
+	if probe == nil {
+		return nil
+	}
+
+	name := manifests.Scalar(container, "name")
+
+	if len(skipped) > 0 {
+		c.warn(document, "container %s %s isn't converted - the healthcheck uses its %s", name, strings.Join(skipped, " and "), field)
+	}
+
+	port := func(handler string) (string, bool) {
+		value := manifests.Scalar(probe, handler, "port")
+
+		resolved, found := resolve(append([]*yaml.Node{container}, siblings...), value)
+		if !(found) {
+			c.warn(document, "container %s %s port %s isn't declared - the healthcheck isn't converted", name, field, value)
+		}
+
+		return resolved, found
+	}
+
+	var test []string
+	switch {
+	case manifests.Value(probe, "exec") != nil:
+		test = []string{"CMD"}
+		for _, item := range manifests.Items(manifests.Lookup(probe, "exec", "command")) {
+			test = append(test, escape(item.Value))
+		}
+	case manifests.Value(probe, "httpGet") != nil:
+		number, found := port("httpGet")
+		if !(found) {
+			return nil
+		}
+
+		scheme, insecure := "http", ""
+		if strings.EqualFold(manifests.Scalar(probe, "httpGet", "scheme"), "HTTPS") {
+			scheme, insecure = "https", "k"
+		}
+
+		host := manifests.Scalar(probe, "httpGet", "host")
+		if host == "" {
+			host = "localhost"
+		}
+
+		path := manifests.Scalar(probe, "httpGet", "path")
+		if !(strings.HasPrefix(path, "/")) {
+			path = "/" + path
+		}
+
+		var headers string
+		for _, header := range manifests.Items(manifests.Lookup(probe, "httpGet", "httpHeaders")) {
+			headers += fmt.Sprintf(" -H '%s: %s'", manifests.Scalar(header, "name"), manifests.Scalar(header, "value"))
+		}
+
+		test = []string{"CMD-SHELL", escape(fmt.Sprintf("curl -fsS%s%s -o /dev/null %s://%s:%s%s || exit 1", insecure, headers, scheme, host, number, path))}
+	case manifests.Value(probe, "tcpSocket") != nil:
+		number, found := port("tcpSocket")
+		if !(found) {
+			return nil
+		}
+
+		test = []string{"CMD-SHELL", fmt.Sprintf("nc -z localhost %s || exit 1", number)}
+	default:
+		c.warn(document, "container %s %s has an unsupported handler - the healthcheck isn't converted", name, field)
+		return nil
+	}
+
+	seconds := func(key string, fallback int) string {
+		value, e := strconv.Atoi(manifests.Scalar(probe, key))
+		if e != nil {
+			value = fallback
+		}
+
+		return fmt.Sprintf("%ds", value)
+	}
+
+	retries, e := strconv.Atoi(manifests.Scalar(probe, "failureThreshold"))
+	if e != nil {
+		retries = 3
+	}
+
+	var start string
+	if manifests.Scalar(probe, "initialDelaySeconds") != "" {
+		start = seconds("initialDelaySeconds", 0)
+	}
+
+	return manifests.Object(
+		"test", test,
+		"interval", seconds("periodSeconds", 10),
+		"timeout", seconds("timeoutSeconds", 1),
+		"retries", retries,
+		"start_period", start,
+	)
+}
@@ -0,0 +1,60 @@
+package deprecations
+
+import (
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+	"github.com/x-ethr/ethr-cli/internal/schema"
+)
+
+// Status describes an API version's state as of the target kubernetes version.
+type Status string
+
+const (
+	Deprecated Status = "deprecated" // Deprecated API versions are still served, but will be removed.
+	Removed    Status = "removed"    // Removed API versions are no longer served.
+)
+
+// Finding represents a single use of a deprecated or removed API version.
+type Finding struct {
+	File     string `json:"file" yaml:"file"`
+	Document int    `json:"document" yaml:"document"`
+	Resource string `json:"resource" yaml:"resource"`
+	Status   Status `json:"status" yaml:"status"`
+
+	Deprecation `yaml:",inline"`
+}
+
+// Lookup returns the deprecation of an API version and kind, if any.
+func Lookup(deprecations []Deprecation, apiVersion, kind string) (Deprecation, bool) {
+	for _, deprecation := range deprecations {
+		if deprecation.APIVersion == apiVersion && deprecation.Kind == kind {
+			return deprecation, true
+		}
+	}
+
+	return Deprecation{}, false
+}
+
+// Check returns every document that uses an API version deprecated as of target (a "<major>.<minor>" kubernetes
+// version), in document order. API versions deprecated after target aren't reported.
+func Check(target string, deprecations []Deprecation, documents ...*manifests.Document) []Finding {
+	var findings []Finding
+	for _, document := range documents {
+		if document.Empty() || document.Kustomization() {
+			continue
+		}
+
+		deprecation, exists := Lookup(deprecations, document.APIVersion(), document.Kind())
+		if !(exists) || schema.Compare(target, deprecation.Deprecated) < 0 {
+			continue
+		}
+
+		status := Deprecated
+		if schema.Compare(target, deprecation.Removed) >= 0 {
+			status = Removed
+		}
+
+		findings = append(findings, Finding{File: document.File, Document: document.Index, Resource: document.String(), Status: status, Deprecation: deprecation})
+	}
+
+	return findings
+}
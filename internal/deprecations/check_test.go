@@ -0,0 +1,59 @@
+package deprecations
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// bundle uses an API version removed in 1.16, one deprecated in 1.19 and removed in 1.22, and a current one.
+const bundle = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata: { name: api }
+---
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata: { name: api }
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: { name: current }
+`
+
+func TestCheck(t *testing.T) {
+	deprecations, e := Table()
+	if e != nil {
+		t.Fatalf("Table() returned an unexpected error: %v", e)
+	}
+
+	documents, e := manifests.Decode("bundle.yaml", strings.NewReader(bundle))
+	if e != nil {
+		t.Fatalf("unable to decode documents: %v", e)
+	}
+
+	tests := []struct {
+		target   string
+		expected []string
+	}{
+		{target: "1.8"},
+		{target: "1.9", expected: []string{"deployment/api (bundle.yaml[0]): deprecated"}},
+		{target: "1.16", expected: []string{"deployment/api (bundle.yaml[0]): removed"}},
+		{target: "1.19", expected: []string{"deployment/api (bundle.yaml[0]): removed", "ingress/api (bundle.yaml[1]): deprecated"}},
+		{target: "1.22", expected: []string{"deployment/api (bundle.yaml[0]): removed", "ingress/api (bundle.yaml[1]): removed"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			var findings []string
+			for _, finding := range Check(test.target, deprecations, documents...) {
+				findings = append(findings, fmt.Sprintf("%s (%s[%d]): %s", finding.Resource, finding.File, finding.Document, finding.Status))
+			}
+
+			if strings.Join(findings, "\n") != strings.Join(test.expected, "\n") {
+				t.Errorf("Check(%s) = %v, expected %v", test.target, findings, test.expected)
+			}
+		})
+	}
+}
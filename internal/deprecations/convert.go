@@ -0,0 +1,233 @@
+package deprecations
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// conversions rewrite a document's root mapping from a deprecated API version's schema to its replacement's. The
+// "apiVersion" itself is updated by [Convert].
+var conversions = map[string]func(root *yaml.Node) error{
+	"rename":       func(root *yaml.Node) error { return nil },
+	"workload":     workload,
+	"ingress":      ingress,
+	"autoscaling":  autoscaling,
+	"runtimeclass": runtimeclass,
+	"flowcontrol":  flowcontrol,
+}
+
+// Convert rewrites a document to the deprecation's replacement API version, retaining its comments and field order.
+func Convert(document *manifests.Document, deprecation Deprecation) error {
+	if !(deprecation.Convertible()) {
+		return fmt.Errorf("%s (%s) can't be converted automatically", document.String(), deprecation.APIVersion)
+	}
+
+	root := document.Root()
+	if root == nil || root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: invalid document - expecting a mapping", document.Location())
+	}
+
+	if e := conversions[deprecation.Conversion](root); e != nil {
+		return fmt.Errorf("unable to convert %s (%s): %w", document.String(), document.Location(), e)
+	}
+
+	manifests.Value(root, "apiVersion").Value = deprecation.Replacement
+
+	return nil
+}
+
+// workload converts extensions/v1beta1 and apps/v1beta* workloads to apps/v1 - where "spec.selector" is required
+// rather than defaulted from the pod template's labels, and "spec.rollbackTo" and "spec.templateGeneration" no longer exist.
+func workload(root *yaml.Node) error {
+	spec := manifests.Value(root, "spec")
+	if spec == nil || spec.Kind != yaml.MappingNode {
+		return errors.New("invalid spec - expecting a mapping")
+	}
+
+	if selector := manifests.Value(spec, "selector"); selector == nil || selector.Tag == "!!null" {
+		labels := manifests.Lookup(spec, "template", "metadata", "labels")
+		if labels == nil || labels.Kind != yaml.MappingNode || len(labels.Content) == 0 {
+			return errors.New("spec.selector is required, and can't be derived - spec.template.metadata.labels is empty")
+		}
+
+		selector = manifests.Mapping()
+		manifests.Set(selector, "matchLabels", manifests.Copy(labels))
+		manifests.Set(spec, "selector", selector)
+	}
+
+	manifests.Delete(spec, "rollbackTo")
+	manifests.Delete(spec, "templateGeneration")
+
+	return nil
+}
+
+// ingress converts extensions/v1beta1 and networking.k8s.io/v1beta1 Ingresses to networking.k8s.io/v1: "spec.backend"
+// becomes "spec.defaultBackend", backends' "serviceName" & "servicePort" become "service.name" & "service.port.number"
+// (or "service.port.name"), and paths require a "pathType".
+func ingress(root *yaml.Node) error {
+	spec := manifests.Value(root, "spec")
+	if spec == nil || spec.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	if key, _ := manifests.Pair(spec, "backend"); key != nil {
+		key.Value = "defaultBackend"
+	}
+
+	if e := backend(manifests.Value(spec, "defaultBackend")); e != nil {
+		return fmt.Errorf("spec.defaultBackend: %w", e)
+	}
+
+	for r, rule := range manifests.Items(manifests.Value(spec, "rules")) {
+		for p, path := range manifests.Items(manifests.Lookup(rule, "http", "paths")) {
+			if e := backend(manifests.Value(path, "backend")); e != nil {
+				return fmt.Errorf("spec.rules[%d].http.paths[%d].backend: %w", r, p, e)
+			}
+
+			if manifests.Scalar(path, "pathType") == "" && path.Kind == yaml.MappingNode {
+				manifests.Set(path, "pathType", manifests.String("ImplementationSpecific"))
+			}
+		}
+	}
+
+	return nil
+}
+
+// backend converts a v1beta1 Ingress backend in place.
+func backend(node *yaml.Node) error {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	key, name := manifests.Pair(node, "serviceName")
+	if key == nil {
+		return nil
+	}
+
+	port := manifests.Value(node, "servicePort")
+	if port == nil || port.Kind != yaml.ScalarNode {
+		return errors.New("servicePort is required alongside serviceName")
+	}
+
+	target := manifests.Mapping()
+	if number, e := strconv.Atoi(port.Value); e == nil {
+		manifests.Set(target, "number", manifests.Integer(number))
+	} else {
+		manifests.Set(target, "name", manifests.String(port.Value))
+	}
+
+	service := manifests.Mapping()
+	manifests.Set(service, "name", name)
+	manifests.Set(service, "port", target)
+
+	// --> "service" takes "serviceName"'s position (and comments)
+	key.Value = "service"
+	manifests.Set(node, "service", service)
+	manifests.Delete(node, "servicePort")
+
+	return nil
+}
+
+// autoscaling converts autoscaling/v2beta1 HorizontalPodAutoscalers to autoscaling/v2, where each metric's target is a
+// "target" of a type ("Utilization", "Value", or "AverageValue"), and its name and selector a "metric".
+func autoscaling(root *yaml.Node) error {
+	for index, metric := range manifests.Items(manifests.Lookup(root, "spec", "metrics")) {
+		field := map[string]string{"Resource": "resource", "Pods": "pods", "Object": "object", "External": "external"}[manifests.Scalar(metric, "type")]
+
+		source := manifests.Value(metric, field)
+		if field == "" || source == nil || source.Kind != yaml.MappingNode {
+			continue
+		}
+
+		converted := manifests.Mapping()
+
+		switch field {
+		case "resource":
+			manifests.Set(converted, "name", manifests.Value(source, "name"))
+		case "object":
+			if described := manifests.Value(source, "target"); described != nil {
+				manifests.Set(converted, "describedObject", described)
+			}
+
+			fallthrough
+		default:
+			identifier := manifests.Mapping()
+			if name := manifests.Value(source, "metricName"); name != nil {
+				manifests.Set(identifier, "name", name)
+			}
+
+			for _, key := range []string{"selector", "metricSelector"} {
+				if selector := manifests.Value(source, key); selector != nil {
+					manifests.Set(identifier, "selector", selector)
+				}
+			}
+
+			manifests.Set(converted, "metric", identifier)
+		}
+
+		target := manifests.Mapping()
+		switch {
+		case manifests.Value(source, "targetAverageUtilization") != nil:
+			manifests.Set(target, "type", manifests.String("Utilization"))
+			manifests.Set(target, "averageUtilization", manifests.Value(source, "targetAverageUtilization"))
+		case manifests.Value(source, "targetAverageValue") != nil:
+			manifests.Set(target, "type", manifests.String("AverageValue"))
+			manifests.Set(target, "averageValue", manifests.Value(source, "targetAverageValue"))
+		case manifests.Value(source, "averageValue") != nil:
+			manifests.Set(target, "type", manifests.String("AverageValue"))
+			manifests.Set(target, "averageValue", manifests.Value(source, "averageValue"))
+		case manifests.Value(source, "targetValue") != nil:
+			manifests.Set(target, "type", manifests.String("Value"))
+			manifests.Set(target, "value", manifests.Value(source, "targetValue"))
+		default:
+			return fmt.Errorf("spec.metrics[%d]: no target value", index)
+		}
+
+		manifests.Set(converted, "target", target)
+
+		source.Content = converted.Content
+	}
+
+	return nil
+}
+
+// runtimeclass converts node.k8s.io/v1beta1 RuntimeClasses to node.k8s.io/v1, where "spec.runtimeHandler",
+// "spec.overhead", and "spec.scheduling" are top-level "handler", "overhead", and "scheduling" fields.
+func runtimeclass(root *yaml.Node) error {
+	spec := manifests.Value(root, "spec")
+	if spec == nil {
+		return nil
+	}
+
+	var fields []*yaml.Node
+	for _, pair := range [][2]string{{"runtimeHandler", "handler"}, {"overhead", "overhead"}, {"scheduling", "scheduling"}} {
+		if key, value := manifests.Pair(spec, pair[0]); key != nil {
+			key.Value = pair[1]
+			fields = append(fields, key, value)
+		}
+	}
+
+	for index := 0; index+1 < len(root.Content); index += 2 {
+		if root.Content[index].Value == "spec" {
+			root.Content = append(root.Content[:index], append(fields, root.Content[index+2:]...)...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// flowcontrol converts flowcontrol.apiserver.k8s.io/v1beta1 and v1beta2 PriorityLevelConfigurations, whose
+// "spec.limited.assuredConcurrencyShares" is "spec.limited.nominalConcurrencyShares" as of v1beta3.
+func flowcontrol(root *yaml.Node) error {
+	if key, _ := manifests.Pair(manifests.Lookup(root, "spec", "limited"), "assuredConcurrencyShares"); key != nil {
+		key.Value = "nominalConcurrencyShares"
+	}
+
+	return nil
+}
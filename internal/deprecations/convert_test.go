@@ -0,0 +1,94 @@
+package deprecations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+func TestConvert(t *testing.T) {
+	deprecations, e := Table()
+	if e != nil {
+		t.Fatalf("Table() returned an unexpected error: %v", e)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		error    string // error is a substring of the expected error, if any
+	}{
+		{
+			name:     "workload",
+			input:    "apiVersion: extensions/v1beta1\nkind: Deployment\nmetadata:\n    name: api # comment\nspec:\n    rollbackTo: { revision: 1 }\n    template:\n        metadata:\n            labels: { app: api }\n",
+			expected: "---\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n    name: api # comment\nspec:\n    template:\n        metadata:\n            labels: { app: api }\n    selector:\n        matchLabels: {app: api}\n",
+		},
+		{
+			name:  "workload-without-labels",
+			input: "apiVersion: apps/v1beta2\nkind: StatefulSet\nmetadata: { name: api }\nspec: { template: {} }\n",
+			error: "spec.selector is required",
+		},
+		{
+			name:     "ingress",
+			input:    "apiVersion: extensions/v1beta1\nkind: Ingress\nmetadata:\n    name: api\nspec:\n    backend:\n        serviceName: fallback\n        servicePort: 80\n    rules:\n        - http:\n              paths:\n                  - path: /\n                    backend:\n                        serviceName: api\n                        servicePort: http\n",
+			expected: "---\napiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n    name: api\nspec:\n    defaultBackend:\n        service:\n            name: fallback\n            port:\n                number: 80\n    rules:\n        - http:\n              paths:\n                  - path: /\n                    backend:\n                        service:\n                            name: api\n                            port:\n                                name: http\n                    pathType: ImplementationSpecific\n",
+		},
+		{
+			name:  "ingress-without-port",
+			input: "apiVersion: extensions/v1beta1\nkind: Ingress\nmetadata: { name: api }\nspec: { backend: { serviceName: api } }\n",
+			error: "servicePort is required",
+		},
+		{
+			name:     "autoscaling",
+			input:    "apiVersion: autoscaling/v2beta1\nkind: HorizontalPodAutoscaler\nmetadata:\n    name: api\nspec:\n    metrics:\n        - type: Resource\n          resource:\n              name: cpu\n              targetAverageUtilization: 80\n        - type: Pods\n          pods:\n              metricName: requests\n              targetAverageValue: 10\n",
+			expected: "---\napiVersion: autoscaling/v2\nkind: HorizontalPodAutoscaler\nmetadata:\n    name: api\nspec:\n    metrics:\n        - type: Resource\n          resource:\n              name: cpu\n              target:\n                  type: Utilization\n                  averageUtilization: 80\n        - type: Pods\n          pods:\n              metric:\n                  name: requests\n              target:\n                  type: AverageValue\n                  averageValue: 10\n",
+		},
+		{
+			name:     "runtimeclass",
+			input:    "apiVersion: node.k8s.io/v1beta1\nkind: RuntimeClass\nmetadata:\n    name: gvisor\nspec:\n    runtimeHandler: runsc\n",
+			expected: "---\napiVersion: node.k8s.io/v1\nkind: RuntimeClass\nmetadata:\n    name: gvisor\nhandler: runsc\n",
+		},
+		{
+			name:  "manual",
+			input: "apiVersion: extensions/v1beta1\nkind: PodSecurityPolicy\nmetadata: { name: restricted }\n",
+			error: "can't be converted automatically",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documents, e := manifests.Decode("input.yaml", strings.NewReader(test.input))
+			if e != nil {
+				t.Fatalf("unable to decode document: %v", e)
+			}
+
+			deprecation, exists := Lookup(deprecations, documents[0].APIVersion(), documents[0].Kind())
+			if !(exists) {
+				t.Fatalf("Lookup() found no deprecation of %s", documents[0].APIVersion())
+			}
+
+			e = Convert(documents[0], deprecation)
+			if test.error != "" {
+				if e == nil || !(strings.Contains(e.Error(), test.error)) {
+					t.Errorf("Convert() error = %v, expected %q", e, test.error)
+				}
+
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("Convert() returned an unexpected error: %v", e)
+			}
+
+			var output strings.Builder
+			if e := manifests.Stream(&output, documents...); e != nil {
+				t.Fatalf("unable to encode document: %v", e)
+			}
+
+			if output.String() != test.expected {
+				t.Errorf("Convert() =\n%s\nexpected:\n%s", output.String(), test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package deprecations detects, and converts, the use of deprecated and removed kubernetes API versions.
+//
+// The deprecation table (deprecations.yaml) is embedded; it follows the upstream "Deprecated API Migration Guide".
+package deprecations
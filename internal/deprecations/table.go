@@ -0,0 +1,43 @@
+package deprecations
+
+import (
+	_ "embed"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed deprecations.yaml
+var table []byte
+
+// Deprecation represents a deprecated API version of a kind.
+type Deprecation struct {
+	APIVersion  string `json:"apiVersion" yaml:"apiVersion"`                       // APIVersion is the deprecated "apiVersion" (e.g. "extensions/v1beta1").
+	Kind        string `json:"kind" yaml:"kind"`                                   // Kind is the resource kind the deprecation applies to.
+	Deprecated  string `json:"deprecated" yaml:"deprecated"`                       // Deprecated is the kubernetes version that deprecated the API version.
+	Removed     string `json:"removed" yaml:"removed"`                             // Removed is the kubernetes version that no longer serves the API version.
+	Replacement string `json:"replacement,omitempty" yaml:"replacement,omitempty"` // Replacement is the "apiVersion" to migrate to - empty if none exists.
+	Conversion  string `json:"conversion,omitempty" yaml:"conversion,omitempty"`   // Conversion names the manifest conversion - empty if manual changes are required.
+	Note        string `json:"note,omitempty" yaml:"note,omitempty"`               // Note describes the changes a migration requires.
+}
+
+// Convertible reports whether a manifest can be converted to the replacement automatically.
+func (d Deprecation) Convertible() bool {
+	return d.Replacement != "" && d.Conversion != ""
+}
+
+// Table returns the embedded deprecation table.
+func Table() ([]Deprecation, error) {
+	var deprecations []Deprecation
+	if e := yaml.Unmarshal(table, &deprecations); e != nil {
+		return nil, fmt.Errorf("unable to parse deprecation table: %w", e)
+	}
+
+	for _, deprecation := range deprecations {
+		if _, exists := conversions[deprecation.Conversion]; deprecation.Conversion != "" && !(exists) {
+			return nil, fmt.Errorf("invalid deprecation table - unknown conversion %q (%s, %s)", deprecation.Conversion, deprecation.APIVersion, deprecation.Kind)
+		}
+	}
+
+	return deprecations, nil
+}
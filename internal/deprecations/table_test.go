@@ -0,0 +1,37 @@
+package deprecations
+
+import (
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/schema"
+)
+
+func TestTable(t *testing.T) {
+	deprecations, e := Table()
+	if e != nil {
+		t.Fatalf("Table() returned an unexpected error: %v", e)
+	}
+
+	seen := make(map[string]bool)
+	for _, deprecation := range deprecations {
+		key := deprecation.APIVersion + "/" + deprecation.Kind
+		if seen[key] {
+			t.Errorf("Table() contains a duplicate entry: %s", key)
+		}
+
+		seen[key] = true
+
+		if schema.Compare(deprecation.Deprecated, deprecation.Removed) > 0 {
+			t.Errorf("Table() entry %s is removed (%s) before it's deprecated (%s)", key, deprecation.Removed, deprecation.Deprecated)
+		}
+
+		if deprecation.Conversion == "" && deprecation.Note == "" {
+			t.Errorf("Table() entry %s requires manual changes, but doesn't describe them", key)
+		}
+
+		// --> a conversion's output must not itself require converting
+		if replacement, exists := Lookup(deprecations, deprecation.Replacement, deprecation.Kind); exists && deprecation.Convertible() {
+			t.Errorf("Table() entry %s converts to a deprecated API version: %s", key, replacement.APIVersion)
+		}
+	}
+}
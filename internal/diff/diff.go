@@ -0,0 +1,508 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Operation describes how a resource, or one of its fields, differs.
+type Operation string
+
+const (
+	Added   Operation = "added"
+	Removed Operation = "removed"
+	Changed Operation = "changed"
+)
+
+// ErrDifferences is returned by commands that found differences - exiting non-zero, as "kubectl diff" does, without
+// being reported as an error.
+var ErrDifferences = errors.New("differences found")
+
+// Mask replaces the values of a Secret's "data" and "stringData".
+const Mask = "***"
+
+// Ignored are the field expressions (see [Pattern]) that are always excluded - fields maintained by the API server
+// rather than the manifests' authors.
+var Ignored = []string{
+	"status",
+	"metadata.managedFields",
+	"metadata.creationTimestamp",
+	"metadata.resourceVersion",
+	"metadata.uid",
+	"metadata.generation",
+	"metadata.selfLink",
+	`metadata.annotations["kubectl.kubernetes.io/last-applied-configuration"]`,
+	`metadata.annotations["deployment.kubernetes.io/revision"]`,
+}
+
+// defaults are fields the API server defaults, by key. A field that's only present on one side, with its default value,
+// isn't a difference.
+var defaults = map[string]interface{}{
+	"dnsPolicy":                     "ClusterFirst",
+	"imagePullPolicy":               "IfNotPresent",
+	"progressDeadlineSeconds":       600,
+	"protocol":                      "TCP",
+	"restartPolicy":                 "Always",
+	"revisionHistoryLimit":          10,
+	"schedulerName":                 "default-scheduler",
+	"sessionAffinity":               "None",
+	"terminationGracePeriodSeconds": 30,
+	"terminationMessagePath":        "/dev/termination-log",
+	"terminationMessagePolicy":      "File",
+}
+
+// identifiers are the keys, in order of preference, by which sequences of mappings (e.g. containers, ports, volumes) are
+// compared item by item - regardless of their order.
+var identifiers = []string{"name", "mountPath", "containerPort", "port", "ip"}
+
+// Change represents a single differing field.
+type Change struct {
+	Operation Operation   `json:"operation" yaml:"operation"`
+	Path      string      `json:"path" yaml:"path"`
+	Before    interface{} `json:"before,omitempty" yaml:"before,omitempty"`
+	After     interface{} `json:"after,omitempty" yaml:"after,omitempty"`
+}
+
+// Resource represents a resource that was added, removed, or changed.
+type Resource struct {
+	Operation Operation `json:"operation" yaml:"operation"`
+	Resource  string    `json:"resource" yaml:"resource"`
+	Group     string    `json:"group,omitempty" yaml:"group,omitempty"`
+	Kind      string    `json:"kind" yaml:"kind"`
+	Namespace string    `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string    `json:"name" yaml:"name"`
+	Before    string    `json:"before,omitempty" yaml:"before,omitempty"` // Before is the source location of the resource's previous definition.
+	After     string    `json:"after,omitempty" yaml:"after,omitempty"`   // After is the source location of the resource's new definition.
+	Changes   []Change  `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// Options configures a comparison.
+type Options struct {
+	Namespace string   // Namespace is attributed to resources that don't declare one.
+	Ignore    []string // Ignore are field expressions (see [Pattern]) excluded in addition to [Ignored].
+	Defaults  bool     // Defaults reports fields that are only present on one side with their server-side default value.
+}
+
+// Compare matches the resources of before and after by group, kind, namespace, and name, returning the resources that
+// differ - in after's order, followed by removed resources in before's order. Ordering, formatting, and quoting don't
+// affect the comparison; neither do null or empty values versus absent ones, nor the order of sequence items that are
+// identified by a key (e.g. containers by "name").
+func Compare(before, after []*manifests.Document, options Options) ([]Resource, error) {
+	// --> resources are matched by their (resolved) namespace; declaring the default one isn't a difference
+	patterns := []manifests.Path{{"metadata", "namespace"}}
+	for _, expression := range append(append([]string{}, Ignored...), options.Ignore...) {
+		pattern, e := Pattern(expression)
+		if e != nil {
+			return nil, e
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	previous, order, e := index(before, options.Namespace)
+	if e != nil {
+		return nil, e
+	}
+
+	current, sequence, e := index(after, options.Namespace)
+	if e != nil {
+		return nil, e
+	}
+
+	var resources []Resource
+	for _, key := range sequence {
+		document := current[key]
+
+		resource := describe(document, options.Namespace)
+
+		source, exists := previous[key]
+		if !(exists) {
+			resource.Operation, resource.After = Added, document.Location()
+			resources = append(resources, resource)
+			continue
+		}
+
+		var left, right interface{}
+		if e := source.Decode(&left); e != nil {
+			return nil, fmt.Errorf("%s: %w", source.Location(), e)
+		}
+
+		if e := document.Decode(&right); e != nil {
+			return nil, fmt.Errorf("%s: %w", document.Location(), e)
+		}
+
+		comparison := &comparison{patterns: patterns, omit: !(options.Defaults), secret: document.Kind() == "Secret"}
+		comparison.compare(nil, left, right)
+
+		if len(comparison.changes) > 0 {
+			resource.Operation, resource.Before, resource.After, resource.Changes = Changed, source.Location(), document.Location(), comparison.changes
+			resources = append(resources, resource)
+		}
+	}
+
+	for _, key := range order {
+		if _, exists := current[key]; !(exists) {
+			resource := describe(previous[key], options.Namespace)
+			resource.Operation, resource.Before = Removed, previous[key].Location()
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+// Summary counts the resources by operation.
+func Summary(resources []Resource) map[Operation]int {
+	summary := map[Operation]int{Added: 0, Removed: 0, Changed: 0}
+	for _, resource := range resources {
+		summary[resource.Operation]++
+	}
+
+	return summary
+}
+
+// index maps documents by identity, retaining their order. Kustomizations and empty documents are skipped, and
+// duplicates are resolved as with [manifests.Merge].
+func index(documents []*manifests.Document, namespace string) (map[string]*manifests.Document, []string, error) {
+	documents, e := manifests.Merge(documents...)
+	if e != nil {
+		return nil, nil, e
+	}
+
+	mapping := make(map[string]*manifests.Document)
+
+	var order []string
+	for _, document := range documents {
+		if document.Empty() || document.Kind() == "" || document.Kustomization() {
+			continue
+		}
+
+		key := identity(document, namespace)
+		if previous, exists := mapping[key]; exists {
+			// --> e.g. one document that declares the default namespace, and another that doesn't
+			return nil, nil, fmt.Errorf("conflicting definitions of %s: %s and %s", key, previous.Location(), document.Location())
+		}
+
+		mapping[key] = document
+		order = append(order, key)
+	}
+
+	return mapping, order, nil
+}
+
+// identity is [manifests.Document.Identity], with namespace attributed to a document that doesn't declare one.
+func identity(document *manifests.Document, namespace string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", document.Group(), document.Kind(), resolve(document, namespace), document.Name())
+}
+
+func resolve(document *manifests.Document, namespace string) string {
+	if value := document.Namespace(); value != "" {
+		return value
+	}
+
+	return namespace
+}
+
+func describe(document *manifests.Document, namespace string) Resource {
+	return Resource{Resource: document.String(), Group: document.Group(), Kind: document.Kind(), Namespace: resolve(document, namespace), Name: document.Name()}
+}
+
+// comparison accumulates the changes between two decoded documents.
+type comparison struct {
+	patterns []manifests.Path
+	omit     bool // omit excludes fields only present on one side with their default value
+	secret   bool // secret masks "data" and "stringData"
+
+	changes []Change
+}
+
+func (c *comparison) compare(path manifests.Path, before, after interface{}) {
+	if c.ignored(path) {
+		return
+	}
+
+	switch {
+	case empty(before) && empty(after):
+		return
+	case empty(before):
+		if after = c.prune(path, after); !(empty(after)) {
+			c.record(Added, path, nil, after)
+		}
+
+		return
+	case empty(after):
+		if before = c.prune(path, before); !(empty(before)) {
+			c.record(Removed, path, before, nil)
+		}
+
+		return
+	}
+
+	switch left := before.(type) {
+	case map[string]interface{}:
+		if right, valid := after.(map[string]interface{}); valid {
+			keys := make(map[string]bool)
+			for key := range left {
+				keys[key] = true
+			}
+
+			for key := range right {
+				keys[key] = true
+			}
+
+			for _, key := range sorted(keys) {
+				c.compare(path.Key(key), left[key], right[key])
+			}
+
+			return
+		}
+	case []interface{}:
+		if right, valid := after.([]interface{}); valid {
+			c.sequence(path, left, right)
+			return
+		}
+	}
+
+	if !(reflect.DeepEqual(before, after)) {
+		c.record(Changed, path, before, after)
+	}
+}
+
+// ignored reports whether path matches an ignored field expression.
+func (c *comparison) ignored(path manifests.Path) bool {
+	for _, pattern := range c.patterns {
+		if matches(pattern, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prune returns a copy of value (found at path) without its ignored fields, for values that are recorded whole (e.g. an
+// added mapping).
+func (c *comparison) prune(path manifests.Path, value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		pruned := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			if child := path.Key(key); !(c.ignored(child)) {
+				pruned[key] = c.prune(child, item)
+			}
+		}
+
+		return pruned
+	case []interface{}:
+		pruned := make([]interface{}, len(value))
+		for index, item := range value {
+			pruned[index] = c.prune(path.Index(index), item)
+		}
+
+		return pruned
+	}
+
+	return value
+}
+
+// sequence compares the items of two sequences - by identifier if every item has a unique one (see identifiers),
+// otherwise by index.
+func (c *comparison) sequence(path manifests.Path, before, after []interface{}) {
+	for _, identifier := range identifiers {
+		left, valid := keyed(before, identifier)
+		if !(valid) {
+			continue
+		}
+
+		right, valid := keyed(after, identifier)
+		if !(valid) {
+			continue
+		}
+
+		var order []string
+		for _, item := range after {
+			order = append(order, fmt.Sprint(item.(map[string]interface{})[identifier]))
+		}
+
+		for _, item := range before {
+			if key := fmt.Sprint(item.(map[string]interface{})[identifier]); right[key] == nil {
+				order = append(order, key)
+			}
+		}
+
+		for _, key := range order {
+			c.compare(append(path[:len(path):len(path)], fmt.Sprintf("[%s=%s]", identifier, key)), left[key], right[key])
+		}
+
+		return
+	}
+
+	for index := 0; index < len(before) || index < len(after); index++ {
+		var left, right interface{}
+		if index < len(before) {
+			left = before[index]
+		}
+
+		if index < len(after) {
+			right = after[index]
+		}
+
+		c.compare(path.Index(index), left, right)
+	}
+}
+
+// keyed maps a sequence's items by identifier, reporting whether every item is a mapping with a unique, scalar value for it.
+func keyed(items []interface{}, identifier string) (map[string]interface{}, bool) {
+	mapping := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		object, valid := item.(map[string]interface{})
+		if !(valid) {
+			return nil, false
+		}
+
+		value, exists := object[identifier]
+		if !(exists) || value == nil {
+			return nil, false
+		}
+
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, false
+		}
+
+		key := fmt.Sprint(value)
+		if _, duplicate := mapping[key]; duplicate {
+			return nil, false
+		}
+
+		mapping[key] = item
+	}
+
+	return mapping, len(items) > 0
+}
+
+func (c *comparison) record(operation Operation, path manifests.Path, before, after interface{}) {
+	if c.omit && operation != Changed && len(path) > 0 {
+		value := before
+		if operation == Added {
+			value = after
+		}
+
+		if fallback, exists := defaults[path[len(path)-1]]; exists && reflect.DeepEqual(value, fallback) {
+			return
+		}
+	}
+
+	if c.secret && len(path) > 0 && (path[0] == "data" || path[0] == "stringData") {
+		before, after = mask(before), mask(after)
+		if operation == Changed {
+			before, after = Mask+" (before)", Mask+" (after)"
+		}
+	}
+
+	c.changes = append(c.changes, Change{Operation: operation, Path: path.String(), Before: before, After: after})
+}
+
+// mask replaces every scalar of value with [Mask].
+func mask(value interface{}) interface{} {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			masked[key] = mask(item)
+		}
+
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(value))
+		for index, item := range value {
+			masked[index] = mask(item)
+		}
+
+		return masked
+	default:
+		return Mask
+	}
+}
+
+// empty reports whether value is absent, null, or an empty mapping or sequence.
+func empty(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+
+	return false
+}
+
+func sorted(keys map[string]bool) []string {
+	values := make([]string, 0, len(keys))
+	for key := range keys {
+		values = append(values, key)
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+// Format renders a value on a single line - scalars as YAML would, and mappings and sequences in YAML's flow style.
+// Strings that YAML would resolve as another type (e.g. "1" or "true") are quoted, and integral floats retain their
+// fraction, so that a change of type (e.g. "replicas: 1 -> \"1\"") is visible.
+func Format(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		if value == "" || strings.ContainsAny(value, "\n{}[],") || strings.Contains(value, ": ") || strings.TrimSpace(value) != value {
+			return fmt.Sprintf("%q", value)
+		}
+
+		if scalar := (&yaml.Node{Kind: yaml.ScalarNode, Value: value}); scalar.ShortTag() != "!!str" {
+			return fmt.Sprintf("%q", value)
+		}
+
+		return value
+	case float64:
+		formatted := strconv.FormatFloat(value, 'g', -1, 64)
+		if !(strings.ContainsAny(formatted, ".eIN")) {
+			formatted += ".0"
+		}
+
+		return formatted
+	case map[string]interface{}:
+		keys := make(map[string]bool, len(value))
+		for key := range value {
+			keys[key] = true
+		}
+
+		var items []string
+		for _, key := range sorted(keys) {
+			items = append(items, fmt.Sprintf("%s: %s", key, Format(value[key])))
+		}
+
+		return "{" + strings.Join(items, ", ") + "}"
+	case []interface{}:
+		items := make([]string, len(value))
+		for index, item := range value {
+			items[index] = Format(item)
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	return fmt.Sprint(value)
+}
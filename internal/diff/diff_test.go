@@ -0,0 +1,156 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// decode parses a multi-document manifest, failing the test on error.
+func decode(t *testing.T, file, content string) []*manifests.Document {
+	t.Helper()
+
+	documents, e := manifests.Decode(file, strings.NewReader(content))
+	if e != nil {
+		t.Fatalf("unable to decode %s: %v", file, e)
+	}
+
+	return documents
+}
+
+func TestCompare(t *testing.T) {
+	const deployment = "apiVersion: apps/v1\nkind: Deployment\nmetadata: { name: api }\nspec:\n    replicas: %s\n    template:\n        spec:\n            containers: %s\n"
+
+	tests := []struct {
+		name     string
+		before   string
+		after    string
+		options  Options
+		expected []string // expected are the changed resources' changes - "<operation> <path>: <before> -> <after>"
+	}{
+		{
+			name:    "unchanged-formatting",
+			before:  fmt.Sprintf(deployment, "1", "[ { name: api, image: \"api:1.0.0\" } ]"),
+			after:   "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: api\n  namespace: default\n  labels: {}\nspec:\n  template:\n    spec:\n      containers:\n        - image: api:1.0.0\n          name: api\n  replicas: 1\n",
+			options: Options{Namespace: "default"},
+		},
+		{
+			name:     "type",
+			before:   fmt.Sprintf(deployment, "1", "[]"),
+			after:    fmt.Sprintf(deployment, "\"1\"", "[]"),
+			expected: []string{"changed spec.replicas: 1 -> \"1\""},
+		},
+		{
+			name:     "reordered-items",
+			before:   fmt.Sprintf(deployment, "1", "[ { name: api, image: \"api:1.0.0\" }, { name: proxy, image: \"proxy:1.0.0\" } ]"),
+			after:    fmt.Sprintf(deployment, "1", "[ { name: proxy, image: \"proxy:2.0.0\" }, { name: api, image: \"api:1.0.0\" } ]"),
+			expected: []string{"changed spec.template.spec.containers[name=proxy].image: proxy:1.0.0 -> proxy:2.0.0"},
+		},
+		{
+			name:     "added-and-removed",
+			before:   fmt.Sprintf(deployment, "1", "[ { name: api, image: \"api:1.0.0\", imagePullPolicy: IfNotPresent } ]"),
+			after:    fmt.Sprintf(deployment, "1", "[ { name: api, image: \"api:1.0.0\", ports: [ { containerPort: 8080 } ] } ]"),
+			expected: []string{"added spec.template.spec.containers[name=api].ports: null -> [{containerPort: 8080}]"},
+		},
+		{
+			name:     "defaults",
+			before:   fmt.Sprintf(deployment, "1", "[ { name: api, imagePullPolicy: IfNotPresent } ]"),
+			after:    fmt.Sprintf(deployment, "1", "[ { name: api } ]"),
+			options:  Options{Defaults: true},
+			expected: []string{"removed spec.template.spec.containers[name=api].imagePullPolicy: IfNotPresent -> null"},
+		},
+		{
+			name:    "ignored",
+			before:  fmt.Sprintf(deployment, "1", "[ { name: api, image: \"api:1.0.0\" } ]") + "status: { replicas: 1 }\n",
+			after:   fmt.Sprintf(deployment, "2", "[ { name: api, image: \"api:2.0.0\" } ]"),
+			options: Options{Ignore: []string{"spec.replicas", "spec.template.spec.containers[*].image"}},
+		},
+		{
+			name:     "secret",
+			before:   "apiVersion: v1\nkind: Secret\nmetadata: { name: credentials }\nstringData: { password: first }\n",
+			after:    "apiVersion: v1\nkind: Secret\nmetadata: { name: credentials }\nstringData: { password: second, token: value }\n",
+			expected: []string{"changed stringData.password: *** (before) -> *** (after)", "added stringData.token: null -> ***"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resources, e := Compare(decode(t, "before.yaml", test.before), decode(t, "after.yaml", test.after), test.options)
+			if e != nil {
+				t.Fatalf("Compare() returned an unexpected error: %v", e)
+			}
+
+			var changes []string
+			for _, resource := range resources {
+				for _, change := range resource.Changes {
+					changes = append(changes, fmt.Sprintf("%s %s: %s -> %s", change.Operation, change.Path, Format(change.Before), Format(change.After)))
+				}
+			}
+
+			if strings.Join(changes, "\n") != strings.Join(test.expected, "\n") {
+				t.Errorf("Compare() =\n%s\nexpected:\n%s", strings.Join(changes, "\n"), strings.Join(test.expected, "\n"))
+			}
+		})
+	}
+}
+
+func TestCompareResources(t *testing.T) {
+	before := decode(t, "before.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata: { name: removed }\n---\napiVersion: v1\nkind: ConfigMap\nmetadata: { name: changed }\ndata: { key: before }\n---\napiVersion: v1\nkind: ConfigMap\nmetadata: { name: unchanged, namespace: other }\n")
+	after := decode(t, "after.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata: { name: added }\n---\napiVersion: v1\nkind: ConfigMap\nmetadata: { name: unchanged, namespace: other }\n---\napiVersion: v1\nkind: ConfigMap\nmetadata: { name: changed }\ndata: { key: after }\n")
+
+	resources, e := Compare(before, after, Options{Namespace: "default"})
+	if e != nil {
+		t.Fatalf("Compare() returned an unexpected error: %v", e)
+	}
+
+	var summary []string
+	for _, resource := range resources {
+		summary = append(summary, fmt.Sprintf("%s %s/%s (%s -> %s)", resource.Operation, resource.Namespace, resource.Resource, resource.Before, resource.After))
+	}
+
+	expected := []string{
+		"added default/configmap/added ( -> after.yaml[0])",
+		"changed default/configmap/changed (before.yaml[1] -> after.yaml[2])",
+		"removed default/configmap/removed (before.yaml[0] -> )",
+	}
+
+	if strings.Join(summary, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Compare() =\n%s\nexpected:\n%s", strings.Join(summary, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if counts := Summary(resources); counts[Added] != 1 || counts[Changed] != 1 || counts[Removed] != 1 {
+		t.Errorf("Summary() = %v, expected one of each operation", counts)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{value: nil, expected: "null"},
+		{value: "example", expected: "example"},
+		{value: "", expected: `""`},
+		{value: "1", expected: `"1"`},
+		{value: "true", expected: `"true"`},
+		{value: "null", expected: `"null"`},
+		{value: "1.5", expected: `"1.5"`},
+		{value: "a: b", expected: `"a: b"`},
+		{value: 1, expected: "1"},
+		{value: 1.0, expected: "1.0"},
+		{value: 1.5, expected: "1.5"},
+		{value: true, expected: "true"},
+		{value: map[string]interface{}{"b": "2", "a": 1}, expected: `{a: 1, b: "2"}`},
+		{value: []interface{}{"x", 1}, expected: "[x, 1]"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T(%v)", test.value, test.value), func(t *testing.T) {
+			if formatted := Format(test.value); formatted != test.expected {
+				t.Errorf("Format(%#v) = %s, expected %s", test.value, formatted, test.expected)
+			}
+		})
+	}
+}
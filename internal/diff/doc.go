@@ -0,0 +1,2 @@
+// Package diff semantically compares manifest bundles, resource by resource and field by field.
+package diff
@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Pattern parses a field expression - dot-separated keys, "[<index>]" indexes, and "[\"<key>\"]" for keys that contain
+// a "." (e.g. `metadata.annotations["kubectl.kubernetes.io/last-applied-configuration"]`). A "*" key matches any key,
+// and "[*]" matches any item.
+func Pattern(expression string) (manifests.Path, error) {
+	var path manifests.Path
+	for index := 0; index < len(expression); {
+		switch expression[index] {
+		case '.':
+			index++
+		case '[':
+			end := bracket(expression, index)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid field expression - unterminated \"[\": %s", expression)
+			}
+
+			content := expression[index+1 : end]
+			switch {
+			case content == "":
+				return nil, fmt.Errorf("invalid field expression - empty \"[]\": %s", expression)
+			case content[0] == '"' || content[0] == '\'':
+				if len(content) < 2 || content[len(content)-1] != content[0] {
+					return nil, fmt.Errorf("invalid field expression - malformed key %s: %s", content, expression)
+				}
+
+				path = path.Key(content[1 : len(content)-1])
+			default:
+				path = append(path, "["+content+"]")
+			}
+
+			index = end + 1
+		default:
+			end := strings.IndexAny(expression[index:], ".[")
+			if end < 0 {
+				end = len(expression) - index
+			}
+
+			path = append(path, expression[index:index+end])
+			index += end
+		}
+	}
+
+	if len(path) == 0 {
+		return nil, fmt.Errorf("invalid field expression: %q", expression)
+	}
+
+	return path, nil
+}
+
+// bracket returns the index of the "]" that closes the "[" at start, skipping quoted content, or -1.
+func bracket(expression string, start int) int {
+	var quote byte
+	for index := start + 1; index < len(expression); index++ {
+		switch character := expression[index]; {
+		case quote != 0:
+			if character == quote {
+				quote = 0
+			}
+		case character == '"' || character == '\'':
+			quote = character
+		case character == ']':
+			return index
+		}
+	}
+
+	return -1
+}
+
+// matches reports whether path is pattern, or is below it.
+func matches(pattern, path manifests.Path) bool {
+	if len(path) < len(pattern) {
+		return false
+	}
+
+	for index, segment := range pattern {
+		item := strings.HasPrefix(path[index], "[")
+		switch {
+		case segment == path[index]:
+		case segment == "*" && !(item):
+		case segment == "[*]" && item:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,72 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+		invalid    bool
+	}{
+		{expression: "spec.replicas", expected: "spec.replicas"},
+		{expression: "spec.containers[0].image", expected: "spec.containers[0].image"},
+		{expression: `metadata.annotations["example.io/key"]`, expected: `metadata.annotations["example.io/key"]`},
+		{expression: "metadata.labels['app']", expected: "metadata.labels.app"},
+		{expression: "spec.*[*].image", expected: "spec.*[*].image"},
+		{expression: "", invalid: true},
+		{expression: "spec[0", invalid: true},
+		{expression: "spec[]", invalid: true},
+		{expression: `spec["key]`, invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			path, e := Pattern(test.expression)
+			if test.invalid {
+				if e == nil {
+					t.Errorf("Pattern(%s) = %s, expected an error", test.expression, path)
+				}
+
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("Pattern(%s) returned an unexpected error: %v", test.expression, e)
+			}
+
+			if path.String() != test.expected {
+				t.Errorf("Pattern(%s) = %s, expected %s", test.expression, path, test.expected)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     manifests.Path
+		expected bool
+	}{
+		{pattern: "status", path: manifests.Path{"status", "replicas"}, expected: true},
+		{pattern: "spec.replicas", path: manifests.Path{"spec"}, expected: false},
+		{pattern: "spec.*.image", path: manifests.Path{"spec", "template", "image"}, expected: true},
+		{pattern: "spec.*.image", path: manifests.Path{"spec", "[0]", "image"}, expected: false},
+		{pattern: "containers[*].image", path: manifests.Path{"containers", "[name=api]", "image"}, expected: true},
+		{pattern: "containers[*].image", path: manifests.Path{"containers", "api", "image"}, expected: false},
+	}
+
+	for _, test := range tests {
+		pattern, e := Pattern(test.pattern)
+		if e != nil {
+			t.Fatalf("Pattern(%s) returned an unexpected error: %v", test.pattern, e)
+		}
+
+		if matched := matches(pattern, test.path); matched != test.expected {
+			t.Errorf("matches(%s, %s) = %t, expected %t", test.pattern, test.path, matched, test.expected)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package dotenv parses and writes ".env" files.
+package dotenv
@@ -0,0 +1,154 @@
+package dotenv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// key matches a valid variable name.
+var key = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// Variable represents a single "<key>=<value>" assignment.
+type Variable struct {
+	Key   string
+	Value string
+}
+
+// Read parses the ".env" file at path.
+func Read(path string) ([]Variable, error) {
+	file, e := os.Open(path)
+	if e != nil {
+		e = fmt.Errorf("unable to open env file: %w", e)
+		return nil, e
+	}
+
+	defer file.Close()
+
+	variables, e := Parse(file)
+	if e != nil {
+		e = fmt.Errorf("unable to parse env file (%s): %w", path, e)
+		return nil, e
+	}
+
+	return variables, nil
+}
+
+// Parse reads "<key>=<value>" assignments, in order, from reader. Blank lines, "#" comments, and an "export" prefix
+// are ignored.
+//
+//   - Unquoted values are trimmed, and end at an inline comment (" #").
+//   - Single-quoted values are literal, and may span multiple lines.
+//   - Double-quoted values may span multiple lines, and support the "\n", "\r", "\t", "\"", and "\\" escapes.
+func Parse(reader io.Reader) ([]Variable, error) {
+	content, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+
+	var variables []Variable
+	for index := 0; index < len(lines); index++ {
+		number := index + 1
+
+		line := strings.TrimSpace(lines[index])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, valid := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !(valid) || !(key.MatchString(name)) {
+			return nil, fmt.Errorf("line %d: invalid assignment - expecting \"<key>=<value>\": %s", number, line)
+		}
+
+		value = strings.TrimLeft(value, " \t")
+
+		if value != "" && (value[0] == '"' || value[0] == '\'') {
+			quote := value[0]
+
+			// --> quoted values may continue onto subsequent line(s)
+			literal := value[1:]
+			for closing(literal, quote) < 0 && index+1 < len(lines) {
+				index++
+				literal += "\n" + lines[index]
+			}
+
+			end := closing(literal, quote)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value for %s", number, name)
+			}
+
+			if remainder := strings.TrimSpace(literal[end+1:]); remainder != "" && !(strings.HasPrefix(remainder, "#")) {
+				return nil, fmt.Errorf("line %d: unexpected content after quoted value for %s: %s", number, name, remainder)
+			}
+
+			value = literal[:end]
+			if quote == '"' {
+				value = unescape(value)
+			}
+		} else {
+			if comment := strings.Index(value, " #"); comment >= 0 {
+				value = value[:comment]
+			}
+
+			value = strings.TrimSpace(value)
+		}
+
+		variables = append(variables, Variable{Key: name, Value: value})
+	}
+
+	return variables, nil
+}
+
+// closing returns the index of value's first unescaped quote, or -1.
+func closing(value string, quote byte) int {
+	for index := 0; index < len(value); index++ {
+		switch {
+		case value[index] == '\\' && quote == '"':
+			index++
+		case value[index] == quote:
+			return index
+		}
+	}
+
+	return -1
+}
+
+// unescape replaces a double-quoted value's escape sequence(s).
+func unescape(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`)
+
+	return replacer.Replace(value)
+}
+
+// plain matches a value that can be written without quotes.
+var plain = regexp.MustCompile(`^[-._a-zA-Z0-9/:@,+=]*$`)
+
+// Write formats variables as "<key>=<value>" assignment(s) that [Parse] reads back verbatim. Values are single-quoted
+// (literal) when they contain special characters, and double-quoted with escapes only when they contain a single quote.
+func Write(writer io.Writer, variables ...Variable) error {
+	for _, variable := range variables {
+		value := variable.Value
+		switch {
+		case plain.MatchString(value):
+		case !(strings.Contains(value, "'")):
+			value = "'" + value + "'"
+		default:
+			replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+			value = `"` + replacer.Replace(value) + `"`
+		}
+
+		if _, e := fmt.Fprintf(writer, "%s=%s\n", variable.Key, value); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package dotenv
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []Variable
+		valid    bool
+	}{
+		{
+			name:     "unquoted",
+			content:  "# comment\n\nexport KEY = value # inline\nEMPTY=\r\n",
+			expected: []Variable{{Key: "KEY", Value: "value"}, {Key: "EMPTY"}},
+			valid:    true,
+		},
+		{
+			name:     "single-quoted",
+			content:  "KEY='literal \\n # value'\nMULTILINE='first\nsecond'\n",
+			expected: []Variable{{Key: "KEY", Value: `literal \n # value`}, {Key: "MULTILINE", Value: "first\nsecond"}},
+			valid:    true,
+		},
+		{
+			name:     "double-quoted",
+			content:  "KEY=\"tab\\tquote\\\"\" # comment\nMULTILINE=\"first\nsecond\"\n",
+			expected: []Variable{{Key: "KEY", Value: "tab\tquote\""}, {Key: "MULTILINE", Value: "first\nsecond"}},
+			valid:    true,
+		},
+		{name: "missing-assignment", content: "KEY\n"},
+		{name: "invalid-key", content: "KEY WITH SPACES=value\n"},
+		{name: "unterminated", content: "KEY='value\n"},
+		{name: "trailing-content", content: "KEY='value' other\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			variables, e := Parse(strings.NewReader(test.content))
+			if (e == nil) != test.valid {
+				t.Fatalf("Parse() = %v, expected valid: %t", e, test.valid)
+			}
+
+			if !(slices.Equal(variables, test.expected)) {
+				t.Errorf("Parse() = %q, expected %q", variables, test.expected)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	variables := []Variable{
+		{Key: "PLAIN", Value: "postgres://user@host:5432/db"},
+		{Key: "EMPTY"},
+		{Key: "SPACES", Value: "a value # not a comment"},
+		{Key: "QUOTE", Value: "it's \"quoted\"\nand\\escaped"},
+	}
+
+	var buffer bytes.Buffer
+	if e := Write(&buffer, variables...); e != nil {
+		t.Fatalf("Write() returned an unexpected error: %v", e)
+	}
+
+	expected := "PLAIN=postgres://user@host:5432/db\nEMPTY=\nSPACES='a value # not a comment'\nQUOTE=\"it's \\\"quoted\\\"\\nand\\\\escaped\"\n"
+	if buffer.String() != expected {
+		t.Errorf("Write() = %q, expected %q", buffer.String(), expected)
+	}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if e := os.WriteFile(path, buffer.Bytes(), 0o600); e != nil {
+		t.Fatalf("unable to write env file: %v", e)
+	}
+
+	parsed, e := Read(path)
+	if e != nil {
+		t.Fatalf("Read() returned an unexpected error: %v", e)
+	}
+
+	if !(slices.Equal(parsed, variables)) {
+		t.Errorf("Read() = %q, expected the written variables %q", parsed, variables)
+	}
+}
@@ -0,0 +1,2 @@
+// Package environment lists, sets, and unsets the environment variables of workload containers.
+package environment
@@ -0,0 +1,297 @@
+package environment
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Source identifies where an environment variable's value comes from.
+type Source string
+
+const (
+	Value     Source = "value"     // Value is a literal "value".
+	Secret    Source = "secret"    // Secret is a "valueFrom.secretKeyRef".
+	ConfigMap Source = "configmap" // ConfigMap is a "valueFrom.configMapKeyRef".
+	Field     Source = "field"     // Field is a downward-api "valueFrom.fieldRef".
+	Resource  Source = "resource"  // Resource is a downward-api "valueFrom.resourceFieldRef".
+)
+
+// Variable represents a single "env" entry, or - when [Variable.From] is true - an "envFrom" entry. For key references,
+// Value is "<name>/<key>"; for "envFrom" entries, Name is the optional prefix and Value is the referenced object's name.
+type Variable struct {
+	Name   string `json:"name" yaml:"name"`
+	Source Source `json:"source" yaml:"source"`
+	Value  string `json:"value" yaml:"value"`
+	From   bool   `json:"from,omitempty" yaml:"from,omitempty"`
+}
+
+// ParseVariable parses a "<name>=<value>" pair of the given source. Key references' values must be "<name>/<key>".
+func ParseVariable(source Source, pair string) (Variable, error) {
+	name, value, valid := strings.Cut(pair, "=")
+	if !(valid) || name == "" {
+		return Variable{}, fmt.Errorf("invalid environment variable - expecting \"<name>=<value>\": %s", pair)
+	}
+
+	if source == Secret || source == ConfigMap {
+		if object, key, valid := strings.Cut(value, "/"); !(valid) || object == "" || key == "" {
+			return Variable{}, fmt.Errorf("invalid %s key reference - expecting \"<name>=<%s>/<key>\": %s", source, source, pair)
+		}
+	}
+
+	if source == Field && value == "" {
+		return Variable{}, fmt.Errorf("invalid field reference - expecting \"<name>=<field-path>\": %s", pair)
+	}
+
+	return Variable{Name: name, Source: source, Value: value}, nil
+}
+
+// ParseReference parses a "secret/<name>" or "configmap/<name>" reference into an "envFrom" [Variable].
+func ParseReference(reference, prefix string) (Variable, error) {
+	kind, name, e := manifests.Target(reference)
+	if e != nil {
+		return Variable{}, e
+	}
+
+	switch strings.ToLower(kind) {
+	case "secret":
+		return Variable{Name: prefix, Source: Secret, Value: name, From: true}, nil
+	case "configmap", "cm":
+		return Variable{Name: prefix, Source: ConfigMap, Value: name, From: true}, nil
+	default:
+		return Variable{}, fmt.Errorf("invalid environment source - expecting \"secret/<name>\" or \"configmap/<name>\": %s", reference)
+	}
+}
+
+// String formats the variable's source for display (e.g. "secret:credentials/password").
+func (v Variable) String() string {
+	if v.Source == Value {
+		return v.Value
+	}
+
+	return fmt.Sprintf("%s:%s", v.Source, v.Value)
+}
+
+// node creates the variable's "env" or "envFrom" entry.
+func (v Variable) node() *yaml.Node {
+	if v.From {
+		key := "secretRef"
+		if v.Source == ConfigMap {
+			key = "configMapRef"
+		}
+
+		var prefix interface{}
+		if v.Name != "" {
+			prefix = v.Name
+		}
+
+		return manifests.Object("prefix", prefix, key, manifests.Object("name", v.Value))
+	}
+
+	node := manifests.Object("name", v.Name)
+	v.assign(node)
+
+	return node
+}
+
+// assign replaces an "env" entry's "value" or "valueFrom" with the variable's. The replaced field's position and
+// comment(s) are retained, as are the entry's other field(s).
+func (v Variable) assign(entry *yaml.Node) {
+	key, value := "value", manifests.String(v.Value)
+
+	switch v.Source {
+	case Secret, ConfigMap:
+		reference := "secretKeyRef"
+		if v.Source == ConfigMap {
+			reference = "configMapKeyRef"
+		}
+
+		name, field, _ := strings.Cut(v.Value, "/")
+
+		key, value = "valueFrom", manifests.Object(reference, manifests.Object("name", name, "key", field))
+	case Field:
+		key, value = "valueFrom", manifests.Object("fieldRef", manifests.Object("fieldPath", v.Value))
+	case Resource:
+		key, value = "valueFrom", manifests.Object("resourceFieldRef", manifests.Object("resource", v.Value))
+	}
+
+	for index := 0; index+1 < len(entry.Content); index += 2 {
+		if field := entry.Content[index]; field.Value == "value" || field.Value == "valueFrom" {
+			previous := entry.Content[index+1]
+
+			value.LineComment, value.FootComment = previous.LineComment, previous.FootComment
+			field.Value = key
+
+			entry.Content[index+1] = value
+
+			if key == "value" {
+				manifests.Delete(entry, "valueFrom")
+			} else {
+				manifests.Delete(entry, "value")
+			}
+
+			return
+		}
+	}
+
+	manifests.Set(entry, key, value)
+}
+
+// parse converts an "env" entry into a [Variable].
+func parse(entry *yaml.Node) Variable {
+	variable := Variable{Name: manifests.Scalar(entry, "name"), Source: Value, Value: manifests.Scalar(entry, "value")}
+
+	from := manifests.Value(entry, "valueFrom")
+	switch {
+	case manifests.Value(from, "secretKeyRef") != nil:
+		variable.Source = Secret
+		variable.Value = fmt.Sprintf("%s/%s", manifests.Scalar(from, "secretKeyRef", "name"), manifests.Scalar(from, "secretKeyRef", "key"))
+	case manifests.Value(from, "configMapKeyRef") != nil:
+		variable.Source = ConfigMap
+		variable.Value = fmt.Sprintf("%s/%s", manifests.Scalar(from, "configMapKeyRef", "name"), manifests.Scalar(from, "configMapKeyRef", "key"))
+	case manifests.Value(from, "fieldRef") != nil:
+		variable.Source = Field
+		variable.Value = manifests.Scalar(from, "fieldRef", "fieldPath")
+	case manifests.Value(from, "resourceFieldRef") != nil:
+		variable.Source = Resource
+		variable.Value = manifests.Scalar(from, "resourceFieldRef", "resource")
+		if container := manifests.Scalar(from, "resourceFieldRef", "containerName"); container != "" {
+			variable.Value = fmt.Sprintf("%s/%s", container, variable.Value)
+		}
+	}
+
+	return variable
+}
+
+// reference converts an "envFrom" entry into a [Variable].
+func reference(entry *yaml.Node) Variable {
+	variable := Variable{Name: manifests.Scalar(entry, "prefix"), Source: Secret, Value: manifests.Scalar(entry, "secretRef", "name"), From: true}
+	if manifests.Value(entry, "configMapRef") != nil {
+		variable.Source = ConfigMap
+		variable.Value = manifests.Scalar(entry, "configMapRef", "name")
+	}
+
+	return variable
+}
+
+// List returns a container's "env" followed by its "envFrom" entries.
+func List(container *yaml.Node) []Variable {
+	var variables []Variable
+	for _, entry := range manifests.Items(manifests.Value(container, "env")) {
+		variables = append(variables, parse(entry))
+	}
+
+	for _, entry := range manifests.Items(manifests.Value(container, "envFrom")) {
+		variables = append(variables, reference(entry))
+	}
+
+	return variables
+}
+
+// Set adds or updates variable(s) of a container, and returns the display name(s) of the variable(s) that changed.
+// Existing entries are updated in place; new entries are appended.
+func Set(container *yaml.Node, variables ...Variable) []string {
+	var changes []string
+	for _, variable := range variables {
+		key := "env"
+		if variable.From {
+			key = "envFrom"
+		}
+
+		sequence := manifests.Value(container, key)
+		if sequence == nil || sequence.Kind != yaml.SequenceNode {
+			sequence = manifests.Sequence()
+			manifests.Set(container, key, sequence)
+		}
+
+		entry := find(sequence, variable)
+		switch {
+		case entry == nil:
+			sequence.Content = append(sequence.Content, variable.node())
+		case variable.From:
+			if reference(entry) == variable {
+				continue
+			}
+
+			if variable.Name != "" {
+				manifests.Set(entry, "prefix", manifests.String(variable.Name))
+			} else {
+				manifests.Delete(entry, "prefix")
+			}
+		default:
+			if parse(entry) == variable {
+				continue
+			}
+
+			variable.assign(entry)
+		}
+
+		changes = append(changes, label(variable))
+	}
+
+	return changes
+}
+
+// Unset removes variable(s) of a container, and returns the display name(s) of the variable(s) that were removed. Empty
+// "env" and "envFrom" sequences are removed entirely.
+func Unset(container *yaml.Node, variables ...Variable) []string {
+	var changes []string
+	for _, variable := range variables {
+		key := "env"
+		if variable.From {
+			key = "envFrom"
+		}
+
+		sequence := manifests.Value(container, key)
+		if sequence == nil || sequence.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		entry := find(sequence, variable)
+		if entry == nil {
+			continue
+		}
+
+		for index := range sequence.Content {
+			if sequence.Content[index] == entry {
+				sequence.Content = append(sequence.Content[:index], sequence.Content[index+1:]...)
+				break
+			}
+		}
+
+		if len(sequence.Content) == 0 {
+			manifests.Delete(container, key)
+		}
+
+		changes = append(changes, label(variable))
+	}
+
+	return changes
+}
+
+// find returns the "env" entry with the variable's name, or the "envFrom" entry referencing the same object.
+func find(sequence *yaml.Node, variable Variable) *yaml.Node {
+	for _, entry := range manifests.Items(sequence) {
+		if variable.From {
+			if current := reference(entry); current.Source == variable.Source && current.Value == variable.Value {
+				return entry
+			}
+		} else if manifests.Scalar(entry, "name") == variable.Name {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// label returns a variable's display name - its name, or the referenced object of an "envFrom" entry.
+func label(variable Variable) string {
+	if variable.From {
+		return fmt.Sprintf("envFrom[%s/%s]", variable.Source, variable.Value)
+	}
+
+	return variable.Name
+}
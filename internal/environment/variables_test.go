@@ -0,0 +1,188 @@
+package environment
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// container decodes a container's mapping node.
+func container(t *testing.T, content string) *yaml.Node {
+	t.Helper()
+
+	var document yaml.Node
+	if e := yaml.Unmarshal([]byte(content), &document); e != nil {
+		t.Fatalf("unable to unmarshal container: %v", e)
+	}
+
+	return document.Content[0]
+}
+
+func TestParseVariable(t *testing.T) {
+	tests := []struct {
+		source   Source
+		pair     string
+		expected Variable
+		valid    bool
+	}{
+		{source: Value, pair: "LOG_LEVEL=debug", expected: Variable{Name: "LOG_LEVEL", Source: Value, Value: "debug"}, valid: true},
+		{source: Value, pair: "EMPTY=", expected: Variable{Name: "EMPTY", Source: Value}, valid: true},
+		{source: Secret, pair: "PASSWORD=database/password", expected: Variable{Name: "PASSWORD", Source: Secret, Value: "database/password"}, valid: true},
+		{source: Field, pair: "NAMESPACE=metadata.namespace", expected: Variable{Name: "NAMESPACE", Source: Field, Value: "metadata.namespace"}, valid: true},
+		{source: Value, pair: "LOG_LEVEL"},
+		{source: Value, pair: "=debug"},
+		{source: ConfigMap, pair: "REGION=settings"},
+		{source: Field, pair: "NAMESPACE="},
+	}
+
+	for _, test := range tests {
+		variable, e := ParseVariable(test.source, test.pair)
+		if (e == nil) != test.valid {
+			t.Errorf("ParseVariable(%s, %q) = %v, expected valid: %t", test.source, test.pair, e, test.valid)
+		} else if variable != test.expected {
+			t.Errorf("ParseVariable(%s, %q) = %+v, expected %+v", test.source, test.pair, variable, test.expected)
+		}
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		reference string
+		expected  Variable
+		valid     bool
+	}{
+		{reference: "secret/credentials", expected: Variable{Source: Secret, Value: "credentials", From: true}, valid: true},
+		{reference: "cm/settings", expected: Variable{Source: ConfigMap, Value: "settings", From: true}, valid: true},
+		{reference: "service/example"},
+		{reference: "settings"},
+	}
+
+	for _, test := range tests {
+		variable, e := ParseReference(test.reference, "")
+		if (e == nil) != test.valid {
+			t.Errorf("ParseReference(%q) = %v, expected valid: %t", test.reference, e, test.valid)
+		} else if variable != test.expected {
+			t.Errorf("ParseReference(%q) = %+v, expected %+v", test.reference, variable, test.expected)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	node := container(t, strings.Join([]string{
+		"name: example",
+		"env:",
+		"    -   name: LOG_LEVEL",
+		"        value: info # the default",
+		"    -   name: PASSWORD",
+		"        value: plain-text",
+	}, "\n"))
+
+	changes := Set(node,
+		Variable{Name: "LOG_LEVEL", Source: Value, Value: "info"},
+		Variable{Name: "PASSWORD", Source: Secret, Value: "database/password"},
+		Variable{Name: "NAMESPACE", Source: Field, Value: "metadata.namespace"},
+		Variable{Name: "SETTINGS_", Source: ConfigMap, Value: "settings", From: true},
+	)
+
+	if expected := []string{"PASSWORD", "NAMESPACE", "envFrom[configmap/settings]"}; !(slices.Equal(changes, expected)) {
+		t.Errorf("Set() = %v, expected %v", changes, expected)
+	}
+
+	expected := []Variable{
+		{Name: "LOG_LEVEL", Source: Value, Value: "info"},
+		{Name: "PASSWORD", Source: Secret, Value: "database/password"},
+		{Name: "NAMESPACE", Source: Field, Value: "metadata.namespace"},
+		{Name: "SETTINGS_", Source: ConfigMap, Value: "settings", From: true},
+	}
+
+	if variables := List(node); !(slices.Equal(variables, expected)) {
+		t.Errorf("List() = %+v, expected %+v", variables, expected)
+	}
+
+	if content, _ := yaml.Marshal(node); !(strings.Contains(string(content), "# the default")) {
+		t.Errorf("Set() expected an unchanged entry's comment to be retained:\n%s", content)
+	}
+}
+
+func TestUnset(t *testing.T) {
+	node := container(t, "name: example\nenv: [ { name: A, value: a }, { name: B, value: b } ]\nenvFrom: [ { secretRef: { name: credentials } } ]\n")
+
+	changes := Unset(node,
+		Variable{Name: "A"},
+		Variable{Name: "MISSING"},
+		Variable{Source: Secret, Value: "credentials", From: true},
+	)
+
+	if expected := []string{"A", "envFrom[secret/credentials]"}; !(slices.Equal(changes, expected)) {
+		t.Errorf("Unset() = %v, expected %v", changes, expected)
+	}
+
+	if variables := List(node); len(variables) != 1 || variables[0].Name != "B" {
+		t.Errorf("List() = %+v, expected only B", variables)
+	}
+
+	for index := 0; index < len(node.Content); index += 2 {
+		if node.Content[index].Value == "envFrom" {
+			t.Errorf("Unset() expected the empty envFrom sequence to be removed")
+		}
+	}
+}
+
+func TestSetSave(t *testing.T) {
+	content := strings.Join([]string{
+		"apiVersion: apps/v1",
+		"kind: Deployment",
+		"metadata:",
+		"    name: example",
+		"spec:",
+		"    template:",
+		"        spec:",
+		"            containers:",
+		"                - name: example",
+		"                  env:",
+		"                      - name: LOG_LEVEL",
+		"                        value: info",
+		"",
+	}, "\n")
+
+	path := filepath.Join(t.TempDir(), "deployment.yaml")
+	if e := os.WriteFile(path, []byte(content), 0o600); e != nil {
+		t.Fatalf("unable to write manifest: %v", e)
+	}
+
+	_, e := manifests.Edit(io.Discard, false, &manifests.Selector{}, func(container *yaml.Node) ([]string, error) {
+		return Set(container,
+			Variable{Name: "NEW", Source: Value, Value: "1"},
+			Variable{Name: "WEIRD", Source: Value, Value: "yes"},
+			Variable{Source: ConfigMap, Value: "settings", From: true},
+		), nil
+	}, path)
+	if e != nil {
+		t.Fatalf("Edit() returned an unexpected error: %v", e)
+	}
+
+	// --> the appended "env" entries must precede the new "envFrom" key, rather than being nested beneath it, and
+	// values YAML 1.1 parsers would read as another type must be quoted
+	expected := strings.Replace(content, "value: info\n", strings.Join([]string{
+		"value: info",
+		"                      - name: NEW",
+		"                        value: \"1\"",
+		"                      - name: WEIRD",
+		"                        value: \"yes\"",
+		"                  envFrom:",
+		"                      - configMapRef:",
+		"                          name: settings",
+		"",
+	}, "\n"), 1)
+
+	if saved, _ := os.ReadFile(path); string(saved) != expected {
+		t.Errorf("Set() saved:\n%s\nexpected:\n%s", saved, expected)
+	}
+}
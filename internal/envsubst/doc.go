@@ -0,0 +1,3 @@
+// Package envsubst substitutes "$VAR" and "${VAR}" placeholders - with shell-style defaults, alternates, and required
+// variables - in text and, YAML-aware, in the scalars of manifests.
+package envsubst
@@ -0,0 +1,200 @@
+package envsubst
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Lookup resolves a variable, reporting whether it's set.
+type Lookup func(name string) (value string, set bool)
+
+// Substituter expands placeholders. The syntax is envsubst's ("$VAR", "${VAR}"), plus the shell's parameter expansions:
+//
+//   - "${VAR:-default}" and "${VAR-default}" - default when unset or empty, or only when unset.
+//   - "${VAR:+alternate}" and "${VAR+alternate}" - alternate when set and non-empty, or when set.
+//   - "${VAR:?message}" and "${VAR?message}" - fail with message when unset or empty, or only when unset.
+//   - "$$" - a literal "$".
+//
+// Defaults, alternates, and messages may themselves contain placeholders. A "$" that doesn't start a placeholder is
+// retained. Unset variables without a default expand to "" - unless Strict is set, in which case they fail.
+type Substituter struct {
+	Lookup Lookup
+	Strict bool
+
+	missing map[string]bool
+}
+
+// Missing returns the unset variables (without a default) that expanded to "", sorted by name.
+func (s *Substituter) Missing() []string {
+	names := make([]string, 0, len(s.missing))
+	for name := range s.missing {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Expand substitutes every placeholder of input.
+func (s *Substituter) Expand(input string) (string, error) {
+	if !(strings.Contains(input, "$")) {
+		return input, nil
+	}
+
+	var output strings.Builder
+	for index := 0; index < len(input); {
+		if input[index] != '$' || index+1 == len(input) {
+			output.WriteByte(input[index])
+			index++
+
+			continue
+		}
+
+		end := placeholder(input, index)
+		switch {
+		case input[index+1] == '$':
+			output.WriteByte('$')
+		case end < 0:
+			return "", fmt.Errorf("unterminated placeholder: %s", input[index:])
+		case end == index+1:
+			output.WriteByte('$')
+		case input[index+1] == '{':
+			value, e := s.evaluate(input[index+2 : end-1])
+			if e != nil {
+				return "", e
+			}
+
+			output.WriteString(value)
+		default:
+			value, e := s.evaluate(input[index+1 : end])
+			if e != nil {
+				return "", e
+			}
+
+			output.WriteString(value)
+		}
+
+		index = end
+	}
+
+	return output.String(), nil
+}
+
+// Whole reports whether input consists of exactly one placeholder (e.g. "${VAR:-default}").
+func Whole(input string) bool {
+	return len(input) > 1 && input[0] == '$' && input[1] != '$' && placeholder(input, 0) == len(input)
+}
+
+// placeholder returns the end (exclusive) of the placeholder that starts with the "$" at start: start+1 if none does,
+// "$$" included, and -1 for an unterminated "${".
+func placeholder(input string, start int) int {
+	index := start + 1
+	switch {
+	case index >= len(input):
+		return index
+	case input[index] == '$':
+		return index + 1
+	case input[index] == '{':
+		for depth := 0; index < len(input); index++ {
+			switch {
+			case input[index] == '{':
+				depth++
+			case input[index] == '}':
+				depth--
+				if depth == 0 {
+					return index + 1
+				}
+			}
+		}
+
+		return -1
+	}
+
+	for index < len(input) && character(input[index], index == start+1) {
+		index++
+	}
+
+	return index
+}
+
+// character reports whether c may appear in a variable name - letters, "_", and (except first) digits.
+func character(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!(first) && c >= '0' && c <= '9')
+}
+
+// evaluate resolves the content of a placeholder - the variable's name and optional operator and operand.
+func (s *Substituter) evaluate(content string) (string, error) {
+	var length int
+	for length < len(content) && character(content[length], length == 0) {
+		length++
+	}
+
+	name, expression := content[:length], content[length:]
+	if name == "" {
+		return "", fmt.Errorf("bad substitution: ${%s}", content)
+	}
+
+	value, set := s.Lookup(name)
+
+	var operator string
+	for _, candidate := range []string{":-", ":+", ":?", "-", "+", "?"} {
+		if strings.HasPrefix(expression, candidate) {
+			operator = candidate
+			break
+		}
+	}
+
+	if operator == "" && expression != "" {
+		return "", fmt.Errorf("bad substitution: ${%s}", content)
+	}
+
+	operand := expression[len(operator):]
+
+	// --> ":" operators treat an empty value as unset
+	present := set && (value != "" || !(strings.HasPrefix(operator, ":")))
+
+	switch strings.TrimPrefix(operator, ":") {
+	case "-":
+		if !(present) {
+			return s.Expand(operand)
+		}
+	case "+":
+		if present {
+			return s.Expand(operand)
+		}
+
+		return "", nil
+	case "?":
+		if !(present) {
+			message, e := s.Expand(operand)
+			if e != nil {
+				return "", e
+			}
+
+			if message == "" {
+				message = "not set"
+				if set {
+					message = "empty"
+				}
+			}
+
+			return "", fmt.Errorf("%s: %s", name, message)
+		}
+	default:
+		if !(set) {
+			if s.Strict {
+				return "", fmt.Errorf("variable not set: %s", name)
+			}
+
+			if s.missing == nil {
+				s.missing = make(map[string]bool)
+			}
+
+			s.missing[name] = true
+		}
+	}
+
+	return value, nil
+}
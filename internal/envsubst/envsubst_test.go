@@ -0,0 +1,94 @@
+package envsubst
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSubstituterExpand(t *testing.T) {
+	lookup := Chain(Variables{"NAME": "example", "EMPTY": "", "NESTED": "${NAME}"})
+
+	tests := []struct {
+		input    string
+		strict   bool
+		expected string
+		error    string // error is a substring of the expected error, if any
+		missing  []string
+	}{
+		{input: "plain", expected: "plain"},
+		{input: "$NAME", expected: "example"},
+		{input: "${NAME}", expected: "example"},
+		{input: "$NAME-suffix", expected: "example-suffix"},
+		{input: "${NAME}suffix", expected: "examplesuffix"},
+		{input: "$$NAME", expected: "$NAME"},
+		{input: "cost: $5 or $", expected: "cost: $5 or $"},
+		{input: "${NESTED}", expected: "${NAME}"},
+		{input: "${UNSET:-default}", expected: "default"},
+		{input: "${EMPTY:-default}", expected: "default"},
+		{input: "${EMPTY-default}", expected: ""},
+		{input: "${UNSET:-${NAME}}", expected: "example"},
+		{input: "${NAME:+alternate}", expected: "alternate"},
+		{input: "${EMPTY:+alternate}", expected: ""},
+		{input: "${EMPTY+alternate}", expected: "alternate"},
+		{input: "${UNSET+alternate}", expected: ""},
+		{input: "${NAME:?required}", expected: "example"},
+		{input: "${EMPTY?required}", expected: ""},
+		{input: "${UNSET:?is required}", error: "UNSET: is required"},
+		{input: "${EMPTY:?}", error: "EMPTY: empty"},
+		{input: "${UNSET?}", error: "UNSET: not set"},
+		{input: "$UNSET and ${OTHER}", expected: " and ", missing: []string{"OTHER", "UNSET"}},
+		{input: "$UNSET", strict: true, error: "variable not set: UNSET"},
+		{input: "${UNSET:-default}", strict: true, expected: "default"},
+		{input: "${NAME", error: "unterminated placeholder"},
+		{input: "${}", error: "bad substitution"},
+		{input: "${NAME/x/y}", error: "bad substitution"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			substituter := &Substituter{Lookup: lookup, Strict: test.strict}
+
+			output, e := substituter.Expand(test.input)
+			if test.error != "" {
+				if e == nil || !(strings.Contains(e.Error(), test.error)) {
+					t.Errorf("Expand(%q) error = %v, expected %q", test.input, e, test.error)
+				}
+
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("Expand(%q) returned an unexpected error: %v", test.input, e)
+			}
+
+			if output != test.expected {
+				t.Errorf("Expand(%q) = %q, expected %q", test.input, output, test.expected)
+			}
+
+			if missing := substituter.Missing(); !(slices.Equal(missing, test.missing)) {
+				t.Errorf("Missing() = %v, expected %v", missing, test.missing)
+			}
+		})
+	}
+}
+
+func TestWhole(t *testing.T) {
+	tests := map[string]bool{
+		"$NAME":               true,
+		"${NAME}":             true,
+		"${NAME:-${DEFAULT}}": true,
+		"$NAME-suffix":        false,
+		"${NAME}${OTHER}":     false,
+		"$$":                  false,
+		"$":                   false,
+		"${NAME":              false,
+		"NAME":                false,
+	}
+
+	for input, expected := range tests {
+		if whole := Whole(input); whole != expected {
+			t.Errorf("Whole(%q) = %t, expected %t", input, whole, expected)
+		}
+	}
+}
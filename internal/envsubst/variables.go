@@ -0,0 +1,118 @@
+package envsubst
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/dotenv"
+)
+
+// inherited is the process' environment as inherited - captured during initialization, before the CLI sets variables
+// of its own (e.g. "VERSION").
+var inherited = os.Environ()
+
+// Variables represents a source of variable values.
+type Variables map[string]string
+
+// Environment returns the process' inherited environment variables.
+func Environment() Variables {
+	variables := make(Variables)
+	for _, assignment := range inherited {
+		if name, value, valid := strings.Cut(assignment, "="); valid {
+			variables[name] = value
+		}
+	}
+
+	return variables
+}
+
+// Values reads a YAML file of top-level "<name>: <value>" scalars. A null value sets the variable to "".
+func Values(path string) (Variables, error) {
+	content, e := os.ReadFile(path)
+	if e != nil {
+		e = fmt.Errorf("unable to read values file: %w", e)
+		return nil, e
+	}
+
+	var document yaml.Node
+	if e := yaml.Unmarshal(content, &document); e != nil {
+		e = fmt.Errorf("unable to parse values file (%s): %w", path, e)
+		return nil, e
+	}
+
+	variables := make(Variables)
+	if len(document.Content) == 0 {
+		return variables, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("values file (%s) must be a mapping of \"<name>: <value>\"", path)
+	}
+
+	for index := 0; index+1 < len(root.Content); index += 2 {
+		key, value := root.Content[index], root.Content[index+1]
+		if !(valid(key.Value)) {
+			return nil, fmt.Errorf("values file (%s), line %d: invalid variable name: %s", path, key.Line, key.Value)
+		}
+
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("values file (%s), line %d: %s must be a scalar", path, value.Line, key.Value)
+		}
+
+		if value.Tag == "!!null" {
+			variables[key.Value] = ""
+			continue
+		}
+
+		variables[key.Value] = value.Value
+	}
+
+	return variables, nil
+}
+
+// Dotenv reads the assignments of a ".env" file - see [dotenv.Parse].
+func Dotenv(path string) (Variables, error) {
+	assignments, e := dotenv.Read(path)
+	if e != nil {
+		return nil, e
+	}
+
+	variables := make(Variables)
+	for _, assignment := range assignments {
+		variables[assignment.Key] = assignment.Value
+	}
+
+	return variables, nil
+}
+
+// Chain returns a [Lookup] that consults sources in order - the first source to set a variable wins.
+func Chain(sources ...Variables) Lookup {
+	return func(name string) (string, bool) {
+		for _, source := range sources {
+			if value, set := source[name]; set {
+				return value, true
+			}
+		}
+
+		return "", false
+	}
+}
+
+// valid reports whether name is a valid variable name.
+func valid(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for index := 0; index < len(name); index++ {
+		if !(character(name[index], index == 0)) {
+			return false
+		}
+	}
+
+	return true
+}
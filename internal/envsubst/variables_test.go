@@ -0,0 +1,71 @@
+package envsubst
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected Variables
+		invalid  bool
+	}{
+		{name: "scalars", content: "NAME: example\nREPLICAS: 3\nEMPTY: ~\nQUOTED: \"true\"\n", expected: Variables{"NAME": "example", "REPLICAS": "3", "EMPTY": "", "QUOTED": "true"}},
+		{name: "empty", content: "", expected: Variables{}},
+		{name: "sequence", content: "- NAME\n", invalid: true},
+		{name: "nested", content: "NAME: { nested: value }\n", invalid: true},
+		{name: "invalid-name", content: "1NAME: value\n", invalid: true},
+		{name: "malformed", content: "NAME: [\n", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "values.yaml")
+			if e := os.WriteFile(path, []byte(test.content), 0o600); e != nil {
+				t.Fatalf("unable to write values: %v", e)
+			}
+
+			variables, e := Values(path)
+			if test.invalid {
+				if e == nil {
+					t.Errorf("Values() = %v, expected an error", variables)
+				}
+
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("Values() returned an unexpected error: %v", e)
+			}
+
+			if !(reflect.DeepEqual(variables, test.expected)) {
+				t.Errorf("Values() = %v, expected %v", variables, test.expected)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	lookup := Chain(Variables{"FIRST": "first", "EMPTY": ""}, Variables{"FIRST": "second", "EMPTY": "second", "SECOND": "second"})
+
+	tests := []struct {
+		name     string
+		expected string
+		set      bool
+	}{
+		{name: "FIRST", expected: "first", set: true},
+		{name: "EMPTY", expected: "", set: true},
+		{name: "SECOND", expected: "second", set: true},
+		{name: "UNSET", expected: "", set: false},
+	}
+
+	for _, test := range tests {
+		if value, set := lookup(test.name); value != test.expected || set != test.set {
+			t.Errorf("Chain()(%s) = (%q, %t), expected (%q, %t)", test.name, value, set, test.expected, test.set)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package envsubst
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// quoted are the scalar styles whose content is always a string.
+const quoted = yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle | yaml.LiteralStyle | yaml.FoldedStyle
+
+// Node substitutes the placeholders of every scalar (keys included) below node. Substituted values can't change the
+// document's structure: the encoder quotes (or block-formats) them as needed. Quoted, block, and explicitly tagged
+// scalars remain strings. A plain scalar that's exactly one placeholder (e.g. "replicas: ${REPLICAS}") takes the type
+// its value would have in YAML - as with envsubst - while other plain scalars remain strings. Either way, values YAML
+// 1.1 parsers would resolve differently (e.g. "yes", "on", "0755") are quoted.
+func (s *Substituter) Node(node *yaml.Node) error {
+	if node == nil {
+		return nil
+	}
+
+	if node.Kind == yaml.ScalarNode {
+		value, e := s.Expand(node.Value)
+		if e != nil {
+			e = fmt.Errorf("line %d: %w", node.Line, e)
+			return e
+		}
+
+		if value == node.Value {
+			return nil
+		}
+
+		if node.Style&(quoted|yaml.TaggedStyle) == 0 && Whole(node.Value) {
+			// --> the encoder resolves the type of untagged, plain scalars
+			node.Tag = ""
+		}
+
+		// --> values YAML 1.1 parsers (e.g. kubectl's) would resolve differently remain strings
+		if node.Style&(quoted|yaml.TaggedStyle) == 0 && manifests.Ambiguous(value) {
+			node.Tag, node.Style = "!!str", yaml.DoubleQuotedStyle
+		}
+
+		node.Value = value
+
+		return nil
+	}
+
+	for _, child := range node.Content {
+		if e := s.Node(child); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package envsubst
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSubstituterNode(t *testing.T) {
+	lookup := Chain(Variables{"REPLICAS": "3", "ENABLED": "true", "NAME": "example", "COMMAND": "a: b\n- c", "KEY": "renamed", "FLAG": "yes", "SWITCH": "on", "MODE": "0755"})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "typed-integer", input: "replicas: ${REPLICAS}\n", expected: "replicas: 3\n"},
+		{name: "typed-boolean", input: "enabled: $ENABLED\n", expected: "enabled: true\n"},
+		{name: "yaml-1.1-boolean", input: "flag: ${FLAG}\n", expected: "flag: \"yes\"\n"},
+		{name: "yaml-1.1-switch", input: "switch: $SWITCH\n", expected: "switch: \"on\"\n"},
+		{name: "yaml-1.1-octal", input: "mode: ${MODE}\n", expected: "mode: \"0755\"\n"},
+		{name: "yaml-1.1-interpolated", input: "flag: ${SWITCH}${MISSING}\n", expected: "flag: \"on\"\n"},
+		{name: "double-quoted", input: "replicas: \"${REPLICAS}\"\n", expected: "replicas: \"3\"\n"},
+		{name: "single-quoted", input: "enabled: '${ENABLED}'\n", expected: "enabled: 'true'\n"},
+		{name: "tagged", input: "replicas: !!str ${REPLICAS}\n", expected: "replicas: !!str 3\n"},
+		{name: "interpolated", input: "value: ${REPLICAS}0\n", expected: "value: \"30\"\n"},
+		{name: "structure", input: "command: ${COMMAND}\n", expected: "command: |-\n    a: b\n    - c\n"},
+		{name: "key", input: "${KEY}: ${NAME}\n", expected: "renamed: example\n"},
+		{name: "sequence", input: "items:\n    - $NAME\n    - ${REPLICAS}\n", expected: "items:\n    - example\n    - 3\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var document yaml.Node
+			if e := yaml.Unmarshal([]byte(test.input), &document); e != nil {
+				t.Fatalf("unable to parse input: %v", e)
+			}
+
+			if e := (&Substituter{Lookup: lookup}).Node(&document); e != nil {
+				t.Fatalf("Node() returned an unexpected error: %v", e)
+			}
+
+			var output strings.Builder
+
+			encoder := yaml.NewEncoder(&output)
+			encoder.SetIndent(4)
+			if e := encoder.Encode(&document); e != nil {
+				t.Fatalf("unable to encode document: %v", e)
+			}
+
+			if output.String() != test.expected {
+				t.Errorf("Node() =\n%s\nexpected:\n%s", output.String(), test.expected)
+			}
+		})
+	}
+}
+
+func TestSubstituterNodeError(t *testing.T) {
+	var document yaml.Node
+	if e := yaml.Unmarshal([]byte("name: example\nvalue: ${UNSET:?required}\n"), &document); e != nil {
+		t.Fatalf("unable to parse input: %v", e)
+	}
+
+	e := (&Substituter{Lookup: Chain()}).Node(&document)
+	if e == nil || e.Error() != "line 2: UNSET: required" {
+		t.Errorf("Node() error = %v, expected \"line 2: UNSET: required\"", e)
+	}
+}
@@ -0,0 +1,145 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Autoscaler represents the options of a generated HorizontalPodAutoscaler.
+type Autoscaler struct {
+	Min     int      // Min is the minimum replica count.
+	Max     int      // Max is the maximum replica count.
+	CPU     string   // CPU is either an average utilization percentage (e.g. "80") or average value (e.g. "500m"); defaults to "80" when no other metric is set.
+	Memory  string   // Memory is either an average utilization percentage (e.g. "75") or average value (e.g. "512Mi").
+	Metrics []string // Metrics are custom metric targets - "[pods:|external:]<name>=<average-value>".
+}
+
+// scalable represents the kinds a HorizontalPodAutoscaler can target.
+var scalable = map[string]bool{"Deployment": true, "StatefulSet": true, "ReplicaSet": true}
+
+// Generate produces a HorizontalPodAutoscaler that scales the target workload document.
+func (a Autoscaler) Generate(target *manifests.Document) (*yaml.Node, error) {
+	if !(scalable[target.Kind()]) {
+		return nil, fmt.Errorf("unsupported autoscaling target kind (%s) - expecting Deployment, StatefulSet or ReplicaSet", target.Kind())
+	}
+
+	if a.Min < 1 {
+		return nil, fmt.Errorf("invalid minimum replica count: %d", a.Min)
+	}
+
+	if a.Max < a.Min {
+		return nil, fmt.Errorf("maximum replica count (%d) must be greater than or equal to the minimum (%d)", a.Max, a.Min)
+	}
+
+	var metrics []*yaml.Node
+	for _, resource := range []struct{ name, value string }{{"cpu", a.CPU}, {"memory", a.Memory}} {
+		if resource.value == "" {
+			continue
+		}
+
+		metrics = append(metrics, manifests.Object("type", "Resource", "resource", manifests.Object(
+			"name", resource.name,
+			"target", threshold(resource.value),
+		)))
+	}
+
+	for _, metric := range a.Metrics {
+		kind := "Pods"
+		if prefix, remainder, found := strings.Cut(metric, ":"); found && (prefix == "pods" || prefix == "external") {
+			kind, metric = map[string]string{"pods": "Pods", "external": "External"}[prefix], remainder
+		}
+
+		name, value, valid := strings.Cut(metric, "=")
+		if !(valid) || name == "" || value == "" {
+			return nil, fmt.Errorf("invalid metric - expecting \"[pods:|external:]<name>=<average-value>\": %s", metric)
+		}
+
+		field := strings.ToLower(kind[:1]) + kind[1:]
+
+		metrics = append(metrics, manifests.Object("type", kind, field, manifests.Object(
+			"metric", manifests.Object("name", name),
+			"target", manifests.Object("type", "AverageValue", "averageValue", value),
+		)))
+	}
+
+	// --> without any metric(s), mirror the api-server's default of 80% average cpu utilization
+	if len(metrics) == 0 {
+		metrics = append(metrics, manifests.Object("type", "Resource", "resource", manifests.Object("name", "cpu", "target", threshold("80"))))
+	}
+
+	return manifests.Resource("autoscaling/v2", "HorizontalPodAutoscaler",
+		manifests.Metadata(target.Name(), target.Namespace(), target.Labels(), nil),
+		"spec", manifests.Object(
+			"scaleTargetRef", manifests.Object("apiVersion", target.APIVersion(), "kind", target.Kind(), "name", target.Name()),
+			"minReplicas", a.Min,
+			"maxReplicas", a.Max,
+			"metrics", metrics,
+		),
+	), nil
+}
+
+// threshold converts a resource metric's value into its target - a bare integer is a utilization percentage, and
+// anything else is an average value quantity.
+func threshold(value string) *yaml.Node {
+	if percentage, e := strconv.Atoi(strings.TrimSuffix(value, "%")); e == nil {
+		return manifests.Object("type", "Utilization", "averageUtilization", percentage)
+	}
+
+	return manifests.Object("type", "AverageValue", "averageValue", value)
+}
+
+// DisruptionBudget represents the options of a generated PodDisruptionBudget. Exactly one field must be set; values
+// are either a pod count (e.g. "1") or percentage (e.g. "50%").
+type DisruptionBudget struct {
+	MinAvailable   string
+	MaxUnavailable string
+}
+
+// Generate produces a PodDisruptionBudget whose selector matches the target workload document's pods.
+func (b DisruptionBudget) Generate(target *manifests.Document) (*yaml.Node, error) {
+	if (b.MinAvailable == "") == (b.MaxUnavailable == "") {
+		return nil, errors.New("exactly one of minAvailable or maxUnavailable is required")
+	}
+
+	if !(target.Workload()) || target.Kind() == "Pod" {
+		return nil, fmt.Errorf("unsupported disruption budget target kind: %s", target.Kind())
+	}
+
+	selector := manifests.Copy(manifests.Lookup(target.Root(), "spec", "selector"))
+	if selector == nil || (manifests.Value(selector, "matchLabels") == nil && manifests.Value(selector, "matchExpressions") == nil) {
+		labels, _ := target.PodTemplate()
+		if labels = manifests.Value(labels, "labels"); labels == nil {
+			return nil, fmt.Errorf("%s has neither a selector nor pod template labels", target)
+		}
+
+		selector = manifests.Object("matchLabels", manifests.Copy(labels))
+	}
+
+	return manifests.Resource("policy/v1", "PodDisruptionBudget",
+		manifests.Metadata(target.Name(), target.Namespace(), target.Labels(), nil),
+		"spec", manifests.Object(
+			"minAvailable", budget(b.MinAvailable),
+			"maxUnavailable", budget(b.MaxUnavailable),
+			"selector", selector,
+		),
+	), nil
+}
+
+// budget converts a pod count or percentage into its int-or-string node.
+func budget(value string) *yaml.Node {
+	if value == "" {
+		return nil
+	}
+
+	if count, e := strconv.Atoi(value); e == nil {
+		return manifests.Integer(count)
+	}
+
+	return manifests.String(value)
+}
@@ -0,0 +1,131 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// workload is a Deployment targeted by the generated autoscaling resource(s).
+const workload = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+    name: example
+    namespace: development
+    labels: { app: example }
+spec:
+    selector:
+        matchLabels: { app: example }
+    template:
+        metadata:
+            labels: { app: example, version: v1 }
+`
+
+func document(t *testing.T, content string) *manifests.Document {
+	t.Helper()
+
+	documents, e := manifests.Decode("test.yaml", strings.NewReader(content))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	return documents[0]
+}
+
+func TestAutoscalerGenerate(t *testing.T) {
+	target := document(t, workload)
+
+	tests := []struct {
+		name       string
+		autoscaler Autoscaler
+		expected   []string // expected are the metrics' "<type>/<name>/<target-type>"
+		valid      bool
+	}{
+		{name: "default", autoscaler: Autoscaler{Min: 1, Max: 3}, expected: []string{"Resource/cpu/Utilization"}, valid: true},
+		{name: "resources", autoscaler: Autoscaler{Min: 1, Max: 3, CPU: "500m", Memory: "75%"}, expected: []string{"Resource/cpu/AverageValue", "Resource/memory/Utilization"}, valid: true},
+		{name: "custom", autoscaler: Autoscaler{Min: 2, Max: 3, Metrics: []string{"requests=100", "external:queue=30"}}, expected: []string{"Pods/requests/AverageValue", "External/queue/AverageValue"}, valid: true},
+		{name: "invalid-minimum", autoscaler: Autoscaler{Min: 0, Max: 3}},
+		{name: "invalid-maximum", autoscaler: Autoscaler{Min: 3, Max: 2}},
+		{name: "invalid-metric", autoscaler: Autoscaler{Min: 1, Max: 3, Metrics: []string{"requests"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node, e := test.autoscaler.Generate(target)
+			if (e == nil) != test.valid {
+				t.Fatalf("Generate() = %v, expected valid: %t", e, test.valid)
+			}
+
+			if !(test.valid) {
+				return
+			}
+
+			if reference := manifests.Scalar(node, "spec", "scaleTargetRef", "name"); reference != "example" || manifests.Scalar(node, "metadata", "namespace") != "development" {
+				t.Errorf("Generate() expected to target development/example")
+			}
+
+			var metrics []string
+			for _, metric := range manifests.Items(manifests.Lookup(node, "spec", "metrics")) {
+				kind := manifests.Scalar(metric, "type")
+				field := strings.ToLower(kind[:1]) + kind[1:]
+
+				name := manifests.Scalar(metric, field, "name")
+				if name == "" {
+					name = manifests.Scalar(metric, field, "metric", "name")
+				}
+
+				metrics = append(metrics, kind+"/"+name+"/"+manifests.Scalar(metric, field, "target", "type"))
+			}
+
+			if strings.Join(metrics, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("Generate() metrics = %v, expected %v", metrics, test.expected)
+			}
+		})
+	}
+
+	if _, e := (Autoscaler{Min: 1, Max: 3}).Generate(document(t, "apiVersion: v1\nkind: Pod\nmetadata: { name: example }\n")); e == nil {
+		t.Errorf("Generate() targeting a Pod expected an error")
+	}
+}
+
+func TestDisruptionBudgetGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   DisruptionBudget
+		manifest string
+		selector string // selector is the expected "matchLabels.version", which is only derived from the template labels
+		valid    bool
+	}{
+		{name: "min-available", budget: DisruptionBudget{MinAvailable: "1"}, manifest: workload, valid: true},
+		{name: "max-unavailable", budget: DisruptionBudget{MaxUnavailable: "50%"}, manifest: workload, valid: true},
+		{name: "template-labels", budget: DisruptionBudget{MinAvailable: "1"}, manifest: strings.Replace(workload, "matchLabels: { app: example }", "{}", 1), selector: "v1", valid: true},
+		{name: "both", budget: DisruptionBudget{MinAvailable: "1", MaxUnavailable: "1"}, manifest: workload},
+		{name: "neither", budget: DisruptionBudget{}, manifest: workload},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node, e := test.budget.Generate(document(t, test.manifest))
+			if (e == nil) != test.valid {
+				t.Fatalf("Generate() = %v, expected valid: %t", e, test.valid)
+			}
+
+			if !(test.valid) {
+				return
+			}
+
+			if version := manifests.Scalar(node, "spec", "selector", "matchLabels", "version"); version != test.selector {
+				t.Errorf("Generate() selector version = %q, expected %q", version, test.selector)
+			}
+
+			if test.budget.MinAvailable != "" && manifests.Lookup(node, "spec", "minAvailable").Tag != "!!int" {
+				t.Errorf("Generate() expected a pod count to be encoded as an integer")
+			}
+
+			if test.budget.MaxUnavailable != "" && manifests.Scalar(node, "spec", "maxUnavailable") != test.budget.MaxUnavailable {
+				t.Errorf("Generate() maxUnavailable = %s, expected %s", manifests.Scalar(node, "spec", "maxUnavailable"), test.budget.MaxUnavailable)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package generate provides deterministic generators for kubernetes resources. Generators return document nodes
+// (see [manifests.Resource]) so output can be written, appended, or further modified without re-parsing.
+package generate
@@ -0,0 +1,285 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// modes are the valid DestinationRule tls modes.
+var modes = []string{"DISABLE", "SIMPLE", "MUTUAL", "ISTIO_MUTUAL"}
+
+// Traffic represents the options of a Service's generated istio traffic resources.
+type Traffic struct {
+	Label    string   // Label is the pod label subsets are derived from; defaults to "version".
+	Weights  []string // Weights split traffic between subsets - "<subset>=<weight>", summing to 100.
+	Mode     string   // Mode is the DestinationRule's tls mode; defaults to "ISTIO_MUTUAL".
+	Port     int      // Port is the Service port to route to; defaults to the Service's first port.
+	Gateway  string   // Gateway is the name of an optional istio Gateway to generate and bind the VirtualService to.
+	Route    string   // Route is the "[<namespace>/]<name>" of an optional Gateway API parent to generate an HTTPRoute for.
+	Hosts    []string // Hosts are the external host(s) the Gateway or HTTPRoute accepts.
+	Selector string   // Selector is the Gateway's ingress-gateway workload selector - "<key>=<value>"; defaults to "istio=ingressgateway".
+}
+
+// Subset represents a DestinationRule subset derived from a workload's pod labels.
+type Subset struct {
+	Name   string
+	Labels map[string]string
+}
+
+// Validate verifies the options and applies default(s).
+func (t *Traffic) Validate() error {
+	if t.Label == "" {
+		t.Label = "version"
+	}
+
+	if t.Mode == "" {
+		t.Mode = "ISTIO_MUTUAL"
+	}
+
+	t.Mode = strings.ToUpper(t.Mode)
+	if !(slices.Contains(modes, t.Mode)) {
+		return fmt.Errorf("invalid tls mode (%s) - expecting one of: %s", t.Mode, strings.Join(modes, ", "))
+	}
+
+	if t.Selector == "" {
+		t.Selector = "istio=ingressgateway"
+	}
+
+	if key, value, valid := strings.Cut(t.Selector, "="); !(valid) || key == "" || value == "" {
+		return fmt.Errorf("invalid gateway selector - expecting \"<key>=<value>\": %s", t.Selector)
+	}
+
+	if t.Gateway != "" && t.Route != "" {
+		return errors.New("an istio gateway and an http-route are mutually exclusive")
+	}
+
+	if t.Gateway != "" && len(t.Hosts) == 0 {
+		return errors.New("a gateway requires at least one host")
+	}
+
+	// --> an HTTPRoute's backends are Services, so it can't address a DestinationRule's subsets
+	if t.Route != "" && len(t.Weights) > 0 {
+		return errors.New("traffic splitting between subsets isn't supported by an http-route - use an istio gateway instead")
+	}
+
+	return nil
+}
+
+// weights parses the "<subset>=<weight>" traffic split, verifying each subset exists and the weights sum to 100.
+func (t *Traffic) weights(subsets []Subset) ([]string, map[string]int, error) {
+	if len(t.Weights) == 0 {
+		return nil, nil, nil
+	}
+
+	known := make(map[string]bool, len(subsets))
+	names := make([]string, 0, len(subsets))
+	for _, subset := range subsets {
+		known[subset.Name] = true
+		names = append(names, subset.Name)
+	}
+
+	var order []string
+	weights := make(map[string]int, len(t.Weights))
+
+	var total int
+	for _, assignment := range t.Weights {
+		name, value, valid := strings.Cut(assignment, "=")
+		weight, e := strconv.Atoi(value)
+		if !(valid) || name == "" || e != nil || weight < 0 || weight > 100 {
+			return nil, nil, fmt.Errorf("invalid weight - expecting \"<subset>=<0-100>\": %s", assignment)
+		}
+
+		if !(known[name]) {
+			return nil, nil, fmt.Errorf("unknown subset (%s) - expecting one of: %s", name, strings.Join(names, ", "))
+		}
+
+		if _, duplicate := weights[name]; duplicate {
+			return nil, nil, fmt.Errorf("duplicate weight for subset: %s", name)
+		}
+
+		order = append(order, name)
+		weights[name] = weight
+		total += weight
+	}
+
+	if total != 100 {
+		return nil, nil, fmt.Errorf("subset weights must sum to 100, not %d", total)
+	}
+
+	return order, weights, nil
+}
+
+// Subsets derives the subsets of a Service from the label values of the workloads it selects, sorted by name. Each
+// subset selects pods by the subset label alone, as the DestinationRule's host already narrows pods to the Service's.
+func (t *Traffic) Subsets(service *manifests.Document, documents []*manifests.Document) []Subset {
+	selector := manifests.Map(manifests.Lookup(service.Root(), "spec", "selector"))
+	if len(selector) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+
+	var subsets []Subset
+	for _, document := range documents {
+		if !(document.Workload()) || document.Namespace() != service.Namespace() {
+			continue
+		}
+
+		template, _ := document.PodTemplate()
+
+		labels := manifests.Map(manifests.Value(template, "labels"))
+		if document.Kind() == "Pod" {
+			labels = document.Labels()
+		}
+
+		if !(manifests.Matches(selector, labels)) {
+			continue
+		}
+
+		value := labels[t.Label]
+		if value == "" || seen[value] {
+			continue
+		}
+
+		seen[value] = true
+
+		subsets = append(subsets, Subset{Name: value, Labels: map[string]string{t.Label: value}})
+	}
+
+	sort.Slice(subsets, func(a, b int) bool { return subsets[a].Name < subsets[b].Name })
+
+	return subsets
+}
+
+// Generate produces the VirtualService and DestinationRule of the target Service document - plus, optionally, an istio
+// Gateway or Gateway API HTTPRoute - deriving subsets from the workloads among documents that the Service selects.
+func (t *Traffic) Generate(service *manifests.Document, documents []*manifests.Document) ([]*yaml.Node, error) {
+	if service.Kind() != "Service" {
+		return nil, fmt.Errorf("unsupported istio target kind (%s) - expecting a Service", service.Kind())
+	}
+
+	if e := t.Validate(); e != nil {
+		return nil, e
+	}
+
+	port := t.Port
+	if port == 0 {
+		ports := manifests.Items(manifests.Lookup(service.Root(), "spec", "ports"))
+		if len(ports) == 0 {
+			return nil, fmt.Errorf("%s doesn't declare any ports", service)
+		}
+
+		port, _ = strconv.Atoi(manifests.Scalar(ports[0], "port"))
+	} else {
+		var declared bool
+		for _, item := range manifests.Items(manifests.Lookup(service.Root(), "spec", "ports")) {
+			if manifests.Scalar(item, "port") == strconv.Itoa(port) {
+				declared = true
+			}
+		}
+
+		if !(declared) {
+			return nil, fmt.Errorf("%s doesn't declare port %d", service, port)
+		}
+	}
+
+	subsets := t.Subsets(service, documents)
+
+	order, weights, e := t.weights(subsets)
+	if e != nil {
+		return nil, e
+	}
+
+	name, namespace, labels := service.Name(), service.Namespace(), service.Labels()
+
+	var resources []*yaml.Node
+
+	{
+		var items []*yaml.Node
+		for _, subset := range subsets {
+			items = append(items, manifests.Object("name", subset.Name, "labels", subset.Labels))
+		}
+
+		resources = append(resources, manifests.Resource("networking.istio.io/v1beta1", "DestinationRule",
+			manifests.Metadata(name, namespace, labels, nil),
+			"spec", manifests.Object(
+				"host", name,
+				"trafficPolicy", manifests.Object("tls", manifests.Object("mode", t.Mode)),
+				"subsets", items,
+			),
+		))
+	}
+
+	{
+		var destinations []*yaml.Node
+		if len(order) == 0 {
+			destinations = append(destinations, manifests.Object("destination", manifests.Object("host", name, "port", manifests.Object("number", port))))
+		}
+
+		for _, subset := range order {
+			destinations = append(destinations, manifests.Object(
+				"destination", manifests.Object("host", name, "subset", subset, "port", manifests.Object("number", port)),
+				"weight", manifests.Integer(weights[subset]),
+			))
+		}
+
+		hosts := []string{name}
+		var gateways []string
+		if t.Gateway != "" {
+			hosts = append(hosts, t.Hosts...)
+			gateways = []string{t.Gateway, "mesh"}
+		}
+
+		resources = append(resources, manifests.Resource("networking.istio.io/v1beta1", "VirtualService",
+			manifests.Metadata(name, namespace, labels, nil),
+			"spec", manifests.Object(
+				"hosts", hosts,
+				"gateways", gateways,
+				"http", manifests.List(manifests.Object("route", destinations)),
+			),
+		))
+	}
+
+	if t.Gateway != "" {
+		key, value, _ := strings.Cut(t.Selector, "=")
+
+		resources = append(resources, manifests.Resource("networking.istio.io/v1beta1", "Gateway",
+			manifests.Metadata(t.Gateway, namespace, labels, nil),
+			"spec", manifests.Object(
+				"selector", map[string]string{key: value},
+				"servers", manifests.List(manifests.Object(
+					"port", manifests.Object("number", 80, "name", "http", "protocol", "HTTP"),
+					"hosts", t.Hosts,
+				)),
+			),
+		))
+	}
+
+	if t.Route != "" {
+		parent := manifests.Object("name", t.Route)
+		if scope, reference, found := strings.Cut(t.Route, "/"); found {
+			parent = manifests.Object("name", reference, "namespace", scope)
+		}
+
+		resources = append(resources, manifests.Resource("gateway.networking.k8s.io/v1", "HTTPRoute",
+			manifests.Metadata(name, namespace, labels, nil),
+			"spec", manifests.Object(
+				"parentRefs", manifests.List(parent),
+				"hostnames", t.Hosts,
+				"rules", manifests.List(manifests.Object(
+					"backendRefs", manifests.List(manifests.Object("name", name, "port", port)),
+				)),
+			),
+		))
+	}
+
+	return resources, nil
+}
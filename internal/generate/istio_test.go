@@ -0,0 +1,189 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// mesh is a Service selecting two versions of a workload, along with an unrelated workload.
+const mesh = `apiVersion: v1
+kind: Service
+metadata: { name: api, namespace: backend }
+spec:
+    selector: { app: api }
+    ports: [ { name: http, port: 80 }, { name: grpc, port: 9090 } ]
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: { name: api-v2, namespace: backend }
+spec:
+    template:
+        metadata:
+            labels: { app: api, version: v2 }
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: { name: api-v1, namespace: backend }
+spec:
+    template:
+        metadata:
+            labels: { app: api, version: v1 }
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: { name: web, namespace: backend }
+spec:
+    template:
+        metadata:
+            labels: { app: web, version: v3 }
+`
+
+func TestTrafficValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		traffic Traffic
+		valid   bool
+	}{
+		{name: "defaults", traffic: Traffic{}, valid: true},
+		{name: "lowercase-mode", traffic: Traffic{Mode: "simple"}, valid: true},
+		{name: "invalid-mode", traffic: Traffic{Mode: "STRICT"}},
+		{name: "invalid-selector", traffic: Traffic{Selector: "istio"}},
+		{name: "gateway-and-route", traffic: Traffic{Gateway: "public", Route: "gateway", Hosts: []string{"example.com"}}},
+		{name: "gateway-without-hosts", traffic: Traffic{Gateway: "public"}},
+		{name: "route-with-weights", traffic: Traffic{Route: "gateway", Weights: []string{"v1=100"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if e := test.traffic.Validate(); (e == nil) != test.valid {
+				t.Errorf("Validate() = %v, expected valid: %t", e, test.valid)
+			}
+		})
+	}
+}
+
+func TestTrafficSubsets(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		expected string
+	}{
+		{name: "selected", selector: "{ app: api }", expected: "v1,v2"},
+		{name: "missing-label", selector: `{ app: api, canary: "" }`},
+		{name: "empty", selector: "{}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documents, e := manifests.Decode("test.yaml", strings.NewReader(strings.Replace(mesh, "selector: { app: api }", "selector: "+test.selector, 1)))
+			if e != nil {
+				t.Fatalf("unable to decode manifest: %v", e)
+			}
+
+			var names []string
+			for _, subset := range (&Traffic{Label: "version"}).Subsets(documents[0], documents) {
+				names = append(names, subset.Name)
+			}
+
+			if strings.Join(names, ",") != test.expected {
+				t.Errorf("Subsets() = %v, expected %s", names, test.expected)
+			}
+		})
+	}
+}
+
+func TestTrafficGenerate(t *testing.T) {
+	documents, e := manifests.Decode("test.yaml", strings.NewReader(mesh))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	traffic := &Traffic{Weights: []string{"v2=10", "v1=90"}, Port: 80, Gateway: "public", Hosts: []string{"api.example.com"}}
+
+	nodes, e := traffic.Generate(documents[0], documents)
+	if e != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", e)
+	}
+
+	expected := `---
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+    name: api
+    namespace: backend
+spec:
+    host: api
+    trafficPolicy:
+        tls:
+            mode: ISTIO_MUTUAL
+    subsets:
+        - name: v1
+          labels:
+            version: v1
+        - name: v2
+          labels:
+            version: v2
+---
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+    name: api
+    namespace: backend
+spec:
+    hosts:
+        - api
+        - api.example.com
+    gateways:
+        - public
+        - mesh
+    http:
+        - route:
+            - destination:
+                host: api
+                subset: v2
+                port:
+                    number: 80
+              weight: 10
+            - destination:
+                host: api
+                subset: v1
+                port:
+                    number: 80
+              weight: 90
+---
+apiVersion: networking.istio.io/v1beta1
+kind: Gateway
+metadata:
+    name: public
+    namespace: backend
+spec:
+    selector:
+        istio: ingressgateway
+    servers:
+        - port:
+            number: 80
+            name: http
+            protocol: HTTP
+          hosts:
+            - api.example.com
+`
+	if output := render(t, nodes...); output != expected {
+		t.Errorf("Generate() =\n%s\nexpected\n%s", output, expected)
+	}
+
+	for _, weights := range [][]string{{"v1=50", "v2=60"}, {"v3=100"}, {"v1=100", "v1=0"}, {"v1"}} {
+		if _, e := (&Traffic{Weights: weights}).Generate(documents[0], documents); e == nil {
+			t.Errorf("Generate() with weights %v expected an error", weights)
+		}
+	}
+
+	if _, e := (&Traffic{Port: 8080}).Generate(documents[0], documents); e == nil {
+		t.Errorf("Generate() with an undeclared port expected an error")
+	}
+
+	if _, e := (&Traffic{}).Generate(documents[1], documents); e == nil {
+		t.Errorf("Generate() of a Deployment expected an error")
+	}
+}
@@ -0,0 +1,206 @@
+package generate
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+//go:embed profiles.yaml
+var profiles []byte
+
+// label matches a valid label value.
+var label = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+
+// Profile represents a namespace's resource budget.
+type Profile struct {
+	Quota  map[string]string `json:"quota" yaml:"quota"`   // Quota is the ResourceQuota's "spec.hard".
+	Limits Limits            `json:"limits" yaml:"limits"` // Limits are the LimitRange's container limits.
+}
+
+// Limits represents a LimitRange's "Container" limits.
+type Limits struct {
+	Default        map[string]string `json:"default,omitempty" yaml:"default,omitempty"`
+	DefaultRequest map[string]string `json:"defaultRequest,omitempty" yaml:"defaultRequest,omitempty"`
+	Max            map[string]string `json:"max,omitempty" yaml:"max,omitempty"`
+	Min            map[string]string `json:"min,omitempty" yaml:"min,omitempty"`
+}
+
+// Profiles represents the namespace generator's configuration - see "profiles.yaml" for the built-in defaults.
+//
+//	pullSecret: registry-credentials
+//	role: edit
+//	profiles:
+//	    small:
+//	        quota: { requests.cpu: "2", requests.memory: 4Gi, pods: "20" }
+//	        limits:
+//	            default: { cpu: 500m, memory: 512Mi }
+//	            defaultRequest: { cpu: 100m, memory: 128Mi }
+type Profiles struct {
+	PullSecret string             `json:"pullSecret,omitempty" yaml:"pullSecret,omitempty"` // PullSecret is the name of the image pull secret the "default" ServiceAccount references.
+	Role       string             `json:"role,omitempty" yaml:"role,omitempty"`             // Role is the ClusterRole granted to the team within its namespace.
+	Profiles   map[string]Profile `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+}
+
+// LoadProfiles returns the built-in profiles, overlaid with those of an optional configuration file: its profiles are
+// added, or replace built-in ones of the same name, and its non-empty settings take precedence.
+func LoadProfiles(path string) (*Profiles, error) {
+	var configuration Profiles
+	if e := yaml.Unmarshal(profiles, &configuration); e != nil {
+		return nil, fmt.Errorf("unable to unmarshal built-in profiles: %w", e)
+	}
+
+	if path == "" {
+		return &configuration, nil
+	}
+
+	content, e := os.ReadFile(path)
+	if e != nil {
+		e = fmt.Errorf("unable to read profiles: %w", e)
+		return nil, e
+	}
+
+	var overrides Profiles
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if e := decoder.Decode(&overrides); e != nil {
+		e = fmt.Errorf("unable to unmarshal profiles (%s): %w", path, e)
+		return nil, e
+	}
+
+	if overrides.PullSecret != "" {
+		configuration.PullSecret = overrides.PullSecret
+	}
+
+	if overrides.Role != "" {
+		configuration.Role = overrides.Role
+	}
+
+	for name, profile := range overrides.Profiles {
+		configuration.Profiles[name] = profile
+	}
+
+	return &configuration, nil
+}
+
+// Names returns the profiles' names, sorted.
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Namespace represents a team namespace's bootstrap bundle.
+type Namespace struct {
+	Name       string  // Name is the namespace's name.
+	Team       string  // Team is the group granted Role within the namespace.
+	Role       string  // Role is the ClusterRole bound to Team.
+	PullSecret string  // PullSecret is the image pull secret referenced by the namespace's "default" ServiceAccount.
+	Istio      bool    // Istio enables istio's sidecar injection.
+	Profile    Profile // Profile is the namespace's resource budget.
+}
+
+// Validate verifies the namespace's required field(s).
+func (n *Namespace) Validate() error {
+	if !(dns1123.MatchString(n.Name)) || len(n.Name) > 63 {
+		return fmt.Errorf("invalid namespace name - must be a lowercase RFC 1123 label: %s", n.Name)
+	}
+
+	if strings.TrimSpace(n.Team) == "" {
+		return errors.New("a team group is required")
+	}
+
+	if n.Role == "" {
+		return errors.New("a team role is required")
+	}
+
+	if n.PullSecret != "" && !(subdomain(n.PullSecret)) {
+		return fmt.Errorf("invalid pull secret name: %s", n.PullSecret)
+	}
+
+	if len(n.Profile.Quota) == 0 {
+		return errors.New("the profile's quota is empty")
+	}
+
+	return nil
+}
+
+// Generate produces the namespace's bundle: the Namespace, its ResourceQuota, LimitRange (unless the profile has no
+// limits), default-deny NetworkPolicy, the "default" ServiceAccount's pull secret reference (unless PullSecret is
+// empty), and the team's RoleBinding.
+func (n *Namespace) Generate() ([]*yaml.Node, error) {
+	if e := n.Validate(); e != nil {
+		return nil, e
+	}
+
+	labels := map[string]string{}
+	if label.MatchString(n.Team) && len(n.Team) <= 63 {
+		labels["team"] = n.Team
+	}
+
+	namespace := map[string]string{"kubernetes.io/metadata.name": n.Name}
+	for key, value := range labels {
+		namespace[key] = value
+	}
+
+	if n.Istio {
+		namespace["istio-injection"] = "enabled"
+	} else {
+		namespace["istio-injection"] = "disabled"
+	}
+
+	documents := []*yaml.Node{
+		manifests.Resource("v1", "Namespace", manifests.Metadata(n.Name, "", namespace, nil)),
+		manifests.Resource("v1", "ResourceQuota", manifests.Metadata("quota", n.Name, labels, nil),
+			"spec", manifests.Object("hard", n.Profile.Quota),
+		),
+	}
+
+	if limits := n.Profile.Limits; len(limits.Default)+len(limits.DefaultRequest)+len(limits.Max)+len(limits.Min) > 0 {
+		documents = append(documents, manifests.Resource("v1", "LimitRange", manifests.Metadata("limits", n.Name, labels, nil),
+			"spec", manifests.Object("limits", manifests.List(manifests.Object(
+				"type", "Container",
+				"default", limits.Default,
+				"defaultRequest", limits.DefaultRequest,
+				"max", limits.Max,
+				"min", limits.Min,
+			))),
+		))
+	}
+
+	documents = append(documents, DefaultDeny(n.Name))
+
+	if n.PullSecret != "" {
+		documents = append(documents, PullSecretPatch("default", n.Name, n.PullSecret))
+	}
+
+	documents = append(documents, manifests.Resource("rbac.authorization.k8s.io/v1", "RoleBinding",
+		manifests.Metadata(fmt.Sprintf("team-%s", strings.ReplaceAll(n.Role, ":", "-")), n.Name, labels, nil),
+		"roleRef", manifests.Object("apiGroup", "rbac.authorization.k8s.io", "kind", "ClusterRole", "name", n.Role),
+		"subjects", manifests.List(manifests.Object("apiGroup", "rbac.authorization.k8s.io", "kind", "Group", "name", n.Team)),
+	))
+
+	return documents, nil
+}
+
+// Kustomization produces a kustomization of the given resource file(s).
+func Kustomization(resources ...string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{
+		manifests.Object("apiVersion", "kustomize.config.k8s.io/v1beta1", "kind", "Kustomization", "resources", resources),
+	}}
+}
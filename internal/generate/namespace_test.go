@@ -0,0 +1,230 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	builtin, e := LoadProfiles("")
+	if e != nil {
+		t.Fatalf("LoadProfiles() returned an unexpected error: %v", e)
+	}
+
+	if names := builtin.Names(); !(slices.Equal(names, []string{"large", "medium", "small"})) || builtin.PullSecret != "registry-credentials" || builtin.Role != "edit" {
+		t.Errorf("LoadProfiles() = %+v, expected the built-in profiles and settings", builtin)
+	}
+
+	directory := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		check   func(profiles *Profiles) bool
+		invalid bool
+	}{
+		{
+			name:    "overlay",
+			content: "role: admin\nprofiles:\n    small: { quota: { pods: \"5\" } }\n    tiny: { quota: { pods: \"1\" } }\n",
+			check: func(profiles *Profiles) bool {
+				small := profiles.Profiles["small"]
+				return profiles.Role == "admin" && profiles.PullSecret == "registry-credentials" && len(small.Quota) == 1 && len(small.Limits.Default) == 0 && slices.Equal(profiles.Names(), []string{"large", "medium", "small", "tiny"})
+			},
+		},
+		{name: "unknown-field", content: "profiles:\n    small: { quotas: {} }\n", invalid: true},
+		{name: "malformed", content: "profiles: [\n", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(directory, test.name+".yaml")
+			if e := os.WriteFile(path, []byte(test.content), 0o600); e != nil {
+				t.Fatalf("unable to write profiles: %v", e)
+			}
+
+			profiles, e := LoadProfiles(path)
+			if test.invalid {
+				if e == nil {
+					t.Errorf("LoadProfiles() = %+v, expected an error", profiles)
+				}
+
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("LoadProfiles() returned an unexpected error: %v", e)
+			}
+
+			if !(test.check(profiles)) {
+				t.Errorf("LoadProfiles() = %+v, expected the file's profiles and settings to overlay the built-in ones", profiles)
+			}
+		})
+	}
+
+	if _, e := LoadProfiles(filepath.Join(directory, "missing.yaml")); e == nil {
+		t.Errorf("LoadProfiles() of a missing file expected an error")
+	}
+}
+
+func TestNamespaceValidate(t *testing.T) {
+	valid := func() Namespace {
+		return Namespace{Name: "payments", Team: "payments-team", Role: "edit", PullSecret: "registry-credentials", Profile: Profile{Quota: map[string]string{"pods": "10"}}}
+	}
+
+	tests := []struct {
+		name     string
+		mutation func(namespace *Namespace)
+		valid    bool
+	}{
+		{name: "valid", mutation: func(namespace *Namespace) {}, valid: true},
+		{name: "without-pull-secret", mutation: func(namespace *Namespace) { namespace.PullSecret = "" }, valid: true},
+		{name: "uppercase-name", mutation: func(namespace *Namespace) { namespace.Name = "Payments" }},
+		{name: "long-name", mutation: func(namespace *Namespace) { namespace.Name = strings.Repeat("a", 64) }},
+		{name: "without-team", mutation: func(namespace *Namespace) { namespace.Team = " " }},
+		{name: "without-role", mutation: func(namespace *Namespace) { namespace.Role = "" }},
+		{name: "invalid-pull-secret", mutation: func(namespace *Namespace) { namespace.PullSecret = "Registry_Credentials" }},
+		{name: "empty-quota", mutation: func(namespace *Namespace) { namespace.Profile.Quota = nil }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespace := valid()
+			test.mutation(&namespace)
+
+			if e := namespace.Validate(); (e == nil) != test.valid {
+				t.Errorf("Validate() = %v, expected valid: %t", e, test.valid)
+			}
+		})
+	}
+}
+
+func TestNamespaceGenerate(t *testing.T) {
+	namespace := Namespace{
+		Name:       "payments",
+		Team:       "payments-team",
+		Role:       "system:aggregate-to-edit",
+		PullSecret: "registry-credentials",
+		Istio:      true,
+		Profile: Profile{
+			Quota:  map[string]string{"pods": "10"},
+			Limits: Limits{Default: map[string]string{"cpu": "500m"}},
+		},
+	}
+
+	nodes, e := namespace.Generate()
+	if e != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", e)
+	}
+
+	var output strings.Builder
+	if e := manifests.Write(&output, nodes...); e != nil {
+		t.Fatalf("unable to write documents: %v", e)
+	}
+
+	expected := `---
+apiVersion: v1
+kind: Namespace
+metadata:
+    name: payments
+    labels:
+        istio-injection: enabled
+        kubernetes.io/metadata.name: payments
+        team: payments-team
+---
+apiVersion: v1
+kind: ResourceQuota
+metadata:
+    name: quota
+    namespace: payments
+    labels:
+        team: payments-team
+spec:
+    hard:
+        pods: "10"
+---
+apiVersion: v1
+kind: LimitRange
+metadata:
+    name: limits
+    namespace: payments
+    labels:
+        team: payments-team
+spec:
+    limits:
+        - type: Container
+          default:
+            cpu: 500m
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+    name: default-deny
+    namespace: payments
+spec:
+    podSelector: {}
+    policyTypes:
+        - Ingress
+        - Egress
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+    name: default
+    namespace: payments
+imagePullSecrets:
+    - name: registry-credentials
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+    name: team-system-aggregate-to-edit
+    namespace: payments
+    labels:
+        team: payments-team
+roleRef:
+    apiGroup: rbac.authorization.k8s.io
+    kind: ClusterRole
+    name: system:aggregate-to-edit
+subjects:
+    - apiGroup: rbac.authorization.k8s.io
+      kind: Group
+      name: payments-team
+`
+
+	if output.String() != expected {
+		t.Errorf("Generate() =\n%s\nexpected:\n%s", output.String(), expected)
+	}
+}
+
+func TestNamespaceGenerateMinimal(t *testing.T) {
+	// --> the team isn't a valid label value, so resources aren't labeled with it
+	namespace := Namespace{Name: "payments", Team: "Payments Team", Role: "edit", Profile: Profile{Quota: map[string]string{"pods": "10"}}}
+
+	nodes, e := namespace.Generate()
+	if e != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", e)
+	}
+
+	var kinds []string
+	for _, node := range nodes {
+		kinds = append(kinds, manifests.Scalar(node.Content[0], "kind"))
+	}
+
+	if expected := []string{"Namespace", "ResourceQuota", "NetworkPolicy", "RoleBinding"}; !(slices.Equal(kinds, expected)) {
+		t.Errorf("Generate() kinds = %v, expected %v", kinds, expected)
+	}
+
+	labels := manifests.Map(manifests.Lookup(nodes[0].Content[0], "metadata", "labels"))
+	if labels["istio-injection"] != "disabled" || labels["team"] != "" {
+		t.Errorf("Generate() namespace labels = %v, expected istio-injection disabled, without a team label", labels)
+	}
+
+	if subjects := manifests.Items(manifests.Value(nodes[3].Content[0], "subjects")); len(subjects) != 1 || manifests.Scalar(subjects[0], "name") != "Payments Team" {
+		t.Errorf("Generate() RoleBinding subjects = %v, expected the team group", subjects)
+	}
+}
@@ -0,0 +1,312 @@
+package generate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Peer represents a NetworkPolicy peer: either a CIDR block, or pods selected by namespace and/or label(s).
+//
+//	namespace: database
+//	pods: { app: postgres }
+//	ports: [ "5432" ]
+type Peer struct {
+	Namespace string            `json:"namespace,omitempty" yaml:"namespace,omitempty"` // Namespace selects a namespace by name; empty selects the policy's own namespace.
+	Pods      map[string]string `json:"pods,omitempty" yaml:"pods,omitempty"`           // Pods selects pods by label(s).
+	CIDR      string            `json:"cidr,omitempty" yaml:"cidr,omitempty"`
+	Except    []string          `json:"except,omitempty" yaml:"except,omitempty"`
+	Ports     []string          `json:"ports,omitempty" yaml:"ports,omitempty"` // Ports are "<port>[/<protocol>]" - port(s) may be names.
+}
+
+// Dependencies represents a file of a service's allowed ingress and egress peers.
+type Dependencies struct {
+	Ingress []Peer `json:"ingress,omitempty" yaml:"ingress,omitempty"`
+	Egress  []Peer `json:"egress,omitempty" yaml:"egress,omitempty"`
+}
+
+// Presets are built-in egress peers, by name.
+var Presets = map[string]Peer{
+	"dns": {
+		Namespace: "kube-system",
+		Pods:      map[string]string{"k8s-app": "kube-dns"},
+		Ports:     []string{"53/UDP", "53/TCP"},
+	},
+	"istio": {
+		Namespace: "istio-system",
+		Pods:      map[string]string{"app": "istiod"},
+		Ports:     []string{"15010/TCP", "15012/TCP"},
+	},
+}
+
+// NetworkPolicy represents the options of a workload's generated NetworkPolicy.
+type NetworkPolicy struct {
+	Ingress []Peer   // Ingress are the peers allowed to reach the workload's declared port(s).
+	Egress  []Peer   // Egress are the peers the workload may reach.
+	Presets []string // Presets are the names of built-in egress peers (see [Presets]).
+}
+
+// ParsePeer parses a peer from its flag representation:
+//
+//   - "cidr:10.0.0.0/8"
+//   - "namespace:gateway"
+//   - "namespace:gateway/app=gateway,version=v1"
+//   - "pods:app=frontend"
+//
+// Port(s) may be appended after an "@" (e.g. "namespace:database/app=postgres@5432,5433/TCP").
+func ParsePeer(value string) (Peer, error) {
+	var peer Peer
+
+	invalid := fmt.Errorf("invalid peer - expecting \"cidr:<cidr>\", \"namespace:<name>[/<key>=<value>,...]\" or \"pods:<key>=<value>[,...]\" with optional \"@<port>[/<protocol>],...\": %s", value)
+
+	selector, ports, found := strings.Cut(value, "@")
+	if found {
+		peer.Ports = strings.Split(ports, ",")
+	}
+
+	kind, remainder, found := strings.Cut(selector, ":")
+	if !(found) || remainder == "" {
+		return peer, invalid
+	}
+
+	labels := func(value string) (map[string]string, error) {
+		mapping := make(map[string]string)
+		for _, pair := range strings.Split(value, ",") {
+			key, value, valid := strings.Cut(pair, "=")
+			if !(valid) || key == "" {
+				return nil, invalid
+			}
+
+			mapping[key] = value
+		}
+
+		return mapping, nil
+	}
+
+	var e error
+	switch kind {
+	case "cidr":
+		peer.CIDR = remainder
+	case "namespace":
+		namespace, selector, found := strings.Cut(remainder, "/")
+		peer.Namespace = namespace
+		if found {
+			peer.Pods, e = labels(selector)
+		}
+	case "pods":
+		peer.Pods, e = labels(remainder)
+	default:
+		e = invalid
+	}
+
+	return peer, e
+}
+
+// Generate produces a NetworkPolicy for a workload document. Ingress is allowed on the workload's declared container
+// port(s), along with any port(s) targeted by the given Service document(s) that select the workload's pods.
+func (n NetworkPolicy) Generate(workload *manifests.Document, services ...*manifests.Document) (*yaml.Node, error) {
+	spec, _ := workload.PodSpec()
+	if spec == nil {
+		return nil, fmt.Errorf("unsupported network policy target kind: %s", workload.Kind())
+	}
+
+	template, _ := workload.PodTemplate()
+
+	labels := manifests.Map(manifests.Value(template, "labels"))
+	if workload.Kind() == "Pod" {
+		labels = workload.Labels()
+	}
+
+	selector := manifests.Map(manifests.Lookup(workload.Root(), "spec", "selector", "matchLabels"))
+	if len(selector) == 0 {
+		selector = labels
+	}
+
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("%s has neither a selector nor pod labels", workload)
+	}
+
+	ports := n.ports(workload, labels, services...)
+
+	var ingress []*yaml.Node
+	if len(n.Ingress) == 0 {
+		ingress = append(ingress, manifests.Object("ports", ports))
+	}
+
+	for _, peer := range n.Ingress {
+		rule, e := peer.rule("from", ports)
+		if e != nil {
+			return nil, e
+		}
+
+		ingress = append(ingress, rule)
+	}
+
+	var egress []*yaml.Node
+	for _, name := range n.Presets {
+		peer, valid := Presets[name]
+		if !(valid) {
+			return nil, fmt.Errorf("unknown preset (%s) - expecting one of: %s", name, strings.Join(presets(), ", "))
+		}
+
+		rule, e := peer.rule("to", nil)
+		if e != nil {
+			return nil, e
+		}
+
+		egress = append(egress, rule)
+	}
+
+	for _, peer := range n.Egress {
+		rule, e := peer.rule("to", nil)
+		if e != nil {
+			return nil, e
+		}
+
+		egress = append(egress, rule)
+	}
+
+	types := []string{"Ingress"}
+	if len(egress) > 0 {
+		types = append(types, "Egress")
+	}
+
+	return manifests.Resource("networking.k8s.io/v1", "NetworkPolicy",
+		manifests.Metadata(workload.Name(), workload.Namespace(), workload.Labels(), nil),
+		"spec", manifests.Object(
+			"podSelector", manifests.Object("matchLabels", selector),
+			"policyTypes", types,
+			"ingress", ingress,
+			"egress", egress,
+		),
+	), nil
+}
+
+// DefaultDeny produces a NetworkPolicy that denies all ingress and egress traffic within a namespace.
+func DefaultDeny(namespace string) *yaml.Node {
+	return manifests.Resource("networking.k8s.io/v1", "NetworkPolicy",
+		manifests.Metadata("default-deny", namespace, nil, nil),
+		"spec", manifests.Object(
+			"podSelector", manifests.Empty(),
+			"policyTypes", []string{"Ingress", "Egress"},
+		),
+	)
+}
+
+// ports returns a workload's ingress port(s): its containers' declared ports, followed by any additional port(s)
+// targeted by services selecting the workload's pods.
+func (n NetworkPolicy) ports(workload *manifests.Document, labels map[string]string, services ...*manifests.Document) []*yaml.Node {
+	var ports []*yaml.Node
+
+	seen := make(map[string]bool)
+	add := func(port *yaml.Node, protocol string) {
+		if port == nil || port.Value == "" {
+			return
+		}
+
+		if protocol == "" {
+			protocol = "TCP"
+		}
+
+		if key := port.Value + "/" + protocol; !(seen[key]) {
+			seen[key] = true
+			ports = append(ports, manifests.Object("port", manifests.Copy(port), "protocol", protocol))
+		}
+	}
+
+	for _, container := range workload.Containers() {
+		for _, port := range manifests.Items(manifests.Value(container.Node, "ports")) {
+			add(manifests.Value(port, "containerPort"), manifests.Scalar(port, "protocol"))
+		}
+	}
+
+	for _, service := range services {
+		if service.Kind() != "Service" || service.Namespace() != workload.Namespace() {
+			continue
+		}
+
+		selector := manifests.Map(manifests.Lookup(service.Root(), "spec", "selector"))
+		if len(selector) == 0 || !(manifests.Matches(selector, labels)) {
+			continue
+		}
+
+		for _, port := range manifests.Items(manifests.Lookup(service.Root(), "spec", "ports")) {
+			target := manifests.Value(port, "targetPort")
+			if target == nil {
+				target = manifests.Value(port, "port")
+			}
+
+			// --> named target ports resolve to a container's declared port, which is already included
+			if _, e := strconv.Atoi(target.Value); e != nil {
+				continue
+			}
+
+			add(target, manifests.Scalar(port, "protocol"))
+		}
+	}
+
+	return ports
+}
+
+// rule converts a peer into an ingress ("from") or egress ("to") rule. Peers without explicit port(s) use defaults.
+func (p Peer) rule(direction string, defaults []*yaml.Node) (*yaml.Node, error) {
+	var peer *yaml.Node
+	switch {
+	case p.CIDR != "":
+		peer = manifests.Object("ipBlock", manifests.Object("cidr", p.CIDR, "except", p.Except))
+	default:
+		var namespace, pods *yaml.Node
+		if p.Namespace != "" {
+			namespace = manifests.Object("matchLabels", map[string]string{"kubernetes.io/metadata.name": p.Namespace})
+		}
+
+		if len(p.Pods) > 0 {
+			pods = manifests.Object("matchLabels", p.Pods)
+		} else if namespace == nil {
+			pods = manifests.Empty()
+		}
+
+		peer = manifests.Object("namespaceSelector", namespace, "podSelector", pods)
+	}
+
+	ports := defaults
+	if len(p.Ports) > 0 {
+		ports = nil
+		for _, value := range p.Ports {
+			port, protocol, _ := strings.Cut(strings.TrimSpace(value), "/")
+			if port == "" {
+				return nil, fmt.Errorf("invalid peer port: %q", value)
+			}
+
+			if protocol == "" {
+				protocol = "TCP"
+			}
+
+			var node interface{} = port
+			if number, e := strconv.Atoi(port); e == nil {
+				node = number
+			}
+
+			ports = append(ports, manifests.Object("port", node, "protocol", strings.ToUpper(protocol)))
+		}
+	}
+
+	return manifests.Object(direction, manifests.List(peer), "ports", ports), nil
+}
+
+// presets returns the names of the built-in presets.
+func presets() []string {
+	names := make([]string, 0, len(Presets))
+	for name := range Presets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,112 @@
+package generate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+func TestParsePeer(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Peer
+		valid    bool
+	}{
+		{value: "cidr:10.0.0.0/8", expected: Peer{CIDR: "10.0.0.0/8"}, valid: true},
+		{value: "namespace:gateway", expected: Peer{Namespace: "gateway"}, valid: true},
+		{value: "namespace:gateway/app=gateway,version=v1", expected: Peer{Namespace: "gateway", Pods: map[string]string{"app": "gateway", "version": "v1"}}, valid: true},
+		{value: "pods:app=frontend@8080,9090/UDP", expected: Peer{Pods: map[string]string{"app": "frontend"}, Ports: []string{"8080", "9090/UDP"}}, valid: true},
+		{value: "pods:app", valid: false},
+		{value: "service:example", valid: false},
+		{value: "cidr:", valid: false},
+	}
+
+	for _, test := range tests {
+		peer, e := ParsePeer(test.value)
+		if (e == nil) != test.valid {
+			t.Errorf("ParsePeer(%q) = %v, expected valid: %t", test.value, e, test.valid)
+			continue
+		}
+
+		if test.valid && !(reflect.DeepEqual(peer, test.expected)) {
+			t.Errorf("ParsePeer(%q) = %+v, expected %+v", test.value, peer, test.expected)
+		}
+	}
+}
+
+func TestNetworkPolicyGenerate(t *testing.T) {
+	documents, e := manifests.Decode("test.yaml", strings.NewReader(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+    name: example
+spec:
+    selector:
+        matchLabels: { app: example }
+    template:
+        metadata:
+            labels: { app: example, version: v1 }
+        spec:
+            containers:
+                - name: example
+                  ports: [ { name: http, containerPort: 8080 } ]
+---
+apiVersion: v1
+kind: Service
+metadata:
+    name: example
+spec:
+    selector: { app: example }
+    ports:
+        - { name: http, port: 80, targetPort: http }
+        - { name: metrics, port: 9090, targetPort: 9090 }
+---
+apiVersion: v1
+kind: Service
+metadata:
+    name: other
+spec:
+    selector: { app: other }
+    ports: [ { name: http, port: 80, targetPort: 8081 } ]
+`))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	policy := NetworkPolicy{
+		Ingress: []Peer{{Namespace: "gateway"}},
+		Egress:  []Peer{{CIDR: "10.0.0.0/8", Ports: []string{"443"}}},
+		Presets: []string{"dns"},
+	}
+
+	node, e := policy.Generate(documents[0], documents[1:]...)
+	if e != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", e)
+	}
+
+	var ports []string
+	for _, port := range manifests.Items(manifests.Value(manifests.Items(manifests.Lookup(node, "spec", "ingress"))[0], "ports")) {
+		ports = append(ports, manifests.Scalar(port, "port")+"/"+manifests.Scalar(port, "protocol"))
+	}
+
+	if expected := []string{"8080/TCP", "9090/TCP"}; !(reflect.DeepEqual(ports, expected)) {
+		t.Errorf("Generate() ingress ports = %v, expected %v", ports, expected)
+	}
+
+	if types := manifests.Lookup(node, "spec", "policyTypes"); len(types.Content) != 2 {
+		t.Errorf("Generate() expected both Ingress and Egress policy types")
+	}
+
+	if egress := manifests.Items(manifests.Lookup(node, "spec", "egress")); len(egress) != 2 {
+		t.Errorf("Generate() = %d egress rule(s), expected the dns preset and the cidr peer", len(egress))
+	}
+
+	if _, e := (NetworkPolicy{Presets: []string{"unknown"}}).Generate(documents[0]); e == nil {
+		t.Errorf("Generate() with an unknown preset expected an error")
+	}
+
+	if _, e := (NetworkPolicy{}).Generate(documents[1]); e == nil {
+		t.Errorf("Generate() targeting a Service expected an error")
+	}
+}
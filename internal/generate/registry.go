@@ -0,0 +1,158 @@
+package generate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// DockerConfigJSON is the type of an image pull secret, whose ".dockerconfigjson" key holds registry credentials.
+const DockerConfigJSON = "kubernetes.io/dockerconfigjson"
+
+// DockerHub is the server docker associates with unqualified images.
+const DockerHub = "https://index.docker.io/v1/"
+
+// Credential represents a single registry's entry of a ".dockerconfigjson" payload.
+type Credential struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth,omitempty"` // Auth is the base64-encoded "<username>:<password>".
+}
+
+// Registries represents the registry credentials of a ".dockerconfigjson" payload, keyed by server.
+type Registries map[string]Credential
+
+// Add sets a registry's credential, erroring if the server was previously added.
+func (r Registries) Add(server, username, password, email string) error {
+	if server == "" {
+		return errors.New("a registry server is required")
+	}
+
+	if username == "" || password == "" {
+		return fmt.Errorf("both a username and password (or token) are required for registry %s", server)
+	}
+
+	if _, exists := r[server]; exists {
+		return fmt.Errorf("duplicate registry server: %s", server)
+	}
+
+	r[server] = Credential{
+		Username: username,
+		Password: password,
+		Email:    email,
+		Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+	}
+
+	return nil
+}
+
+// ParseRegistry adds a "<server>=<username>:<password>" registry.
+func (r Registries) ParseRegistry(value string) error {
+	server, credentials, valid := strings.Cut(value, "=")
+	username, password, separated := strings.Cut(credentials, ":")
+	if !(valid) || !(separated) {
+		// --> the value isn't included, as it may contain a password
+		return errors.New("invalid registry - expecting \"<server>=<username>:<password>\"")
+	}
+
+	return r.Add(server, username, password, "")
+}
+
+// Import copies the entries of a docker client configuration file (e.g. "~/.docker/config.json") - only the given
+// server(s), if any. Servers that already exist aren't overwritten. Import returns the server(s) that were skipped
+// because their credentials are held by a credential helper, rather than the file.
+func (r Registries) Import(path string, servers ...string) ([]string, error) {
+	content, e := os.ReadFile(path)
+	if e != nil {
+		return nil, fmt.Errorf("unable to read docker configuration: %w", e)
+	}
+
+	var configuration struct {
+		Auths       map[string]Credential `json:"auths"`
+		CredsStore  string                `json:"credsStore"`
+		CredHelpers map[string]string     `json:"credHelpers"`
+	}
+
+	if e := json.Unmarshal(content, &configuration); e != nil {
+		return nil, fmt.Errorf("unable to unmarshal docker configuration (%s): %w", path, e)
+	}
+
+	wanted := func(server string) bool {
+		return len(servers) == 0 || slices.Contains(servers, server)
+	}
+
+	var skipped []string
+	for server, credential := range configuration.Auths {
+		if !(wanted(server)) {
+			continue
+		}
+
+		if _, exists := r[server]; exists {
+			continue
+		}
+
+		if credential.Auth == "" && credential.Username == "" {
+			skipped = append(skipped, server)
+			continue
+		}
+
+		if credential.Auth == "" {
+			credential.Auth = base64.StdEncoding.EncodeToString([]byte(credential.Username + ":" + credential.Password))
+		}
+
+		r[server] = credential
+	}
+
+	for server := range configuration.CredHelpers {
+		if _, exists := r[server]; !(exists) && wanted(server) {
+			skipped = append(skipped, server)
+		}
+	}
+
+	for _, server := range servers {
+		if _, exists := r[server]; !(exists) && !(slices.Contains(skipped, server)) {
+			return nil, fmt.Errorf("registry %s not found in docker configuration (%s)", server, path)
+		}
+	}
+
+	sort.Strings(skipped)
+
+	return skipped, nil
+}
+
+// JSON returns the ".dockerconfigjson" payload.
+func (r Registries) JSON() ([]byte, error) {
+	return json.Marshal(map[string]Registries{"auths": r})
+}
+
+// Configuration returns the path of the current user's docker client configuration, honoring "DOCKER_CONFIG".
+func Configuration() (string, error) {
+	if directory := os.Getenv("DOCKER_CONFIG"); directory != "" {
+		return filepath.Join(directory, "config.json"), nil
+	}
+
+	home, e := os.UserHomeDir()
+	if e != nil {
+		return "", fmt.Errorf("unable to locate home directory: %w", e)
+	}
+
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// PullSecretPatch produces a strategic-merge patch that adds secret to a ServiceAccount's "imagePullSecrets".
+func PullSecretPatch(account, namespace, secret string) *yaml.Node {
+	return manifests.Resource("v1", "ServiceAccount", manifests.Metadata(account, namespace, nil, nil),
+		"imagePullSecrets", manifests.List(manifests.Object("name", secret)),
+	)
+}
@@ -0,0 +1,122 @@
+package generate
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRegistriesParseRegistry(t *testing.T) {
+	registries := Registries{}
+
+	if e := registries.ParseRegistry("ghcr.io=user:p@ss:word"); e != nil {
+		t.Fatalf("ParseRegistry() returned an unexpected error: %v", e)
+	}
+
+	credential := registries["ghcr.io"]
+	if credential.Password != "p@ss:word" || credential.Auth != base64.StdEncoding.EncodeToString([]byte("user:p@ss:word")) {
+		t.Errorf("ParseRegistry() = %+v, expected the password to include every character after the first \":\"", credential)
+	}
+
+	for _, value := range []string{"ghcr.io=user:other", "ghcr.io", "ghcr.io=user", "=user:password", "quay.io=:password"} {
+		if e := registries.ParseRegistry(value); e == nil {
+			t.Errorf("ParseRegistry(%q) expected an error", value)
+		}
+	}
+}
+
+func TestRegistriesImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	content := `{
+    "auths": {
+        "ghcr.io": { "auth": "dXNlcjpwYXNzd29yZA==" },
+        "quay.io": { "username": "user", "password": "password" },
+        "registry.io": {},
+        "existing.io": { "auth": "b3RoZXI6b3RoZXI=" }
+    },
+    "credHelpers": { "gcr.io": "gcloud" }
+}`
+	if e := os.WriteFile(path, []byte(content), 0o600); e != nil {
+		t.Fatalf("unable to write docker configuration: %v", e)
+	}
+
+	tests := []struct {
+		name     string
+		servers  []string
+		imported []string
+		skipped  []string
+		valid    bool
+	}{
+		{name: "all", imported: []string{"existing.io", "ghcr.io", "quay.io"}, skipped: []string{"gcr.io", "registry.io"}, valid: true},
+		{name: "selected", servers: []string{"quay.io", "gcr.io"}, imported: []string{"existing.io", "quay.io"}, skipped: []string{"gcr.io"}, valid: true},
+		{name: "missing", servers: []string{"docker.io"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registries := Registries{}
+			if e := registries.Add("existing.io", "user", "password", ""); e != nil {
+				t.Fatalf("Add() returned an unexpected error: %v", e)
+			}
+
+			skipped, e := registries.Import(path, test.servers...)
+			if (e == nil) != test.valid {
+				t.Fatalf("Import() = %v, expected valid: %t", e, test.valid)
+			}
+
+			if !(test.valid) {
+				return
+			}
+
+			if !(slices.Equal(skipped, test.skipped)) {
+				t.Errorf("Import() skipped = %v, expected %v", skipped, test.skipped)
+			}
+
+			var imported []string
+			for server := range registries {
+				imported = append(imported, server)
+			}
+
+			slices.Sort(imported)
+			if !(slices.Equal(imported, test.imported)) {
+				t.Errorf("Import() = %v, expected %v", imported, test.imported)
+			}
+
+			if registries["existing.io"].Username != "user" {
+				t.Errorf("Import() overwrote an existing registry")
+			}
+
+			if quay, exists := registries["quay.io"]; exists && quay.Auth != base64.StdEncoding.EncodeToString([]byte("user:password")) {
+				t.Errorf("Import() auth = %s, expected it derived from the username and password", quay.Auth)
+			}
+		})
+	}
+}
+
+func TestConfiguration(t *testing.T) {
+	directory := t.TempDir()
+
+	t.Setenv("DOCKER_CONFIG", directory)
+
+	if path, e := Configuration(); e != nil || path != filepath.Join(directory, "config.json") {
+		t.Errorf("Configuration() = (%s, %v), expected %s", path, e, filepath.Join(directory, "config.json"))
+	}
+}
+
+func TestPullSecretPatch(t *testing.T) {
+	expected := `---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+    name: default
+    namespace: backend
+imagePullSecrets:
+    - name: registry
+`
+	if output := render(t, PullSecretPatch("default", "backend", "registry")); output != expected {
+		t.Errorf("PullSecretPatch() =\n%s\nexpected\n%s", output, expected)
+	}
+}
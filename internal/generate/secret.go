@@ -0,0 +1,226 @@
+package generate
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/dotenv"
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Secret types.
+const (
+	Opaque = "Opaque"            // Opaque is an arbitrary, user-defined secret.
+	TLS    = "kubernetes.io/tls" // TLS is a certificate and its private key, under the "tls.crt" and "tls.key" keys.
+)
+
+// key matches a valid Secret data key.
+var key = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// Secret represents the options of a generated Secret.
+type Secret struct {
+	Name      string
+	Namespace string
+	Type      string            // Type defaults to [Opaque].
+	Labels    map[string]string // Labels are added to the Secret's metadata.
+	Plain     bool              // Plain writes values to "stringData" rather than base64-encoded "data".
+
+	data map[string][]byte
+}
+
+// Add sets a data key's value, erroring if the key is invalid or was previously added.
+func (s *Secret) Add(name string, value []byte) error {
+	if !(key.MatchString(name)) {
+		return fmt.Errorf("invalid secret key (%q) - keys may only contain alphanumeric characters, \"-\", \"_\", or \".\"", name)
+	}
+
+	if _, exists := s.data[name]; exists {
+		return fmt.Errorf("duplicate secret key: %s", name)
+	}
+
+	if s.data == nil {
+		s.data = make(map[string][]byte)
+	}
+
+	s.data[name] = value
+
+	return nil
+}
+
+// Literal adds a "<key>=<value>" pair.
+func (s *Secret) Literal(pair string) error {
+	name, value, valid := strings.Cut(pair, "=")
+	if !(valid) {
+		return fmt.Errorf("invalid literal - expecting \"<key>=<value>\": %s", pair)
+	}
+
+	return s.Add(name, []byte(value))
+}
+
+// EnvFile adds every assignment of a ".env" file.
+func (s *Secret) EnvFile(path string) error {
+	variables, e := dotenv.Read(path)
+	if e != nil {
+		return e
+	}
+
+	for _, variable := range variables {
+		if e := s.Add(variable.Key, []byte(variable.Value)); e != nil {
+			return fmt.Errorf("%s: %w", path, e)
+		}
+	}
+
+	return nil
+}
+
+// File adds a file's contents - source is either "<key>=<path>" or a path, whose base name becomes the key. A
+// directory's regular files are each added under their base name.
+func (s *Secret) File(source string) error {
+	name, path, valid := strings.Cut(source, "=")
+	if !(valid) {
+		name, path = "", source
+	}
+
+	information, e := os.Stat(path)
+	if e != nil {
+		return fmt.Errorf("unable to read secret file: %w", e)
+	}
+
+	if !(information.IsDir()) {
+		content, e := os.ReadFile(path)
+		if e != nil {
+			return fmt.Errorf("unable to read secret file: %w", e)
+		}
+
+		if name == "" {
+			name = filepath.Base(path)
+		}
+
+		return s.Add(name, content)
+	}
+
+	if name != "" {
+		return fmt.Errorf("a key can't be given for a directory: %s", source)
+	}
+
+	entries, e := os.ReadDir(path)
+	if e != nil {
+		return fmt.Errorf("unable to read secret directory: %w", e)
+	}
+
+	for _, entry := range entries {
+		if !(entry.Type().IsRegular()) {
+			continue
+		}
+
+		if e := s.File(filepath.Join(path, entry.Name())); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Validate verifies the Secret's name, type, and - for [TLS] secrets - that its certificate and key form a valid pair.
+func (s *Secret) Validate() error {
+	if len(s.Name) > 253 || !(subdomain(s.Name)) {
+		return fmt.Errorf("invalid secret name (%q) - must be a lowercase RFC 1123 subdomain", s.Name)
+	}
+
+	switch s.Type {
+	case "", Opaque:
+	case TLS:
+		certificate, private := s.data["tls.crt"], s.data["tls.key"]
+		if certificate == nil || private == nil {
+			return fmt.Errorf("a %s secret requires both the \"tls.crt\" and \"tls.key\" keys (e.g. --from-file tls.crt=./certificate.pem)", TLS)
+		}
+
+		if _, e := tls.X509KeyPair(certificate, private); e != nil {
+			return fmt.Errorf("invalid %s secret: %w", TLS, e)
+		}
+	case DockerConfigJSON:
+		var payload struct {
+			Auths map[string]Credential `json:"auths"`
+		}
+
+		if e := json.Unmarshal(s.data[".dockerconfigjson"], &payload); e != nil || len(payload.Auths) == 0 {
+			return fmt.Errorf("a %s secret requires a \".dockerconfigjson\" key with at least one registry", DockerConfigJSON)
+		}
+	default:
+		return fmt.Errorf("unsupported secret type (%s) - expecting %s, %s or %s", s.Type, Opaque, TLS, DockerConfigJSON)
+	}
+
+	if len(s.data) == 0 {
+		return fmt.Errorf("a secret requires at least one key")
+	}
+
+	return nil
+}
+
+// Generate produces the Secret.
+func (s *Secret) Generate() (*yaml.Node, error) {
+	if e := s.Validate(); e != nil {
+		return nil, e
+	}
+
+	kind := s.Type
+	if kind == "" {
+		kind = Opaque
+	}
+
+	keys := make([]string, 0, len(s.data))
+	for name := range s.data {
+		keys = append(keys, name)
+	}
+
+	sort.Strings(keys)
+
+	data := manifests.Mapping()
+	for _, name := range keys {
+		value := s.data[name]
+
+		if !(s.Plain) {
+			manifests.Set(data, name, manifests.String(base64.StdEncoding.EncodeToString(value)))
+			continue
+		}
+
+		if !(utf8.Valid(value)) {
+			return nil, fmt.Errorf("the value of %s isn't valid utf-8 and can't be written as plain stringData", name)
+		}
+
+		node := manifests.String(string(value))
+		if strings.Contains(node.Value, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+
+		manifests.Set(data, name, node)
+	}
+
+	field := "data"
+	if s.Plain {
+		field = "stringData"
+	}
+
+	return manifests.Resource("v1", "Secret", manifests.Metadata(s.Name, s.Namespace, s.Labels, nil), "type", kind, field, data), nil
+}
+
+// subdomain reports whether name is a valid RFC 1123 subdomain.
+func subdomain(name string) bool {
+	for _, label := range strings.Split(name, ".") {
+		if !(dns1123.MatchString(label)) {
+			return false
+		}
+	}
+
+	return name != ""
+}
@@ -0,0 +1,149 @@
+package generate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pair returns a self-signed, PEM-encoded certificate and its private key.
+func pair(t *testing.T) (certificate, private []byte) {
+	t.Helper()
+
+	key, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		t.Fatalf("unable to generate key: %v", e)
+	}
+
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+
+	der, e := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if e != nil {
+		t.Fatalf("unable to create certificate: %v", e)
+	}
+
+	encoded, e := x509.MarshalECPrivateKey(key)
+	if e != nil {
+		t.Fatalf("unable to marshal key: %v", e)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: encoded})
+}
+
+func TestSecretAdd(t *testing.T) {
+	secret := &Secret{Name: "example"}
+
+	if e := secret.Literal("username=admin"); e != nil {
+		t.Fatalf("Literal() returned an unexpected error: %v", e)
+	}
+
+	for _, pair := range []string{"username=other", "invalid key=value", "missing-assignment"} {
+		if e := secret.Literal(pair); e == nil {
+			t.Errorf("Literal(%q) expected an error", pair)
+		}
+	}
+
+	directory := t.TempDir()
+	for name, content := range map[string]string{"config.json": "{}", "nested/ignored.txt": "", ".env": "PASSWORD='p@ss word'\n"} {
+		path := filepath.Join(directory, "files", name)
+		if e := os.MkdirAll(filepath.Dir(path), 0o755); e != nil {
+			t.Fatalf("unable to create directory: %v", e)
+		}
+
+		if e := os.WriteFile(path, []byte(content), 0o600); e != nil {
+			t.Fatalf("unable to write file: %v", e)
+		}
+	}
+
+	if e := secret.File(filepath.Join(directory, "files")); e != nil {
+		t.Fatalf("File() returned an unexpected error: %v", e)
+	}
+
+	if e := secret.File("renamed=" + filepath.Join(directory, "files", "config.json")); e != nil {
+		t.Fatalf("File() returned an unexpected error: %v", e)
+	}
+
+	if e := secret.File("key=" + directory); e == nil {
+		t.Errorf("File() of a keyed directory expected an error")
+	}
+
+	if e := secret.EnvFile(filepath.Join(directory, "files", ".env")); e != nil {
+		t.Fatalf("EnvFile() returned an unexpected error: %v", e)
+	}
+
+	secret.Plain = true
+
+	node, e := secret.Generate()
+	if e != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", e)
+	}
+
+	expected := `---
+apiVersion: v1
+kind: Secret
+metadata:
+    name: example
+type: Opaque
+stringData:
+    .env: |
+        PASSWORD='p@ss word'
+    PASSWORD: p@ss word
+    config.json: "{}"
+    renamed: "{}"
+    username: admin
+`
+	if output := render(t, node); output != expected {
+		t.Errorf("Generate() =\n%s\nexpected\n%s", output, expected)
+	}
+}
+
+func TestSecretGenerate(t *testing.T) {
+	certificate, private := pair(t)
+
+	_, mismatched := pair(t)
+
+	tests := []struct {
+		name     string
+		secret   Secret
+		data     map[string]string
+		expected string // expected is a substring of the generated manifest
+		valid    bool
+	}{
+		{name: "opaque", secret: Secret{Name: "example", Namespace: "backend"}, data: map[string]string{"token": "value"}, expected: "type: Opaque\ndata:\n    token: dmFsdWU=\n", valid: true},
+		{name: "tls", secret: Secret{Name: "example", Type: TLS}, data: map[string]string{"tls.crt": string(certificate), "tls.key": string(private)}, expected: "type: kubernetes.io/tls\n", valid: true},
+		{name: "tls-mismatched", secret: Secret{Name: "example", Type: TLS}, data: map[string]string{"tls.crt": string(certificate), "tls.key": string(mismatched)}},
+		{name: "tls-incomplete", secret: Secret{Name: "example", Type: TLS}, data: map[string]string{"tls.crt": string(certificate)}},
+		{name: "invalid-name", secret: Secret{Name: "Example"}, data: map[string]string{"token": "value"}},
+		{name: "unsupported-type", secret: Secret{Name: "example", Type: "custom"}, data: map[string]string{"token": "value"}},
+		{name: "empty", secret: Secret{Name: "example"}},
+		{name: "plain-binary", secret: Secret{Name: "example", Plain: true}, data: map[string]string{"binary": "\xff\xfe"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secret := test.secret
+			for name, value := range test.data {
+				if e := secret.Add(name, []byte(value)); e != nil {
+					t.Fatalf("Add() returned an unexpected error: %v", e)
+				}
+			}
+
+			node, e := secret.Generate()
+			if (e == nil) != test.valid {
+				t.Fatalf("Generate() = %v, expected valid: %t", e, test.valid)
+			}
+
+			if test.valid && !(strings.Contains(render(t, node), test.expected)) {
+				t.Errorf("Generate() =\n%s\nexpected to contain\n%s", render(t, node), test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// dns1123 matches a valid kubernetes resource name (RFC 1123 label).
+var dns1123 = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Service represents the specification of a service's Service, ServiceAccount, and Deployment.
+//
+//	name: example
+//	image: service:latest
+//	replicas: 2
+//	ports:
+//	    -   name: http
+//	        port: 8080
+//	probes:
+//	    liveness: { path: /health, port: http }
+//	    readiness: { path: /health, port: http }
+//	env:
+//	    CI: "true"
+//	downward:
+//	    LOCAL_POD_IP: status.podIP
+//	resources:
+//	    requests: { cpu: 100m, memory: 128Mi }
+//	istio:
+//	    inject: true
+type Service struct {
+	Name            string            `json:"name" yaml:"name"`
+	Namespace       string            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Version         string            `json:"version,omitempty" yaml:"version,omitempty"` // Version is the "version" label; defaults to "v1".
+	Image           string            `json:"image" yaml:"image"`
+	ImagePullPolicy string            `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	Replicas        *int              `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"` // Labels are added to every generated resource.
+	Ports           []Port            `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Probes          Probes            `json:"probes,omitempty" yaml:"probes,omitempty"`
+	Env             map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	Downward        map[string]string `json:"downward,omitempty" yaml:"downward,omitempty"` // Downward maps environment variable names to downward-API field paths.
+	Resources       *Resources        `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Istio           Istio             `json:"istio,omitempty" yaml:"istio,omitempty"`
+	ServiceAccount  *bool             `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"` // ServiceAccount toggles the ServiceAccount; defaults to true.
+}
+
+// Port represents a container port exposed by the Service.
+type Port struct {
+	Name          string `json:"name" yaml:"name"`
+	Port          int    `json:"port" yaml:"port"`
+	ContainerPort int    `json:"containerPort,omitempty" yaml:"containerPort,omitempty"` // ContainerPort defaults to Port.
+	Protocol      string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+}
+
+// Probes represents the container's probes.
+type Probes struct {
+	Liveness  *Probe `json:"liveness,omitempty" yaml:"liveness,omitempty"`
+	Readiness *Probe `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+	Startup   *Probe `json:"startup,omitempty" yaml:"startup,omitempty"`
+}
+
+// Probe represents an http-get, tcp-socket, or exec probe. An http-get probe is generated when Path is set, an exec
+// probe when Command is set, and otherwise a tcp-socket probe.
+type Probe struct {
+	Path                string   `json:"path,omitempty" yaml:"path,omitempty"`
+	Port                string   `json:"port,omitempty" yaml:"port,omitempty"` // Port is a port number or name; defaults to the first port.
+	Command             []string `json:"command,omitempty" yaml:"command,omitempty"`
+	InitialDelaySeconds *int     `json:"initialDelaySeconds,omitempty" yaml:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       *int     `json:"periodSeconds,omitempty" yaml:"periodSeconds,omitempty"`
+	TimeoutSeconds      *int     `json:"timeoutSeconds,omitempty" yaml:"timeoutSeconds,omitempty"`
+	FailureThreshold    *int     `json:"failureThreshold,omitempty" yaml:"failureThreshold,omitempty"`
+}
+
+// Resources represents the container's resource requests and limits.
+type Resources struct {
+	Requests map[string]string `json:"requests,omitempty" yaml:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty" yaml:"limits,omitempty"`
+}
+
+// Istio represents the service's istio configuration.
+type Istio struct {
+	Inject *bool `json:"inject,omitempty" yaml:"inject,omitempty"` // Inject sets the pod's "sidecar.istio.io/inject" label.
+}
+
+// Validate verifies the specification's required field(s) and applies default(s).
+func (s *Service) Validate() error {
+	if s.Name == "" {
+		return errors.New("service specification is missing \"name\"")
+	} else if !(dns1123.MatchString(s.Name)) || len(s.Name) > 63 {
+		return fmt.Errorf("invalid service name - must be a lowercase RFC 1123 label: %s", s.Name)
+	}
+
+	if s.Image == "" {
+		return errors.New("service specification is missing \"image\"")
+	}
+
+	if s.Version == "" {
+		s.Version = "v1"
+	}
+
+	names := make(map[string]bool, len(s.Ports))
+	for index := range s.Ports {
+		port := &s.Ports[index]
+		if port.Port <= 0 || port.Port > 65535 {
+			return fmt.Errorf("invalid port (%s): %d", port.Name, port.Port)
+		}
+
+		if port.Name == "" {
+			return fmt.Errorf("port %d is missing \"name\"", port.Port)
+		} else if names[port.Name] {
+			return fmt.Errorf("duplicate port name: %s", port.Name)
+		}
+
+		names[port.Name] = true
+
+		if port.ContainerPort == 0 {
+			port.ContainerPort = port.Port
+		}
+	}
+
+	for _, probe := range []*Probe{s.Probes.Liveness, s.Probes.Readiness, s.Probes.Startup} {
+		if probe != nil && probe.Port == "" && len(probe.Command) == 0 {
+			if len(s.Ports) == 0 {
+				return errors.New("probe requires a \"port\" when no ports are declared")
+			}
+
+			probe.Port = s.Ports[0].Name
+		}
+	}
+
+	return nil
+}
+
+// Selector returns the labels that identify the service's pods.
+func (s *Service) Selector() map[string]string {
+	return map[string]string{"app": s.Name}
+}
+
+// labels merges the specification's common labels with the given label(s).
+func (s *Service) labels(labels map[string]string) map[string]string {
+	merged := make(map[string]string, len(s.Labels)+len(labels))
+	for key, value := range s.Labels {
+		merged[key] = value
+	}
+
+	for key, value := range labels {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// Generate produces the service's Service, ServiceAccount (unless disabled), and Deployment document(s).
+func (s *Service) Generate() ([]*yaml.Node, error) {
+	if e := s.Validate(); e != nil {
+		return nil, e
+	}
+
+	var documents []*yaml.Node
+
+	{
+		var ports []*yaml.Node
+		for _, port := range s.Ports {
+			ports = append(ports, manifests.Object("name", port.Name, "port", port.Port, "targetPort", port.ContainerPort, "protocol", port.Protocol))
+		}
+
+		documents = append(documents, manifests.Resource("v1", "Service",
+			manifests.Metadata(s.Name, s.Namespace, s.labels(map[string]string{"app": s.Name, "service": s.Name}), nil),
+			"spec", manifests.Object("selector", s.Selector(), "ports", ports),
+		))
+	}
+
+	account := s.ServiceAccount == nil || *s.ServiceAccount
+	if account {
+		documents = append(documents, manifests.Resource("v1", "ServiceAccount",
+			manifests.Metadata(s.Name, s.Namespace, s.labels(map[string]string{"account": s.Name}), nil),
+		))
+	}
+
+	{
+		labels := s.labels(map[string]string{"app": s.Name, "version": s.Version, "service": s.Name})
+
+		template := s.labels(map[string]string{"app": s.Name, "version": s.Version, "service": s.Name})
+		if s.Istio.Inject != nil {
+			template["sidecar.istio.io/inject"] = strconv.FormatBool(*s.Istio.Inject)
+		}
+
+		var ports []*yaml.Node
+		for _, port := range s.Ports {
+			ports = append(ports, manifests.Object("name", port.Name, "containerPort", port.ContainerPort, "protocol", port.Protocol))
+		}
+
+		var resources *yaml.Node
+		if s.Resources != nil {
+			resources = manifests.Object("requests", s.Resources.Requests, "limits", s.Resources.Limits)
+		}
+
+		var name string
+		if account {
+			name = s.Name
+		}
+
+		container := manifests.Object(
+			"name", s.Name,
+			"image", s.Image,
+			"imagePullPolicy", s.ImagePullPolicy,
+			"ports", ports,
+			"livenessProbe", s.Probes.Liveness.node(),
+			"readinessProbe", s.Probes.Readiness.node(),
+			"startupProbe", s.Probes.Startup.node(),
+			"env", s.environment(),
+			"resources", resources,
+		)
+
+		documents = append(documents, manifests.Resource("apps/v1", "Deployment",
+			manifests.Metadata(s.Name, s.Namespace, labels, nil),
+			"spec", manifests.Object(
+				"replicas", s.Replicas,
+				"selector", manifests.Object("matchLabels", map[string]string{"app": s.Name, "version": s.Version, "service": s.Name}),
+				"template", manifests.Object(
+					"metadata", manifests.Object("labels", template),
+					"spec", manifests.Object(
+						"serviceAccountName", name,
+						"containers", manifests.List(container),
+					),
+				),
+			),
+		))
+	}
+
+	return documents, nil
+}
+
+// environment returns the container's environment variable(s) - plain values followed by downward-API field(s),
+// each sorted by name.
+func (s *Service) environment() []*yaml.Node {
+	var variables []*yaml.Node
+
+	for _, name := range sorted(s.Env) {
+		variables = append(variables, manifests.Object("name", name, "value", manifests.String(s.Env[name])))
+	}
+
+	for _, name := range sorted(s.Downward) {
+		variables = append(variables, manifests.Object("name", name, "valueFrom", manifests.Object(
+			"fieldRef", manifests.Object("fieldPath", s.Downward[name]),
+		)))
+	}
+
+	return variables
+}
+
+// node converts the probe into its kubernetes representation.
+func (p *Probe) node() *yaml.Node {
+	if p == nil {
+		return nil
+	}
+
+	var port interface{} = p.Port
+	if number, e := strconv.Atoi(p.Port); e == nil {
+		port = number
+	}
+
+	var handler []interface{}
+	switch {
+	case len(p.Command) > 0:
+		handler = []interface{}{"exec", manifests.Object("command", p.Command)}
+	case p.Path != "":
+		handler = []interface{}{"httpGet", manifests.Object("path", p.Path, "port", port)}
+	default:
+		handler = []interface{}{"tcpSocket", manifests.Object("port", port)}
+	}
+
+	return manifests.Object(append(handler,
+		"initialDelaySeconds", p.InitialDelaySeconds,
+		"periodSeconds", p.PeriodSeconds,
+		"timeoutSeconds", p.TimeoutSeconds,
+		"failureThreshold", p.FailureThreshold,
+	)...)
+}
+
+// sorted returns a map's keys in lexical order.
+func sorted(mapping map[string]string) []string {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
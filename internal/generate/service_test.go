@@ -0,0 +1,175 @@
+package generate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// render encodes generated nodes as a yaml stream.
+func render(t *testing.T, nodes ...*yaml.Node) string {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	if e := manifests.Write(&buffer, nodes...); e != nil {
+		t.Fatalf("unable to write manifests: %v", e)
+	}
+
+	return buffer.String()
+}
+
+func TestServiceValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		service Service
+		valid   bool
+	}{
+		{name: "minimal", service: Service{Name: "example", Image: "example:1.0.0"}, valid: true},
+		{name: "missing-name", service: Service{Image: "example:1.0.0"}},
+		{name: "invalid-name", service: Service{Name: "Example", Image: "example:1.0.0"}},
+		{name: "missing-image", service: Service{Name: "example"}},
+		{name: "invalid-port", service: Service{Name: "example", Image: "example:1.0.0", Ports: []Port{{Name: "http", Port: 70000}}}},
+		{name: "unnamed-port", service: Service{Name: "example", Image: "example:1.0.0", Ports: []Port{{Port: 8080}}}},
+		{name: "duplicate-port", service: Service{Name: "example", Image: "example:1.0.0", Ports: []Port{{Name: "http", Port: 8080}, {Name: "http", Port: 8081}}}},
+		{name: "probe-without-port", service: Service{Name: "example", Image: "example:1.0.0", Probes: Probes{Liveness: &Probe{Path: "/health"}}}},
+		{name: "exec-probe-without-port", service: Service{Name: "example", Image: "example:1.0.0", Probes: Probes{Liveness: &Probe{Command: []string{"true"}}}}, valid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if e := test.service.Validate(); (e == nil) != test.valid {
+				t.Errorf("Validate() = %v, expected valid: %t", e, test.valid)
+			}
+		})
+	}
+}
+
+func TestServiceGenerate(t *testing.T) {
+	replicas, inject := 2, true
+
+	service := &Service{
+		Name:     "example",
+		Image:    "example:1.0.0",
+		Replicas: &replicas,
+		Ports:    []Port{{Name: "http", Port: 8080}},
+		Probes:   Probes{Liveness: &Probe{}, Readiness: &Probe{Path: "/health"}},
+		Env:      map[string]string{"CI": "true"},
+		Downward: map[string]string{"POD_IP": "status.podIP"},
+		Istio:    Istio{Inject: &inject},
+	}
+
+	nodes, e := service.Generate()
+	if e != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", e)
+	}
+
+	expected := `---
+apiVersion: v1
+kind: Service
+metadata:
+    name: example
+    labels:
+        app: example
+        service: example
+spec:
+    selector:
+        app: example
+    ports:
+        - name: http
+          port: 8080
+          targetPort: 8080
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+    name: example
+    labels:
+        account: example
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+    name: example
+    labels:
+        app: example
+        service: example
+        version: v1
+spec:
+    replicas: 2
+    selector:
+        matchLabels:
+            app: example
+            service: example
+            version: v1
+    template:
+        metadata:
+            labels:
+                app: example
+                service: example
+                sidecar.istio.io/inject: "true"
+                version: v1
+        spec:
+            serviceAccountName: example
+            containers:
+                - name: example
+                  image: example:1.0.0
+                  ports:
+                    - name: http
+                      containerPort: 8080
+                  livenessProbe:
+                    tcpSocket:
+                        port: http
+                  readinessProbe:
+                    httpGet:
+                        path: /health
+                        port: http
+                  env:
+                    - name: CI
+                      value: "true"
+                    - name: POD_IP
+                      valueFrom:
+                        fieldRef:
+                            fieldPath: status.podIP
+`
+
+	if output := render(t, nodes...); output != expected {
+		t.Errorf("Generate() =\n%s\nexpected:\n%s", output, expected)
+	}
+
+	disabled := false
+
+	service.ServiceAccount = &disabled
+
+	nodes, e = service.Generate()
+	if e != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", e)
+	}
+
+	if len(nodes) != 2 || manifests.Scalar(nodes[1], "spec", "template", "spec", "serviceAccountName") != "" {
+		t.Errorf("Generate() without a ServiceAccount expected a Service and Deployment without \"serviceAccountName\"")
+	}
+}
+
+func TestServiceEnvironment(t *testing.T) {
+	var service Service
+	if e := yaml.Unmarshal([]byte("name: example\nimage: example:1.0.0\nenv: { FLAG: \"yes\", MODE: \"0755\", SWITCH: \"on\" }\n"), &service); e != nil {
+		t.Fatalf("unable to decode service: %v", e)
+	}
+
+	nodes, e := service.Generate()
+	if e != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", e)
+	}
+
+	// --> kubectl's YAML 1.1 parser would otherwise read the values as a boolean, and an octal integer
+	content := render(t, nodes...)
+	for _, expected := range []string{`value: "yes"`, `value: "0755"`, `value: "on"`} {
+		if !(strings.Contains(content, expected)) {
+			t.Errorf("Generate() expected to contain %s:\n%s", expected, content)
+		}
+	}
+}
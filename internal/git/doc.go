@@ -0,0 +1,2 @@
+// Package git provides a thin wrapper around the git executable for commands that commit and push the file(s) they modify.
+package git
@@ -0,0 +1,249 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+// ErrNotRepository is returned when a path isn't within a git working tree.
+var ErrNotRepository = errors.New("not a git repository")
+
+// Repository represents a git working tree, addressed by its top-level directory.
+type Repository struct {
+	Root string // Root is the absolute path to the working tree's top-level directory.
+}
+
+// Open resolves the git working tree that contains path. path may be either a directory or a file - which needn't
+// exist yet.
+func Open(ctx context.Context, path string) (*Repository, error) {
+	directory := filepath.Dir(path)
+
+	information, e := os.Stat(path)
+	switch {
+	case e == nil && information.IsDir():
+		directory = path
+	case e != nil && !(errors.Is(e, os.ErrNotExist)):
+		e = fmt.Errorf("unable to inspect %s: %w", path, e)
+		return nil, e
+	case e != nil:
+		// --> a missing file is fine, but git would otherwise fail opaquely in a missing directory
+		if _, e := os.Stat(directory); errors.Is(e, os.ErrNotExist) {
+			e = fmt.Errorf("directory does not exist: %s", directory)
+			return nil, e
+		}
+	}
+
+	output, e := run(ctx, directory, "rev-parse", "--show-toplevel")
+	if e != nil {
+		// --> distinguish a path outside any repository from git, or the directory, being unavailable
+		if strings.Contains(e.Error(), "not a git repository") {
+			e = fmt.Errorf("%w: %s", ErrNotRepository, path)
+			return nil, e
+		}
+
+		e = fmt.Errorf("unable to locate git repository for %s: %w", path, e)
+		return nil, e
+	}
+
+	root, e := filepath.EvalSymlinks(strings.TrimSpace(output))
+	if e != nil {
+		e = fmt.Errorf("unable to resolve repository root: %w", e)
+		return nil, e
+	}
+
+	return &Repository{Root: root}, nil
+}
+
+// Relative converts path into a slash-separated path relative to the repository's root.
+func (r *Repository) Relative(path string) (string, error) {
+	absolute, e := filepath.Abs(path)
+	if e != nil {
+		return "", e
+	}
+
+	// --> the file may not exist yet; resolve symbolic links against its directory
+	directory, e := filepath.EvalSymlinks(filepath.Dir(absolute))
+	if e != nil {
+		return "", e
+	}
+
+	relative, e := filepath.Rel(r.Root, filepath.Join(directory, filepath.Base(absolute)))
+	if e != nil {
+		return "", e
+	}
+
+	if relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path is outside of the repository (%s): %s", r.Root, path)
+	}
+
+	return filepath.ToSlash(relative), nil
+}
+
+// Changes returns the repository-relative path(s) of all modified, staged, and untracked file(s).
+func (r *Repository) Changes(ctx context.Context) ([]string, error) {
+	output, e := run(ctx, r.Root, "status", "--porcelain=v1", "-z", "--untracked-files=all")
+	if e != nil {
+		return nil, e
+	}
+
+	var changes []string
+
+	entries := strings.Split(output, "\x00")
+	for index := 0; index < len(entries); index++ {
+		entry := entries[index]
+		if len(entry) < 4 {
+			continue
+		}
+
+		changes = append(changes, entry[3:])
+
+		// --> renames and copies are followed by their original path
+		if entry[0] == 'R' || entry[0] == 'C' {
+			index++
+			if index < len(entries) {
+				changes = append(changes, entries[index])
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// Unrelated returns the repository's change(s) that aren't included in paths (repository-relative).
+func (r *Repository) Unrelated(ctx context.Context, paths ...string) ([]string, error) {
+	changes, e := r.Changes(ctx)
+	if e != nil {
+		return nil, e
+	}
+
+	allowed := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		allowed[path] = true
+	}
+
+	var unrelated []string
+	for _, change := range changes {
+		if !(allowed[change]) {
+			unrelated = append(unrelated, change)
+		}
+	}
+
+	return unrelated, nil
+}
+
+// Tracked reports whether path is tracked by the repository.
+func (r *Repository) Tracked(ctx context.Context, path string) (bool, error) {
+	relative, e := r.Relative(path)
+	if e != nil {
+		return false, e
+	}
+
+	output, e := run(ctx, r.Root, "ls-files", "--full-name", "--", relative)
+	if e != nil {
+		return false, e
+	}
+
+	return strings.TrimSpace(output) != "", nil
+}
+
+// Ignored reports whether path - which needn't exist - is excluded by the repository's ignore rules.
+func (r *Repository) Ignored(ctx context.Context, path string) (bool, error) {
+	relative, e := r.Relative(path)
+	if e != nil {
+		return false, e
+	}
+
+	// --> "check-ignore" exits with a status of 1 when the path isn't ignored, which run reports as an error
+	command := exec.CommandContext(ctx, "git", "-C", r.Root, "check-ignore", "--quiet", "--", relative)
+
+	slog.Log(ctx, log.Trace, "Git", slog.String("directory", r.Root), slog.Any("arguments", command.Args[3:]))
+
+	if e := command.Run(); e != nil {
+		var exit *exec.ExitError
+		if errors.As(e, &exit) && exit.ExitCode() == 1 {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("git check-ignore: %w", e)
+	}
+
+	return true, nil
+}
+
+// Branch returns the name of the current branch.
+func (r *Repository) Branch(ctx context.Context) (string, error) {
+	output, e := run(ctx, r.Root, "rev-parse", "--abbrev-ref", "HEAD")
+	if e != nil {
+		return "", e
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// Checkout creates and switches to a new branch.
+func (r *Repository) Checkout(ctx context.Context, branch string) error {
+	_, e := run(ctx, r.Root, "checkout", "-b", branch)
+
+	return e
+}
+
+// Add stages the given repository-relative path(s).
+func (r *Repository) Add(ctx context.Context, paths ...string) error {
+	_, e := run(ctx, r.Root, append([]string{"add", "--"}, paths...)...)
+
+	return e
+}
+
+// Commit records only the given repository-relative path(s) with message, and returns the resulting commit's hash.
+func (r *Repository) Commit(ctx context.Context, message string, paths ...string) (string, error) {
+	if _, e := run(ctx, r.Root, append([]string{"commit", "--message", message, "--"}, paths...)...); e != nil {
+		return "", e
+	}
+
+	output, e := run(ctx, r.Root, "rev-parse", "HEAD")
+	if e != nil {
+		return "", e
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// Push publishes branch to remote, and configures the upstream tracking reference.
+func (r *Repository) Push(ctx context.Context, remote, branch string) error {
+	_, e := run(ctx, r.Root, "push", "--set-upstream", remote, branch)
+
+	return e
+}
+
+// run executes git in directory and returns its standard-output. Standard-error is included in any returned error.
+func run(ctx context.Context, directory string, arguments ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, "git", append([]string{"-C", directory}, arguments...)...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	slog.Log(ctx, log.Trace, "Git", slog.String("directory", directory), slog.Any("arguments", arguments))
+
+	if e := command.Run(); e != nil {
+		var exit *exec.ExitError
+		if errors.As(e, &exit) {
+			e = fmt.Errorf("git %s: %s", arguments[0], strings.TrimSpace(stderr.String()))
+		} else {
+			e = fmt.Errorf("unable to execute git: %w", e)
+		}
+
+		return "", e
+	}
+
+	return stdout.String(), nil
+}
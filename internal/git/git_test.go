@@ -0,0 +1,197 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fixture creates a bare remote repository, and a clone of it with a single committed file ("kustomization.yaml"),
+// returning the clone's and remote's paths.
+func fixture(t *testing.T) (clone, remote string) {
+	t.Helper()
+
+	if _, e := exec.LookPath("git"); e != nil {
+		t.Skip("git isn't available")
+	}
+
+	// --> isolate the tests from the user's, and system's, git configuration
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	directory := t.TempDir()
+
+	remote, clone = filepath.Join(directory, "remote.git"), filepath.Join(directory, "clone")
+
+	execute(t, directory, "init", "--quiet", "--bare", "--initial-branch", "main", remote)
+	execute(t, directory, "clone", "--quiet", remote, clone)
+	execute(t, clone, "checkout", "--quiet", "-B", "main")
+
+	write(t, filepath.Join(clone, "kustomization.yaml"), "images: []\n")
+
+	execute(t, clone, "add", "kustomization.yaml")
+	execute(t, clone, "commit", "--quiet", "--message", "Initial Commit")
+	execute(t, clone, "push", "--quiet", "origin", "main")
+
+	return clone, remote
+}
+
+// execute runs git in directory, failing the test on error, and returns its standard-output.
+func execute(t *testing.T, directory string, arguments ...string) string {
+	t.Helper()
+
+	output, e := run(context.Background(), directory, arguments...)
+	if e != nil {
+		t.Fatalf("unable to execute git %s: %v", strings.Join(arguments, " "), e)
+	}
+
+	return strings.TrimSpace(output)
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+
+	if e := os.WriteFile(path, []byte(content), 0o644); e != nil {
+		t.Fatalf("unable to write %s: %v", path, e)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	clone, _ := fixture(t)
+
+	ctx := context.Background()
+
+	// --> neither a directory's nor a file's name determines its type (e.g. "overlays.d", "Makefile")
+	if e := os.Mkdir(filepath.Join(clone, "overlays.d"), 0o755); e != nil {
+		t.Fatalf("unable to create directory: %v", e)
+	}
+
+	write(t, filepath.Join(clone, "Makefile"), "all:\n")
+
+	for _, path := range []string{clone, filepath.Join(clone, "kustomization.yaml"), filepath.Join(clone, "overlays.d"), filepath.Join(clone, "Makefile"), filepath.Join(clone, "new.yaml")} {
+		repository, e := Open(ctx, path)
+		if e != nil {
+			t.Fatalf("Open(%s) returned an unexpected error: %v", path, e)
+		}
+
+		expected, _ := filepath.EvalSymlinks(clone)
+		if repository.Root != expected {
+			t.Errorf("Open(%s).Root = %s, expected %s", path, repository.Root, expected)
+		}
+	}
+
+	if _, e := Open(ctx, t.TempDir()); !(errors.Is(e, ErrNotRepository)) {
+		t.Errorf("Open() of a directory outside of a repository = %v, expected %v", e, ErrNotRepository)
+	}
+}
+
+func TestRepositoryRelative(t *testing.T) {
+	clone, _ := fixture(t)
+
+	repository, e := Open(context.Background(), clone)
+	if e != nil {
+		t.Fatalf("unable to open repository: %v", e)
+	}
+
+	if e := os.Mkdir(filepath.Join(clone, "overlays"), 0o755); e != nil {
+		t.Fatalf("unable to create directory: %v", e)
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+		valid    bool
+	}{
+		{path: filepath.Join(clone, "kustomization.yaml"), expected: "kustomization.yaml", valid: true},
+		{path: filepath.Join(clone, "overlays", "missing.yaml"), expected: "overlays/missing.yaml", valid: true},
+		{path: filepath.Join(clone, "..", "outside.yaml"), valid: false},
+	}
+
+	for _, test := range tests {
+		relative, e := repository.Relative(test.path)
+		if test.valid && (e != nil || relative != test.expected) {
+			t.Errorf("Relative(%s) = (%q, %v), expected %q", test.path, relative, e, test.expected)
+		}
+
+		if !(test.valid) && e == nil {
+			t.Errorf("Relative(%s) = %q, expected an error", test.path, relative)
+		}
+	}
+}
+
+func TestRepositoryChanges(t *testing.T) {
+	clone, _ := fixture(t)
+
+	ctx := context.Background()
+
+	repository, e := Open(ctx, clone)
+	if e != nil {
+		t.Fatalf("unable to open repository: %v", e)
+	}
+
+	changes, e := repository.Changes(ctx)
+	if e != nil || len(changes) != 0 {
+		t.Fatalf("Changes() of a clean working tree = (%v, %v), expected none", changes, e)
+	}
+
+	write(t, filepath.Join(clone, "kustomization.yaml"), "images: [ { name: example } ]\n")
+	write(t, filepath.Join(clone, "untracked.yaml"), "{}\n")
+
+	changes, e = repository.Changes(ctx)
+	if e != nil {
+		t.Fatalf("Changes() returned an unexpected error: %v", e)
+	}
+
+	slices.Sort(changes)
+	if expected := []string{"kustomization.yaml", "untracked.yaml"}; !(slices.Equal(changes, expected)) {
+		t.Errorf("Changes() = %v, expected %v", changes, expected)
+	}
+
+	unrelated, e := repository.Unrelated(ctx, "kustomization.yaml")
+	if e != nil || !(slices.Equal(unrelated, []string{"untracked.yaml"})) {
+		t.Errorf("Unrelated() = (%v, %v), expected [untracked.yaml]", unrelated, e)
+	}
+}
+
+func TestRepositoryTrackedIgnored(t *testing.T) {
+	clone, _ := fixture(t)
+
+	ctx := context.Background()
+
+	write(t, filepath.Join(clone, ".gitignore"), "*.env\n")
+
+	repository, e := Open(ctx, clone)
+	if e != nil {
+		t.Fatalf("unable to open repository: %v", e)
+	}
+
+	tests := []struct {
+		path             string
+		tracked, ignored bool
+	}{
+		{path: "kustomization.yaml", tracked: true},
+		{path: "secret.env", ignored: true},
+		{path: "secret.yaml"},
+	}
+
+	for _, test := range tests {
+		path := filepath.Join(clone, test.path)
+
+		if tracked, e := repository.Tracked(ctx, path); e != nil || tracked != test.tracked {
+			t.Errorf("Tracked(%s) = (%t, %v), expected %t", test.path, tracked, e, test.tracked)
+		}
+
+		if ignored, e := repository.Ignored(ctx, path); e != nil || ignored != test.ignored {
+			t.Errorf("Ignored(%s) = (%t, %v), expected %t", test.path, ignored, e, test.ignored)
+		}
+	}
+}
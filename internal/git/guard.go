@@ -0,0 +1,47 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+// Guard verifies that sensitive content (e.g. a Secret) may be written to path. Guard errors if path is tracked by a
+// git repository, unless force is true, and reports whether path is within a repository without being ignored - and
+// so at risk of being committed. Paths outside a repository are always permitted; any other failure to resolve the
+// repository (e.g. git being unavailable) is returned rather than risk writing to a tracked file.
+func Guard(ctx context.Context, path string, force bool) (exposed bool, e error) {
+	repository, e := Open(ctx, path)
+	if e != nil {
+		if errors.Is(e, ErrNotRepository) {
+			slog.Log(ctx, log.Debug, "Path Isn't Within a Git Repository", slog.String("path", path))
+
+			return false, nil
+		}
+
+		return false, e
+	}
+
+	tracked, e := repository.Tracked(ctx, path)
+	if e != nil {
+		return false, e
+	}
+
+	if tracked {
+		if !(force) {
+			return false, fmt.Errorf("refusing to write sensitive content to a file tracked by git (%s) - use --force to overwrite it", path)
+		}
+
+		return false, nil
+	}
+
+	ignored, e := repository.Ignored(ctx, path)
+	if e != nil {
+		return false, e
+	}
+
+	return !(ignored), nil
+}
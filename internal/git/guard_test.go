@@ -0,0 +1,59 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGuard(t *testing.T) {
+	clone, _ := fixture(t)
+
+	write(t, filepath.Join(clone, ".gitignore"), "*.env\n")
+
+	tests := []struct {
+		name    string
+		path    string
+		force   bool
+		exposed bool
+		valid   bool
+	}{
+		{name: "tracked", path: filepath.Join(clone, "kustomization.yaml")},
+		{name: "tracked-forced", path: filepath.Join(clone, "kustomization.yaml"), force: true, valid: true},
+		{name: "ignored", path: filepath.Join(clone, "secret.env"), valid: true},
+		{name: "untracked", path: filepath.Join(clone, "secret.yaml"), exposed: true, valid: true},
+		{name: "outside-repository", path: filepath.Join(t.TempDir(), "secret.yaml"), valid: true},
+		{name: "missing-directory", path: filepath.Join(clone, "missing", "secret.yaml")},
+	}
+
+	if _, e := Guard(context.Background(), filepath.Join(clone, "missing", "secret.yaml"), false); e == nil || !(strings.Contains(e.Error(), "directory does not exist")) {
+		t.Errorf("Guard() in a missing directory = %v, expected a \"directory does not exist\" error", e)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exposed, e := Guard(context.Background(), test.path, test.force)
+			if (e == nil) != test.valid {
+				t.Fatalf("Guard() = %v, expected valid: %t", e, test.valid)
+			}
+
+			if exposed != test.exposed {
+				t.Errorf("Guard() exposed = %t, expected %t", exposed, test.exposed)
+			}
+		})
+	}
+}
+
+func TestGuardUnavailable(t *testing.T) {
+	clone, _ := fixture(t)
+
+	// --> without git, a tracked file can't be ruled out - and the write mustn't be permitted
+	t.Setenv("PATH", t.TempDir())
+
+	_, e := Guard(context.Background(), filepath.Join(clone, "kustomization.yaml"), false)
+	if e == nil || errors.Is(e, ErrNotRepository) {
+		t.Errorf("Guard() without git = %v, expected an error", e)
+	}
+}
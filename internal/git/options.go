@@ -0,0 +1,215 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-ethr/ethr-cli/internal/log"
+)
+
+// Options represents the user-provided version-control flags shared by commands that mutate file(s).
+type Options struct {
+	Commit  bool   // Commit enables committing the modified file(s).
+	Branch  string // Branch is an optional, templated name of a new branch to commit to.
+	Message string // Message is an optional, templated commit message. Defaults to the [Change.Summary].
+	Remote  string // Remote is an optional remote to push the commit to.
+}
+
+// Change describes a command's modification(s), and is the data provided to the branch and message templates.
+//
+//   - Command: the name of the command that performed the change (e.g. "image").
+//   - Summary: a generated, single-line description of the change.
+//   - Files: the repository-relative path(s) of the modified file(s).
+//   - Values: command-specific values (e.g. "tag", "build").
+type Change struct {
+	Command string
+	Summary string
+	Files   []string
+	Values  map[string]string
+}
+
+// Register adds the version-control flags to cmd.
+func (o *Options) Register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	flags.BoolVar(&o.Commit, "commit", false, "commit the modified file(s) - refuses to run if the working tree has unrelated changes")
+	flags.StringVar(&o.Branch, "branch", "", "create and commit to a new branch - supports go templating (e.g. \"release/{{ .Values.tag }}\")")
+	flags.StringVar(&o.Message, "message", "", "the commit message - supports go templating (e.g. \"{{ .Summary }} [skip ci]\")")
+	flags.StringVar(&o.Remote, "push", "", "push the commit to the given remote (e.g. \"origin\")")
+
+	cmd.MarkFlagsMutuallyExclusive("commit", "dry-run")
+}
+
+// Validate ensures flags that depend on [Options.Commit] aren't used without it.
+func (o *Options) Validate() error {
+	if o.Commit {
+		return nil
+	}
+
+	var dependents []string
+	if o.Branch != "" {
+		dependents = append(dependents, "--branch")
+	}
+	if o.Message != "" {
+		dependents = append(dependents, "--message")
+	}
+	if o.Remote != "" {
+		dependents = append(dependents, "--push")
+	}
+
+	if len(dependents) > 0 {
+		return fmt.Errorf("%s requires --commit", strings.Join(dependents, ", "))
+	}
+
+	return nil
+}
+
+// Prepare resolves the repository containing paths, and verifies the working tree has no changes other than to paths.
+// A nil [Repository] is returned when [Options.Commit] isn't enabled.
+func (o *Options) Prepare(ctx context.Context, paths ...string) (*Repository, error) {
+	if e := o.Validate(); e != nil {
+		return nil, e
+	}
+
+	if !(o.Commit) || len(paths) == 0 {
+		return nil, nil
+	}
+
+	repository, e := Open(ctx, paths[0])
+	if e != nil {
+		return nil, e
+	}
+
+	relatives := make([]string, 0, len(paths))
+	for _, path := range paths {
+		relative, e := repository.Relative(path)
+		if e != nil {
+			return nil, e
+		}
+
+		relatives = append(relatives, relative)
+	}
+
+	unrelated, e := repository.Unrelated(ctx, relatives...)
+	if e != nil {
+		return nil, e
+	}
+
+	if len(unrelated) > 0 {
+		return nil, fmt.Errorf("working tree has unrelated changes - commit or stash them and try again: %s", strings.Join(unrelated, ", "))
+	}
+
+	return repository, nil
+}
+
+// Apply commits the change's file(s) to the repository and, if configured, creates a branch and pushes it.
+// The files in [Change.Files] may be absolute or relative to the current working directory; they're
+// replaced with their repository-relative equivalent(s) prior to template evaluation.
+func (o *Options) Apply(ctx context.Context, repository *Repository, change Change) error {
+	if repository == nil {
+		return nil
+	}
+
+	files := make([]string, 0, len(change.Files))
+	for _, file := range change.Files {
+		relative, e := repository.Relative(file)
+		if e != nil {
+			return e
+		}
+
+		files = append(files, relative)
+	}
+
+	change.Files = files
+
+	changes, e := repository.Changes(ctx)
+	if e != nil {
+		return e
+	}
+
+	var modified bool
+	for _, path := range changes {
+		for _, file := range files {
+			modified = modified || path == file
+		}
+	}
+
+	if !(modified) {
+		slog.Log(ctx, log.Info, "No Changes to Commit", slog.Any("files", files))
+
+		return nil
+	}
+
+	message := change.Summary
+	if o.Message != "" {
+		message, e = render("message", o.Message, change)
+		if e != nil {
+			return e
+		}
+	}
+
+	if strings.TrimSpace(message) == "" {
+		return errors.New("commit message cannot be empty")
+	}
+
+	branch, e := repository.Branch(ctx)
+	if e != nil {
+		return e
+	}
+
+	if o.Branch != "" {
+		branch, e = render("branch", o.Branch, change)
+		if e != nil {
+			return e
+		}
+
+		if e := repository.Checkout(ctx, branch); e != nil {
+			return e
+		}
+	}
+
+	if e := repository.Add(ctx, files...); e != nil {
+		return e
+	}
+
+	hash, e := repository.Commit(ctx, message, files...)
+	if e != nil {
+		return e
+	}
+
+	slog.Log(ctx, log.Info, "Commit", slog.String("hash", hash), slog.String("branch", branch), slog.Any("files", files))
+
+	if o.Remote != "" {
+		if e := repository.Push(ctx, o.Remote, branch); e != nil {
+			return e
+		}
+
+		slog.Log(ctx, log.Info, "Push", slog.String("remote", o.Remote), slog.String("branch", branch))
+	}
+
+	return nil
+}
+
+// render evaluates value as a go template against change.
+func render(name, value string, change Change) (string, error) {
+	t, e := template.New(name).Option("missingkey=error").Parse(value)
+	if e != nil {
+		e = fmt.Errorf("unable to parse %s template: %w", name, e)
+		return "", e
+	}
+
+	var buffer bytes.Buffer
+	if e := t.Execute(&buffer, change); e != nil {
+		e = fmt.Errorf("unable to render %s template: %w", name, e)
+		return "", e
+	}
+
+	return strings.TrimSpace(buffer.String()), nil
+}
@@ -0,0 +1,183 @@
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		valid   bool
+	}{
+		{name: "disabled", options: Options{}, valid: true},
+		{name: "commit", options: Options{Commit: true, Branch: "release", Message: "message", Remote: "origin"}, valid: true},
+		{name: "branch-without-commit", options: Options{Branch: "release"}, valid: false},
+		{name: "push-without-commit", options: Options{Remote: "origin"}, valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if e := test.options.Validate(); (e == nil) != test.valid {
+				t.Errorf("Validate() = %v, expected valid: %t", e, test.valid)
+			}
+		})
+	}
+}
+
+func TestOptionsPrepare(t *testing.T) {
+	clone, _ := fixture(t)
+
+	ctx := context.Background()
+
+	path := filepath.Join(clone, "kustomization.yaml")
+
+	if repository, e := (&Options{}).Prepare(ctx, path); e != nil || repository != nil {
+		t.Errorf("Prepare() without --commit = (%v, %v), expected no repository", repository, e)
+	}
+
+	options := &Options{Commit: true}
+
+	write(t, path, "images: [ { name: example } ]\n")
+
+	if repository, e := options.Prepare(ctx, path); e != nil || repository == nil {
+		t.Fatalf("Prepare() with only related changes = (%v, %v), expected a repository", repository, e)
+	}
+
+	write(t, filepath.Join(clone, "unrelated.yaml"), "{}\n")
+
+	if _, e := options.Prepare(ctx, path); e == nil || !(strings.Contains(e.Error(), "unrelated.yaml")) {
+		t.Errorf("Prepare() with unrelated changes = %v, expected an error listing unrelated.yaml", e)
+	}
+}
+
+func TestOptionsApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  Options
+		branch   string // branch is the expected branch of the commit
+		message  string // message is the expected commit message
+		upstream bool   // upstream reports whether the branch is expected on the remote
+	}{
+		{
+			name:    "commit",
+			options: Options{Commit: true},
+			branch:  "main",
+			message: "Update Image (service) to registry.io/example:1.0.0",
+		},
+		{
+			name:     "branch-and-push",
+			options:  Options{Commit: true, Branch: "release/{{ .Values.tag }}", Message: "{{ .Summary }} - {{ .Values.newName }} [skip ci]", Remote: "origin"},
+			branch:   "release/1.0.0",
+			message:  "Update Image (service) to registry.io/example:1.0.0 - registry.io/example [skip ci]",
+			upstream: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clone, remote := fixture(t)
+
+			ctx := context.Background()
+
+			path := filepath.Join(clone, "kustomization.yaml")
+
+			repository, e := test.options.Prepare(ctx, path)
+			if e != nil {
+				t.Fatalf("Prepare() returned an unexpected error: %v", e)
+			}
+
+			write(t, path, "images: [ { name: service, newName: registry.io/example, newTag: 1.0.0 } ]\n")
+
+			change := Change{
+				Command: "image",
+				Summary: "Update Image (service) to registry.io/example:1.0.0",
+				Files:   []string{path},
+				Values:  map[string]string{"tag": "1.0.0", "newName": "registry.io/example"},
+			}
+
+			if e := test.options.Apply(ctx, repository, change); e != nil {
+				t.Fatalf("Apply() returned an unexpected error: %v", e)
+			}
+
+			if branch := execute(t, clone, "rev-parse", "--abbrev-ref", "HEAD"); branch != test.branch {
+				t.Errorf("branch = %s, expected %s", branch, test.branch)
+			}
+
+			if message := execute(t, clone, "log", "-1", "--format=%s"); message != test.message {
+				t.Errorf("commit message = %q, expected %q", message, test.message)
+			}
+
+			if status := execute(t, clone, "status", "--porcelain"); status != "" {
+				t.Errorf("working tree = %q, expected it to be clean", status)
+			}
+
+			published := execute(t, remote, "branch", "--list", test.branch)
+			if test.upstream {
+				if published == "" {
+					t.Fatalf("branch %s wasn't pushed to the remote", test.branch)
+				}
+
+				if local, upstream := execute(t, clone, "rev-parse", "HEAD"), execute(t, remote, "rev-parse", test.branch); local != upstream {
+					t.Errorf("remote %s = %s, expected %s", test.branch, upstream, local)
+				}
+			} else if head := execute(t, remote, "rev-parse", "main"); head == execute(t, clone, "rev-parse", "HEAD") {
+				t.Errorf("the commit was pushed to the remote without --push")
+			}
+		})
+	}
+}
+
+func TestOptionsApplyUnchanged(t *testing.T) {
+	clone, _ := fixture(t)
+
+	ctx := context.Background()
+
+	path := filepath.Join(clone, "kustomization.yaml")
+
+	options := &Options{Commit: true}
+
+	repository, e := options.Prepare(ctx, path)
+	if e != nil {
+		t.Fatalf("Prepare() returned an unexpected error: %v", e)
+	}
+
+	before := execute(t, clone, "rev-parse", "HEAD")
+
+	if e := options.Apply(ctx, repository, Change{Command: "image", Summary: "Update", Files: []string{path}}); e != nil {
+		t.Fatalf("Apply() returned an unexpected error: %v", e)
+	}
+
+	if after := execute(t, clone, "rev-parse", "HEAD"); after != before {
+		t.Errorf("Apply() without changes created commit %s", after)
+	}
+}
+
+func TestRender(t *testing.T) {
+	change := Change{Command: "image", Summary: "Update Image", Values: map[string]string{"tag": "1.0.0", "newName": "registry.io/example"}}
+
+	tests := []struct {
+		template string
+		expected string
+		valid    bool
+	}{
+		{template: "release/{{ .Values.tag }}", expected: "release/1.0.0", valid: true},
+		{template: "{{ .Summary }} ({{ .Values.newName }})", expected: "Update Image (registry.io/example)", valid: true},
+		{template: "{{ .Values.missing }}", valid: false},
+		{template: "{{ .Values.tag", valid: false},
+	}
+
+	for _, test := range tests {
+		value, e := render("test", test.template, change)
+		if test.valid && (e != nil || value != test.expected) {
+			t.Errorf("render(%q) = (%q, %v), expected %q", test.template, value, e, test.expected)
+		}
+
+		if !(test.valid) && e == nil {
+			t.Errorf("render(%q) = %q, expected an error", test.template, value)
+		}
+	}
+}
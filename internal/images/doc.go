@@ -0,0 +1,2 @@
+// Package images collects the container images referenced by a manifest bundle.
+package images
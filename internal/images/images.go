@@ -0,0 +1,165 @@
+package images
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/kustomize/api/types"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Custom is the [Source.Type] of images found outside a workload's pod specification (e.g. a custom resource's).
+const Custom = "custom"
+
+// Source represents a single field that references an image.
+type Source struct {
+	Resource  string `json:"resource" yaml:"resource"`                       // Resource is the kubectl-style reference of the document (e.g. "deployment/example").
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"` // Namespace is the document's namespace, if declared.
+	Container string `json:"container,omitempty" yaml:"container,omitempty"` // Container is the container's name - empty for Custom sources.
+	Type      string `json:"type" yaml:"type"`                               // Type is the container's field ("containers", "initContainers", "ephemeralContainers"), or Custom.
+	Path      string `json:"path" yaml:"path"`                               // Path is the image field's location within the document.
+	File      string `json:"file" yaml:"file"`                               // File is the path the document was read from.
+	Document  int    `json:"document" yaml:"document"`                       // Document is the document's position within its file.
+}
+
+func (s Source) String() string {
+	if s.Container != "" {
+		return s.Resource + " (" + s.Type + "/" + s.Container + ")"
+	}
+
+	return s.Resource + " (" + s.Path + ")"
+}
+
+// Reference represents a unique image, and every field that references it.
+type Reference struct {
+	Image   string   `json:"image" yaml:"image"`
+	Name    string   `json:"name" yaml:"name"`
+	Tag     string   `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Digest  string   `json:"digest,omitempty" yaml:"digest,omitempty"`
+	Sources []Source `json:"sources" yaml:"sources"`
+}
+
+// ignored are kinds whose content is arbitrary data rather than a specification.
+var ignored = map[string]bool{
+	"ConfigMap":                true,
+	"Secret":                   true,
+	"CustomResourceDefinition": true,
+}
+
+// Collect returns every image referenced by the documents - after applying overrides - sorted by reference. Workloads'
+// images are taken from their pod specification (init-containers, containers, and ephemeral-containers). Any other
+// resource (e.g. a custom resource) is searched for "image" fields and fields suffixed "Image" (e.g. "baseImage"), either
+// a reference or a Helm-style "repository" & "tag" mapping - kustomize doesn't transform these, so overrides are the only
+// means of reconciling them with a kustomization's "images".
+func Collect(overrides []types.Image, documents ...*manifests.Document) []Reference {
+	index := make(map[string]*Reference)
+
+	add := func(document *manifests.Document, value string, source Source) {
+		value = strings.TrimSpace(value)
+		if value == "" || strings.ContainsAny(value, " \t\n$") {
+			return
+		}
+
+		image := Override(manifests.ParseImage(value), overrides)
+
+		source.Resource, source.Namespace = document.String(), document.Namespace()
+		source.File, source.Document = document.File, document.Index
+
+		reference, exists := index[image.String()]
+		if !(exists) {
+			reference = &Reference{Image: image.String(), Name: image.Name, Tag: image.Tag, Digest: image.Digest}
+			index[image.String()] = reference
+		}
+
+		for _, existing := range reference.Sources {
+			if existing == source {
+				return
+			}
+		}
+
+		reference.Sources = append(reference.Sources, source)
+	}
+
+	for _, document := range documents {
+		if document.Empty() || document.Kustomization() || ignored[document.Kind()] {
+			continue
+		}
+
+		if document.Workload() {
+			for _, container := range document.Containers() {
+				add(document, container.Image(), Source{Container: container.Name(), Type: container.Type, Path: container.Path.Key("image").String()})
+			}
+
+			continue
+		}
+
+		search(document.Root(), nil, func(value string, path manifests.Path) {
+			add(document, value, Source{Type: Custom, Path: path.String()})
+		})
+	}
+
+	references := make([]Reference, 0, len(index))
+	for _, reference := range index {
+		references = append(references, *reference)
+	}
+
+	sort.Slice(references, func(i, j int) bool {
+		return references[i].Image < references[j].Image
+	})
+
+	return references
+}
+
+// search walks node, calling found for every image field.
+func search(node *yaml.Node, path manifests.Path, found func(value string, path manifests.Path)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			key, value := node.Content[index].Value, node.Content[index+1]
+			if !(key == "image" || strings.HasSuffix(key, "Image")) {
+				search(value, path.Key(key), found)
+				continue
+			}
+
+			switch value.Kind {
+			case yaml.ScalarNode:
+				if value.Tag == "!!str" {
+					found(value.Value, path.Key(key))
+				}
+			case yaml.MappingNode:
+				if repository := manifests.Scalar(value, "repository"); repository != "" {
+					found(helm(value, repository), path.Key(key))
+					continue
+				}
+
+				search(value, path.Key(key), found)
+			default:
+				search(value, path.Key(key), found)
+			}
+		}
+	case yaml.SequenceNode:
+		for index, item := range node.Content {
+			search(item, path.Index(index), found)
+		}
+	}
+}
+
+// helm formats a Helm-style image mapping ("registry", "repository", "tag", and "digest") into a reference.
+func helm(node *yaml.Node, repository string) string {
+	reference := repository
+	if registry := manifests.Scalar(node, "registry"); registry != "" {
+		reference = registry + "/" + reference
+	}
+
+	if tag := manifests.Scalar(node, "tag"); tag != "" {
+		reference += ":" + tag
+	}
+
+	if digest := manifests.Scalar(node, "digest"); digest != "" {
+		reference += "@" + digest
+	}
+
+	return reference
+}
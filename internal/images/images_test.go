@@ -0,0 +1,102 @@
+package images
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kustomize/api/types"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// bundle is a set of resources that reference images from workloads, a custom resource, and ignored kinds.
+const bundle = `apiVersion: apps/v1
+kind: Deployment
+metadata: { name: api, namespace: backend }
+spec:
+    template:
+        spec:
+            initContainers:
+                - { name: migrate, image: "api:1.0.0" }
+            containers:
+                - { name: api, image: "api:1.0.0" }
+                - { name: proxy, image: "registry.io:5000/proxy@sha256:abc" }
+---
+apiVersion: v1
+kind: Pod
+metadata: { name: debug }
+spec:
+    containers:
+        - { name: debug, image: "${IMAGE}" }
+---
+apiVersion: example.io/v1
+kind: Database
+metadata: { name: database }
+spec:
+    image: postgres:16
+    backup:
+        baseImage: { registry: registry.io, repository: backup, tag: "1.0" }
+    replicas: [ { image: 16 } ]
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: { name: settings }
+data: { image: "ignored:1.0.0" }
+`
+
+func TestCollect(t *testing.T) {
+	documents, e := manifests.Decode("bundle.yaml", strings.NewReader(bundle))
+	if e != nil {
+		t.Fatalf("unable to decode documents: %v", e)
+	}
+
+	tests := []struct {
+		name      string
+		overrides []types.Image
+		expected  map[string][]string // expected maps every collected image to its sources' String()
+	}{
+		{
+			name: "sources",
+			expected: map[string][]string{
+				"api:1.0.0":                         {"deployment/api (initContainers/migrate)", "deployment/api (containers/api)"},
+				"registry.io:5000/proxy@sha256:abc": {"deployment/api (containers/proxy)"},
+				"postgres:16":                       {"database/database (spec.image)"},
+				"registry.io/backup:1.0":            {"database/database (spec.backup.baseImage)"},
+			},
+		},
+		{
+			name:      "overrides",
+			overrides: []types.Image{{Name: "api", NewTag: "2.0.0"}, {Name: "postgres", NewName: "registry.io/postgres"}},
+			expected: map[string][]string{
+				"api:2.0.0":                         {"deployment/api (initContainers/migrate)", "deployment/api (containers/api)"},
+				"registry.io:5000/proxy@sha256:abc": {"deployment/api (containers/proxy)"},
+				"registry.io/postgres:16":           {"database/database (spec.image)"},
+				"registry.io/backup:1.0":            {"database/database (spec.backup.baseImage)"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			references := Collect(test.overrides, documents...)
+			if len(references) != len(test.expected) {
+				t.Fatalf("Collect() = %+v, expected %d image(s)", references, len(test.expected))
+			}
+
+			for index, reference := range references {
+				if index > 0 && references[index-1].Image >= reference.Image {
+					t.Errorf("Collect() isn't sorted: %s precedes %s", references[index-1].Image, reference.Image)
+				}
+
+				var sources []string
+				for _, source := range reference.Sources {
+					sources = append(sources, source.String())
+				}
+
+				if expected, exists := test.expected[reference.Image]; !(exists) || strings.Join(sources, ", ") != strings.Join(expected, ", ") {
+					t.Errorf("Collect() %s sources = %v, expected %v", reference.Image, sources, expected)
+				}
+			}
+		})
+	}
+}
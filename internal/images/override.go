@@ -0,0 +1,60 @@
+package images
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/types"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Overrides returns the "images" overrides of every kustomization among the documents.
+func Overrides(documents ...*manifests.Document) ([]types.Image, error) {
+	var overrides []types.Image
+	for _, document := range documents {
+		if !(document.Kustomization()) {
+			continue
+		}
+
+		var kustomization types.Kustomization
+		if e := document.Decode(&kustomization); e != nil {
+			return nil, fmt.Errorf("unable to decode kustomization (%s): %w", document.Location(), e)
+		}
+
+		overrides = append(overrides, kustomization.Images...)
+	}
+
+	return overrides, nil
+}
+
+// Override applies the first override whose name matches the image's, as kustomize's image transformer does: a new
+// name replaces the name, a new tag replaces both the tag and any digest, a digest replaces both the digest and any tag,
+// and a tag suffix is appended to the resulting tag.
+func Override(image manifests.Image, overrides []types.Image) manifests.Image {
+	for _, override := range overrides {
+		if override.Name != image.Name {
+			continue
+		}
+
+		if override.NewName != "" {
+			image.Name = override.NewName
+		}
+
+		switch {
+		case override.NewTag != "" && override.Digest != "":
+			image.Tag, image.Digest = override.NewTag, override.Digest
+		case override.NewTag != "":
+			image.Tag, image.Digest = override.NewTag, ""
+		case override.Digest != "":
+			image.Tag, image.Digest = "", override.Digest
+		}
+
+		if override.TagSuffix != "" {
+			image.Tag += override.TagSuffix
+		}
+
+		break
+	}
+
+	return image
+}
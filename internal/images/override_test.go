@@ -0,0 +1,57 @@
+package images
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kustomize/api/types"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+func TestOverride(t *testing.T) {
+	overrides := []types.Image{
+		{Name: "api", NewName: "registry.io/api", NewTag: "2.0.0"},
+		{Name: "api", NewTag: "ignored"},
+		{Name: "worker", Digest: "sha256:abc"},
+		{Name: "web", NewTag: "2.0.0", Digest: "sha256:def"},
+		{Name: "job", TagSuffix: "-debug"},
+		{Name: "renamed", NewName: "registry.io/renamed"},
+	}
+
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "api:1.0.0@sha256:old", expected: "registry.io/api:2.0.0"},
+		{image: "worker:1.0.0", expected: "worker@sha256:abc"},
+		{image: "web", expected: "web:2.0.0@sha256:def"},
+		{image: "job:1.0.0", expected: "job:1.0.0-debug"},
+		{image: "renamed:1.0.0", expected: "registry.io/renamed:1.0.0"},
+		{image: "registry.io/api:1.0.0", expected: "registry.io/api:1.0.0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			if image := Override(manifests.ParseImage(test.image), overrides); image.String() != test.expected {
+				t.Errorf("Override(%s) = %s, expected %s", test.image, image, test.expected)
+			}
+		})
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	documents, e := manifests.Decode("kustomization.yaml", strings.NewReader("apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nimages: [ { name: api, newTag: 2.0.0 }, { name: web, digest: \"sha256:abc\" } ]\n---\napiVersion: v1\nkind: ConfigMap\nmetadata: { name: images }\n"))
+	if e != nil {
+		t.Fatalf("unable to decode documents: %v", e)
+	}
+
+	overrides, e := Overrides(documents...)
+	if e != nil {
+		t.Fatalf("Overrides() returned an unexpected error: %v", e)
+	}
+
+	if len(overrides) != 2 || overrides[0].Name != "api" || overrides[0].NewTag != "2.0.0" || overrides[1].Digest != "sha256:abc" {
+		t.Errorf("Overrides() = %+v, expected the kustomization's images", overrides)
+	}
+}
@@ -0,0 +1,183 @@
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Chain represents the kubeconfig file(s) of a "KUBECONFIG" list, each as written. Modifications follow kubectl's
+// rules for which file is changed, and are only persisted by [Chain.Save].
+type Chain struct {
+	Paths   []string  // Paths are the chain's file(s), in precedence order.
+	Configs []*Config // Configs are the decoded file(s) of Paths; nil for file(s) that don't exist.
+
+	modified map[int]bool
+}
+
+// Open decodes kubeconfig file(s). As with kubectl, files that don't exist are skipped, unless there's only one (e.g.
+// "--kubeconfig") - but at least one must exist.
+func Open(paths ...string) (*Chain, error) {
+	chain := &Chain{Paths: paths, Configs: make([]*Config, len(paths)), modified: make(map[int]bool)}
+
+	var found bool
+	for index, path := range paths {
+		config, e := Decode(path)
+		if errors.Is(e, os.ErrNotExist) && len(paths) > 1 {
+			continue
+		} else if e != nil {
+			e = fmt.Errorf("unable to read kubeconfig: %w", e)
+			return nil, e
+		}
+
+		found = true
+
+		chain.Configs[index] = config
+	}
+
+	if !(found) {
+		return nil, errors.New("no kubeconfig found - set --kubeconfig or KUBECONFIG")
+	}
+
+	return chain, nil
+}
+
+// Merged returns the chain's merged view, with relative file references resolved. As with kubectl, the first file to
+// define a cluster, user, or context - or the current-context - wins.
+func (c *Chain) Merged() (*Config, error) {
+	merged := &Config{APIVersion: "v1", Kind: "Config"}
+
+	clusters, users, contexts := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+	for index, raw := range c.Configs {
+		if raw == nil {
+			continue
+		}
+
+		config, e := raw.resolve(c.Paths[index])
+		if e != nil {
+			return nil, e
+		}
+
+		if merged.CurrentContext == "" {
+			merged.CurrentContext = config.CurrentContext
+		}
+
+		if merged.Extra == nil && len(config.Extra) > 0 {
+			merged.Extra = config.Extra
+		}
+
+		for _, cluster := range config.Clusters {
+			if !(clusters[cluster.Name]) {
+				clusters[cluster.Name] = true
+				merged.Clusters = append(merged.Clusters, cluster)
+			}
+		}
+
+		for _, user := range config.Users {
+			if !(users[user.Name]) {
+				users[user.Name] = true
+				merged.Users = append(merged.Users, user)
+			}
+		}
+
+		for _, entry := range config.Contexts {
+			if !(contexts[entry.Name]) {
+				contexts[entry.Name] = true
+				merged.Contexts = append(merged.Contexts, entry)
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// current returns the index of the file that holds the current-context - the first that sets one, otherwise the
+// first file (created if it doesn't exist).
+func (c *Chain) current() int {
+	for index, config := range c.Configs {
+		if config != nil && config.CurrentContext != "" {
+			return index
+		}
+	}
+
+	if c.Configs[0] == nil {
+		c.Configs[0] = &Config{APIVersion: "v1", Kind: "Config"}
+	}
+
+	return 0
+}
+
+// defining returns the index of the first file that defines a context, or -1.
+func (c *Chain) defining(name string) int {
+	for index, config := range c.Configs {
+		if config != nil && config.Context(name) != nil {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Use sets the current-context, returning the path of the file that changed.
+func (c *Chain) Use(name string) (string, error) {
+	if c.defining(name) < 0 {
+		return "", fmt.Errorf("context not found: %s", name)
+	}
+
+	index := c.current()
+
+	c.Configs[index].CurrentContext = name
+	c.modified[index] = true
+
+	return c.Paths[index], nil
+}
+
+// Rename renames a context in the file that defines it - and the current-context, if it's the renamed context -
+// returning the path(s) of the file(s) that changed.
+func (c *Chain) Rename(previous, name string) ([]string, error) {
+	index := c.defining(previous)
+	if index < 0 {
+		return nil, fmt.Errorf("context not found: %s", previous)
+	}
+
+	if c.defining(name) >= 0 {
+		return nil, fmt.Errorf("context already exists: %s", name)
+	}
+
+	config := c.Configs[index]
+	for position := range config.Contexts {
+		if config.Contexts[position].Name == previous {
+			config.Contexts[position].Name = name
+		}
+	}
+
+	c.modified[index] = true
+
+	paths := []string{c.Paths[index]}
+	if current := c.current(); c.Configs[current].CurrentContext == previous {
+		c.Configs[current].CurrentContext = name
+		if !(c.modified[current]) {
+			c.modified[current] = true
+			paths = append(paths, c.Paths[current])
+		}
+	}
+
+	return paths, nil
+}
+
+// Save atomically writes the file(s) changed since the chain was opened.
+func (c *Chain) Save() error {
+	for index, path := range c.Paths {
+		if !(c.modified[index]) {
+			continue
+		}
+
+		if e := Write(path, c.Configs[index]); e != nil {
+			return e
+		}
+
+		delete(c.modified, index)
+	}
+
+	return nil
+}
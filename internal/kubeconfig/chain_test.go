@@ -0,0 +1,165 @@
+package kubeconfig
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestChainMerged(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string // files are the chain's kubeconfig(s), in precedence order
+		current  string   // current is the expected current-context
+		server   string   // server is the expected server of the "shared" cluster
+		contexts []string // contexts are the expected context names, in order
+	}{
+		{
+			name:     "first-definition-wins",
+			files:    []string{"clusters: [ { name: shared, cluster: { server: https://first } } ]\ncontexts: [ { name: first, context: { cluster: shared } } ]\n", "clusters: [ { name: shared, cluster: { server: https://second } } ]\ncontexts: [ { name: first, context: { cluster: other } }, { name: second, context: { cluster: shared } } ]\n"},
+			server:   "https://first",
+			contexts: []string{"first", "second"},
+		},
+		{
+			name:     "first-current-context-wins",
+			files:    []string{"current-context: first\n", "current-context: second\nclusters: [ { name: shared, cluster: { server: https://second } } ]\n"},
+			current:  "first",
+			server:   "https://second",
+			contexts: nil,
+		},
+		{
+			name:     "empty-current-context-skipped",
+			files:    []string{"clusters: []\n", "current-context: second\n"},
+			current:  "second",
+			contexts: nil,
+		},
+		{
+			name:     "missing-file-skipped",
+			files:    []string{"", "current-context: second\ncontexts: [ { name: second, context: { cluster: shared } } ]\n"},
+			current:  "second",
+			contexts: []string{"second"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			directory := t.TempDir()
+
+			var paths []string
+			for index, content := range test.files {
+				path := filepath.Join(directory, string(rune('a'+index)))
+				if content != "" {
+					path = write(t, directory, string(rune('a'+index)), content)
+				}
+
+				paths = append(paths, path)
+			}
+
+			config, e := Load(paths...)
+			if e != nil {
+				t.Fatalf("Load() returned an unexpected error: %v", e)
+			}
+
+			if config.CurrentContext != test.current {
+				t.Errorf("Load() current-context = %q, expected %q", config.CurrentContext, test.current)
+			}
+
+			var server string
+			if cluster := config.Cluster("shared"); cluster != nil {
+				server = cluster.Server
+			}
+
+			if server != test.server {
+				t.Errorf("Load() shared cluster server = %q, expected %q", server, test.server)
+			}
+
+			var contexts []string
+			for _, entry := range config.Contexts {
+				contexts = append(contexts, entry.Name)
+			}
+
+			if !(slices.Equal(contexts, test.contexts)) {
+				t.Errorf("Load() contexts = %v, expected %v", contexts, test.contexts)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	directory := t.TempDir()
+
+	if _, e := Open(filepath.Join(directory, "missing")); e == nil {
+		t.Errorf("Open() of a single missing file expected an error")
+	}
+
+	if _, e := Open(filepath.Join(directory, "missing"), filepath.Join(directory, "other")); e == nil {
+		t.Errorf("Open() without any existing file expected an error")
+	}
+}
+
+func TestChainUse(t *testing.T) {
+	directory := t.TempDir()
+
+	first := write(t, directory, "first", "contexts: [ { name: first, context: { cluster: first } } ]\n")
+	second := write(t, directory, "second", "current-context: second\ncontexts: [ { name: second, context: { cluster: second } } ]\n")
+
+	chain, e := Open(first, second)
+	if e != nil {
+		t.Fatalf("Open() returned an unexpected error: %v", e)
+	}
+
+	// --> the file that holds the current-context is changed, rather than the one that defines the context
+	path, e := chain.Use("first")
+	if e != nil || path != second {
+		t.Fatalf("Use() = (%s, %v), expected %s", path, e, second)
+	}
+
+	if _, e := chain.Use("missing"); e == nil {
+		t.Errorf("Use() of a missing context expected an error")
+	}
+
+	if e := chain.Save(); e != nil {
+		t.Fatalf("Save() returned an unexpected error: %v", e)
+	}
+
+	for path, expected := range map[string]string{first: "", second: "first"} {
+		config, e := Decode(path)
+		if e != nil || config.CurrentContext != expected {
+			t.Errorf("Decode(%s) current-context = (%q, %v), expected %q", filepath.Base(path), config.CurrentContext, e, expected)
+		}
+	}
+}
+
+func TestChainRename(t *testing.T) {
+	directory := t.TempDir()
+
+	first := write(t, directory, "first", "current-context: example\n")
+	second := write(t, directory, "second", "contexts: [ { name: example, context: { cluster: example } }, { name: other, context: { cluster: other } } ]\n")
+
+	chain, e := Open(first, second)
+	if e != nil {
+		t.Fatalf("Open() returned an unexpected error: %v", e)
+	}
+
+	if _, e := chain.Rename("example", "other"); e == nil {
+		t.Errorf("Rename() to an existing context expected an error")
+	}
+
+	paths, e := chain.Rename("example", "renamed")
+	if e != nil {
+		t.Fatalf("Rename() returned an unexpected error: %v", e)
+	}
+
+	if expected := []string{second, first}; !(slices.Equal(paths, expected)) {
+		t.Errorf("Rename() = %v, expected %v", paths, expected)
+	}
+
+	merged, e := chain.Merged()
+	if e != nil {
+		t.Fatalf("Merged() returned an unexpected error: %v", e)
+	}
+
+	if merged.CurrentContext != "renamed" || merged.Context("renamed") == nil || merged.Context("example") != nil {
+		t.Errorf("Merged() = %+v, expected the context and current-context to be renamed", merged)
+	}
+}
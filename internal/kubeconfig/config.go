@@ -0,0 +1,220 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config represents a kubeconfig file. Fields that aren't modeled are retained in each type's Extra.
+type Config struct {
+	APIVersion     string                 `yaml:"apiVersion,omitempty"`
+	Kind           string                 `yaml:"kind,omitempty"`
+	Clusters       []NamedCluster         `yaml:"clusters"`
+	Users          []NamedUser            `yaml:"users"`
+	Contexts       []NamedContext         `yaml:"contexts"`
+	CurrentContext string                 `yaml:"current-context"`
+	Extra          map[string]interface{} `yaml:",inline"`
+}
+
+// NamedCluster represents an entry of a kubeconfig's "clusters".
+type NamedCluster struct {
+	Name    string  `yaml:"name"`
+	Cluster Cluster `yaml:"cluster"`
+}
+
+// Cluster represents an API server and how to trust it.
+type Cluster struct {
+	Server                   string                 `yaml:"server"`
+	CertificateAuthority     string                 `yaml:"certificate-authority,omitempty"`
+	CertificateAuthorityData string                 `yaml:"certificate-authority-data,omitempty"`
+	InsecureSkipTLSVerify    bool                   `yaml:"insecure-skip-tls-verify,omitempty"`
+	TLSServerName            string                 `yaml:"tls-server-name,omitempty"`
+	ProxyURL                 string                 `yaml:"proxy-url,omitempty"`
+	Extra                    map[string]interface{} `yaml:",inline"`
+}
+
+// NamedUser represents an entry of a kubeconfig's "users".
+type NamedUser struct {
+	Name string `yaml:"name"`
+	User User   `yaml:"user"`
+}
+
+// User represents the credentials used to authenticate to an API server.
+type User struct {
+	ClientCertificate     string                 `yaml:"client-certificate,omitempty"`
+	ClientCertificateData string                 `yaml:"client-certificate-data,omitempty"`
+	ClientKey             string                 `yaml:"client-key,omitempty"`
+	ClientKeyData         string                 `yaml:"client-key-data,omitempty"`
+	Token                 string                 `yaml:"token,omitempty"`
+	TokenFile             string                 `yaml:"tokenFile,omitempty"`
+	Username              string                 `yaml:"username,omitempty"`
+	Password              string                 `yaml:"password,omitempty"`
+	Exec                  *Exec                  `yaml:"exec,omitempty"`
+	Extra                 map[string]interface{} `yaml:",inline"`
+}
+
+// Exec represents a client-go credential plugin.
+type Exec struct {
+	APIVersion string                 `yaml:"apiVersion"`
+	Command    string                 `yaml:"command"`
+	Args       []string               `yaml:"args,omitempty"`
+	Env        []Variable             `yaml:"env,omitempty"`
+	Extra      map[string]interface{} `yaml:",inline"`
+}
+
+// Variable represents an environment variable passed to a credential plugin.
+type Variable struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// NamedContext represents an entry of a kubeconfig's "contexts".
+type NamedContext struct {
+	Name    string  `yaml:"name"`
+	Context Context `yaml:"context"`
+}
+
+// Context binds a cluster, a user, and an optional default namespace.
+type Context struct {
+	Cluster   string                 `yaml:"cluster"`
+	User      string                 `yaml:"user"`
+	Namespace string                 `yaml:"namespace,omitempty"`
+	Extra     map[string]interface{} `yaml:",inline"`
+}
+
+// Cluster returns the named cluster, or nil.
+func (c *Config) Cluster(name string) *Cluster {
+	for index := range c.Clusters {
+		if c.Clusters[index].Name == name {
+			return &c.Clusters[index].Cluster
+		}
+	}
+
+	return nil
+}
+
+// User returns the named user, or nil.
+func (c *Config) User(name string) *User {
+	for index := range c.Users {
+		if c.Users[index].Name == name {
+			return &c.Users[index].User
+		}
+	}
+
+	return nil
+}
+
+// Context returns the named context, or nil.
+func (c *Config) Context(name string) *Context {
+	for index := range c.Contexts {
+		if c.Contexts[index].Name == name {
+			return &c.Contexts[index].Context
+		}
+	}
+
+	return nil
+}
+
+// Paths returns the kubeconfig file(s) to load, in precedence order - explicit (e.g. the "--kubeconfig" flag) if set,
+// otherwise the "KUBECONFIG" environment variable's list, otherwise "~/.kube/config".
+func Paths(explicit string) []string {
+	if explicit != "" {
+		return []string{explicit}
+	}
+
+	if variable := os.Getenv("KUBECONFIG"); variable != "" {
+		seen := make(map[string]bool)
+
+		var paths []string
+		for _, path := range filepath.SplitList(variable) {
+			if path == "" || seen[path] {
+				continue
+			}
+
+			seen[path] = true
+			paths = append(paths, path)
+		}
+
+		return paths
+	}
+
+	home, e := os.UserHomeDir()
+	if e != nil {
+		return nil
+	}
+
+	return []string{filepath.Join(home, ".kube", "config")}
+}
+
+// Decode parses a single kubeconfig file as written - file references are left unresolved.
+func Decode(path string) (*Config, error) {
+	content, e := os.ReadFile(path)
+	if e != nil {
+		return nil, e
+	}
+
+	var config Config
+	if e := yaml.Unmarshal(content, &config); e != nil {
+		e = fmt.Errorf("unable to parse kubeconfig (%s): %w", path, e)
+		return nil, e
+	}
+
+	return &config, nil
+}
+
+// Read parses a single kubeconfig file. Relative file references (e.g. "certificate-authority") are resolved against
+// the file's directory, as kubectl does.
+func Read(path string) (*Config, error) {
+	config, e := Decode(path)
+	if e != nil {
+		return nil, e
+	}
+
+	return config.resolve(path)
+}
+
+// resolve returns a copy of the config whose relative file references are resolved against path's directory.
+func (c *Config) resolve(path string) (*Config, error) {
+	directory, e := filepath.Abs(filepath.Dir(path))
+	if e != nil {
+		return nil, e
+	}
+
+	resolve := func(reference *string) {
+		if *reference != "" && !(filepath.IsAbs(*reference)) {
+			*reference = filepath.Join(directory, *reference)
+		}
+	}
+
+	config := *c
+	config.Clusters = append([]NamedCluster(nil), c.Clusters...)
+	config.Users = append([]NamedUser(nil), c.Users...)
+	config.Contexts = append([]NamedContext(nil), c.Contexts...)
+
+	for index := range config.Clusters {
+		resolve(&config.Clusters[index].Cluster.CertificateAuthority)
+	}
+
+	for index := range config.Users {
+		user := &config.Users[index].User
+
+		resolve(&user.ClientCertificate)
+		resolve(&user.ClientKey)
+		resolve(&user.TokenFile)
+	}
+
+	return &config, nil
+}
+
+// Load reads and merges kubeconfig file(s) - see [Chain.Merged].
+func Load(paths ...string) (*Config, error) {
+	chain, e := Open(paths...)
+	if e != nil {
+		return nil, e
+	}
+
+	return chain.Merged()
+}
@@ -0,0 +1,115 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// write creates a kubeconfig file within directory, returning its path.
+func write(t *testing.T, directory, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(directory, name)
+	if e := os.MkdirAll(filepath.Dir(path), 0o700); e != nil {
+		t.Fatalf("unable to create directory: %v", e)
+	}
+
+	if e := os.WriteFile(path, []byte(content), 0o600); e != nil {
+		t.Fatalf("unable to write kubeconfig: %v", e)
+	}
+
+	return path
+}
+
+func TestConfigResolve(t *testing.T) {
+	directory := t.TempDir()
+
+	absolute := filepath.Join(string(filepath.Separator), "etc", "kubernetes", "ca.crt")
+
+	tests := []struct {
+		name      string
+		reference string
+		expected  string
+	}{
+		{name: "relative", reference: "ca.crt", expected: filepath.Join(directory, "nested", "ca.crt")},
+		{name: "parent", reference: "../certificates/ca.crt", expected: filepath.Join(directory, "certificates", "ca.crt")},
+		{name: "absolute", reference: absolute, expected: absolute},
+		{name: "unset", reference: "", expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			raw := &Config{
+				Clusters: []NamedCluster{{Name: "example", Cluster: Cluster{CertificateAuthority: test.reference}}},
+				Users:    []NamedUser{{Name: "example", User: User{ClientCertificate: test.reference, ClientKey: test.reference, TokenFile: test.reference}}},
+			}
+
+			config, e := raw.resolve(filepath.Join(directory, "nested", "config"))
+			if e != nil {
+				t.Fatalf("resolve() returned an unexpected error: %v", e)
+			}
+
+			user := config.Users[0].User
+			resolved := []string{config.Clusters[0].Cluster.CertificateAuthority, user.ClientCertificate, user.ClientKey, user.TokenFile}
+			for _, reference := range resolved {
+				if reference != test.expected {
+					t.Errorf("resolve() = %v, expected every reference to be %q", resolved, test.expected)
+					break
+				}
+			}
+
+			if raw.Clusters[0].Cluster.CertificateAuthority != test.reference || raw.Users[0].User.TokenFile != test.reference {
+				t.Errorf("resolve() modified the original config")
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	directory := t.TempDir()
+
+	path := write(t, directory, "config", "clusters: [ { name: example, cluster: { server: https://127.0.0.1:6443, certificate-authority: ca.crt, extension: retained } } ]\n")
+
+	config, e := Read(path)
+	if e != nil {
+		t.Fatalf("Read() returned an unexpected error: %v", e)
+	}
+
+	cluster := config.Cluster("example")
+	if cluster == nil || cluster.CertificateAuthority != filepath.Join(directory, "ca.crt") || cluster.Extra["extension"] != "retained" {
+		t.Errorf("Read() = %+v, expected a resolved certificate-authority and the retained extension", cluster)
+	}
+
+	if _, e := Read(write(t, directory, "invalid", "clusters: {")); e == nil {
+		t.Errorf("Read() of invalid yaml expected an error")
+	}
+}
+
+func TestPaths(t *testing.T) {
+	home := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name     string
+		explicit string
+		variable string
+		expected []string
+	}{
+		{name: "explicit", explicit: "explicit", variable: "first", expected: []string{"explicit"}},
+		{name: "variable", variable: "first" + string(filepath.ListSeparator) + string(filepath.ListSeparator) + "second" + string(filepath.ListSeparator) + "first", expected: []string{"first", "second"}},
+		{name: "default", expected: []string{filepath.Join(home, ".kube", "config")}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("KUBECONFIG", test.variable)
+
+			if paths := Paths(test.explicit); !(slices.Equal(paths, test.expected)) {
+				t.Errorf("Paths(%q) = %v, expected %v", test.explicit, paths, test.expected)
+			}
+		})
+	}
+}
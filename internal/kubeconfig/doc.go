@@ -0,0 +1,2 @@
+// Package kubeconfig reads kubeconfig files, following kubectl's "KUBECONFIG" merge semantics.
+package kubeconfig
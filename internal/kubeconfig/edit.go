@@ -0,0 +1,135 @@
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// Flatten embeds the certificate file(s) a config references - certificate-authority, client-certificate, and
+// client-key - as their "-data" counterparts. File references should already be absolute (see [Read]).
+func (c *Config) Flatten() error {
+	embed := func(reference, data *string) error {
+		if *reference == "" {
+			return nil
+		}
+
+		content, e := os.ReadFile(*reference)
+		if e != nil {
+			e = fmt.Errorf("unable to embed %s: %w", *reference, e)
+			return e
+		}
+
+		*data, *reference = base64.StdEncoding.EncodeToString(content), ""
+
+		return nil
+	}
+
+	for index := range c.Clusters {
+		cluster := &c.Clusters[index].Cluster
+		if e := embed(&cluster.CertificateAuthority, &cluster.CertificateAuthorityData); e != nil {
+			return e
+		}
+	}
+
+	for index := range c.Users {
+		user := &c.Users[index].User
+		if e := embed(&user.ClientCertificate, &user.ClientCertificateData); e != nil {
+			return e
+		}
+
+		if e := embed(&user.ClientKey, &user.ClientKeyData); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Minify returns a config reduced to a single context - the current-context if name is empty - and the cluster and
+// user it references.
+func (c *Config) Minify(name string) (*Config, error) {
+	if name == "" {
+		name = c.CurrentContext
+	}
+
+	if name == "" {
+		return nil, errors.New("no context selected - set --context or a current-context")
+	}
+
+	selected := c.Context(name)
+	if selected == nil {
+		return nil, fmt.Errorf("context not found: %s", name)
+	}
+
+	minified := &Config{APIVersion: "v1", Kind: "Config", CurrentContext: name, Extra: c.Extra}
+	minified.Contexts = []NamedContext{{Name: name, Context: *selected}}
+
+	if cluster := c.Cluster(selected.Cluster); cluster != nil {
+		minified.Clusters = []NamedCluster{{Name: selected.Cluster, Cluster: *cluster}}
+	} else {
+		return nil, fmt.Errorf("cluster (%s) of context (%s) not found", selected.Cluster, name)
+	}
+
+	if user := c.User(selected.User); user != nil {
+		minified.Users = []NamedUser{{Name: selected.User, User: *user}}
+	}
+
+	return minified, nil
+}
+
+// Merge adds the clusters, users, and contexts of other to the config, adopting its current-context if the config has
+// none. Entries that already exist with identical content are skipped. Entries that exist with different content are
+// replaced when overwrite is set, and otherwise left alone and returned as conflicts (e.g. "context/example").
+func (c *Config) Merge(other *Config, overwrite bool) (conflicts []string) {
+	for _, incoming := range other.Clusters {
+		existing := c.Cluster(incoming.Name)
+		switch {
+		case existing == nil:
+			c.Clusters = append(c.Clusters, incoming)
+		case reflect.DeepEqual(*existing, incoming.Cluster):
+		case overwrite:
+			*existing = incoming.Cluster
+		default:
+			conflicts = append(conflicts, "cluster/"+incoming.Name)
+		}
+	}
+
+	for _, incoming := range other.Users {
+		existing := c.User(incoming.Name)
+		switch {
+		case existing == nil:
+			c.Users = append(c.Users, incoming)
+		case reflect.DeepEqual(*existing, incoming.User):
+		case overwrite:
+			*existing = incoming.User
+		default:
+			conflicts = append(conflicts, "user/"+incoming.Name)
+		}
+	}
+
+	for _, incoming := range other.Contexts {
+		existing := c.Context(incoming.Name)
+		switch {
+		case existing == nil:
+			c.Contexts = append(c.Contexts, incoming)
+		case reflect.DeepEqual(*existing, incoming.Context):
+		case overwrite:
+			*existing = incoming.Context
+		default:
+			conflicts = append(conflicts, "context/"+incoming.Name)
+		}
+	}
+
+	if c.CurrentContext == "" {
+		c.CurrentContext = other.CurrentContext
+	}
+
+	if c.APIVersion == "" {
+		c.APIVersion, c.Kind = "v1", "Config"
+	}
+
+	return conflicts
+}
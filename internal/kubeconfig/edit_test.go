@@ -0,0 +1,100 @@
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConfigMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		overwrite bool
+		server    string
+		conflicts []string
+	}{
+		{name: "conflict", server: "https://original", conflicts: []string{"cluster/shared", "context/shared"}},
+		{name: "overwrite", overwrite: true, server: "https://incoming"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &Config{
+				CurrentContext: "shared",
+				Clusters:       []NamedCluster{{Name: "shared", Cluster: Cluster{Server: "https://original"}}},
+				Users:          []NamedUser{{Name: "shared", User: User{Token: "token"}}},
+				Contexts:       []NamedContext{{Name: "shared", Context: Context{Cluster: "shared", User: "shared"}}},
+			}
+
+			other := &Config{
+				CurrentContext: "added",
+				Clusters:       []NamedCluster{{Name: "shared", Cluster: Cluster{Server: "https://incoming"}}},
+				Users:          []NamedUser{{Name: "shared", User: User{Token: "token"}}},
+				Contexts:       []NamedContext{{Name: "shared", Context: Context{Cluster: "shared", User: "shared", Namespace: "other"}}, {Name: "added", Context: Context{Cluster: "shared"}}},
+			}
+
+			conflicts := config.Merge(other, test.overwrite)
+			if !(slices.Equal(conflicts, test.conflicts)) {
+				t.Errorf("Merge() = %v, expected %v", conflicts, test.conflicts)
+			}
+
+			if config.Cluster("shared").Server != test.server || config.Context("added") == nil || len(config.Users) != 1 {
+				t.Errorf("Merge() = %+v, expected server %s, the added context, and a single (identical) user", config, test.server)
+			}
+
+			if config.CurrentContext != "shared" || config.APIVersion != "v1" {
+				t.Errorf("Merge() = (current-context: %s, apiVersion: %s), expected the existing current-context to be retained", config.CurrentContext, config.APIVersion)
+			}
+		})
+	}
+}
+
+func TestConfigMinify(t *testing.T) {
+	config := &Config{
+		CurrentContext: "first",
+		Clusters:       []NamedCluster{{Name: "first", Cluster: Cluster{Server: "https://first"}}, {Name: "second", Cluster: Cluster{Server: "https://second"}}},
+		Users:          []NamedUser{{Name: "first"}, {Name: "second"}},
+		Contexts:       []NamedContext{{Name: "first", Context: Context{Cluster: "first", User: "first"}}, {Name: "second", Context: Context{Cluster: "second", User: "second"}}, {Name: "broken", Context: Context{Cluster: "missing"}}},
+	}
+
+	minified, e := config.Minify("")
+	if e != nil {
+		t.Fatalf("Minify() returned an unexpected error: %v", e)
+	}
+
+	if len(minified.Clusters) != 1 || len(minified.Users) != 1 || len(minified.Contexts) != 1 || minified.Cluster("first") == nil || minified.CurrentContext != "first" {
+		t.Errorf("Minify() = %+v, expected only the current-context's entries", minified)
+	}
+
+	for _, name := range []string{"missing", "broken"} {
+		if _, e := config.Minify(name); e == nil {
+			t.Errorf("Minify(%s) expected an error", name)
+		}
+	}
+}
+
+func TestConfigFlatten(t *testing.T) {
+	directory := t.TempDir()
+
+	authority := filepath.Join(directory, "ca.crt")
+	if e := os.WriteFile(authority, []byte("certificate"), 0o600); e != nil {
+		t.Fatalf("unable to write certificate: %v", e)
+	}
+
+	config := &Config{Clusters: []NamedCluster{{Name: "example", Cluster: Cluster{CertificateAuthority: authority}}}}
+	if e := config.Flatten(); e != nil {
+		t.Fatalf("Flatten() returned an unexpected error: %v", e)
+	}
+
+	cluster := config.Cluster("example")
+	if cluster.CertificateAuthority != "" || cluster.CertificateAuthorityData != base64.StdEncoding.EncodeToString([]byte("certificate")) {
+		t.Errorf("Flatten() = %+v, expected the certificate-authority to be embedded", cluster)
+	}
+
+	missing := &Config{Users: []NamedUser{{Name: "example", User: User{ClientKey: filepath.Join(directory, "missing.key")}}}}
+	if e := missing.Flatten(); e == nil {
+		t.Errorf("Flatten() of a missing file expected an error")
+	}
+}
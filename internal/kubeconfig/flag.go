@@ -0,0 +1,13 @@
+package kubeconfig
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Explicit is the value of the shared "--kubeconfig" flag - see [Register].
+var Explicit string
+
+// Register adds the "--kubeconfig" flag to a command as a persistent flag, shared by all of its sub-command(s).
+func Register(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&Explicit, "kubeconfig", "", "path to the kubeconfig file - defaults to $KUBECONFIG, then ~/.kube/config")
+}
@@ -0,0 +1,90 @@
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Encode writes a config as YAML.
+func Encode(writer io.Writer, config *Config) error {
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+
+	if e := encoder.Encode(config); e != nil {
+		e = fmt.Errorf("unable to encode kubeconfig: %w", e)
+		return e
+	}
+
+	return encoder.Close()
+}
+
+// Write atomically replaces (or creates) a kubeconfig file: the config is written to a temporary file in the same
+// directory, synced, and renamed over path - so that readers never observe a partial file. An existing file's mode is
+// retained; new files are only readable by their owner.
+func Write(path string, config *Config) error {
+	mode := fs.FileMode(0600)
+	if information, e := os.Stat(path); e == nil {
+		mode = information.Mode().Perm()
+	} else if !(errors.Is(e, os.ErrNotExist)) {
+		return e
+	}
+
+	directory := filepath.Dir(path)
+	if e := os.MkdirAll(directory, 0700); e != nil {
+		e = fmt.Errorf("unable to create kubeconfig directory: %w", e)
+		return e
+	}
+
+	file, e := os.CreateTemp(directory, "."+filepath.Base(path)+".*.tmp")
+	if e != nil {
+		e = fmt.Errorf("unable to create temporary kubeconfig: %w", e)
+		return e
+	}
+
+	// --> no-op once renamed
+	defer os.Remove(file.Name())
+
+	if e := Encode(file, config); e != nil {
+		file.Close()
+		return e
+	}
+
+	if e := file.Chmod(mode); e != nil {
+		file.Close()
+		return e
+	}
+
+	if e := file.Sync(); e != nil {
+		file.Close()
+		return e
+	}
+
+	if e := file.Close(); e != nil {
+		return e
+	}
+
+	if e := os.Rename(file.Name(), path); e != nil {
+		e = fmt.Errorf("unable to replace kubeconfig (%s): %w", path, e)
+		return e
+	}
+
+	return nil
+}
+
+// Stdout is the output path that represents standard-output.
+const Stdout = "-"
+
+// Output writes a config to path - atomically (see [Write]), unless path is [Stdout].
+func Output(path string, config *Config) error {
+	if path == Stdout {
+		return Encode(os.Stdout, config)
+	}
+
+	return Write(path, config)
+}
@@ -0,0 +1,58 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration represents the linter's user-provided rule configuration.
+//
+//	rules:
+//	    latest-image-tag: error
+//	    missing-security-context: "off"
+type Configuration struct {
+	Rules map[string]Severity `json:"rules" yaml:"rules"` // Rules overrides rules' default severities by ID.
+}
+
+// Load reads a [Configuration] from a YAML file.
+func Load(path string) (*Configuration, error) {
+	content, e := os.ReadFile(path)
+	if e != nil {
+		e = fmt.Errorf("unable to read configuration: %w", e)
+		return nil, e
+	}
+
+	var configuration Configuration
+	if e := yaml.Unmarshal(content, &configuration); e != nil {
+		e = fmt.Errorf("unable to unmarshal configuration (%s): %w", path, e)
+		return nil, e
+	}
+
+	return &configuration, nil
+}
+
+// Override parses "<rule>=<severity>" assignment(s) into the configuration, taking precedence over existing value(s).
+func (c *Configuration) Override(assignments ...string) error {
+	if c.Rules == nil {
+		c.Rules = make(map[string]Severity)
+	}
+
+	for _, assignment := range assignments {
+		id, value, valid := strings.Cut(assignment, "=")
+		if !(valid) {
+			return fmt.Errorf("invalid rule assignment - expecting \"<rule>=<severity>\": %s", assignment)
+		}
+
+		var severity Severity
+		if e := severity.Set(value); e != nil {
+			return fmt.Errorf("invalid severity for rule %q: %w", id, e)
+		}
+
+		c.Rules[id] = severity
+	}
+
+	return nil
+}
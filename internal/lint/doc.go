@@ -0,0 +1,7 @@
+// Package lint provides a rule-based linter for kubernetes manifests.
+//
+// Rules are identified by ID, and each carries a configurable [Severity]. Findings can be suppressed inline with a
+// "# ethr-lint:ignore <rule>[,<rule>...]" comment; the directive applies to the node it's attached to and all of its
+// descendants. A directive on the first line of a mapping - including the top of a document - applies to the
+// entire mapping. Omitting the rule ID(s) suppresses every rule.
+package lint
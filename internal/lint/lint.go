@@ -0,0 +1,187 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Directive is the comment prefix that suppresses rule(s).
+const Directive = "ethr-lint:ignore"
+
+// Finding represents a single rule violation.
+type Finding struct {
+	File     string   `json:"file" yaml:"file"`
+	Document int      `json:"document" yaml:"document"`
+	Resource string   `json:"resource" yaml:"resource"`
+	Path     string   `json:"path" yaml:"path"`
+	Rule     string   `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	Message  string   `json:"message" yaml:"message"`
+}
+
+// Linter evaluates documents against the enabled rule(s).
+type Linter struct {
+	rules []Rule
+}
+
+// New creates a [Linter] from the built-in rules, applying the configuration's severities. An error is returned
+// if the configuration references an unknown rule.
+func New(configuration *Configuration) (*Linter, error) {
+	rules := Rules()
+
+	known := make(map[string]int, len(rules))
+	for index, rule := range rules {
+		known[rule.ID] = index
+	}
+
+	if configuration != nil {
+		for id, severity := range configuration.Rules {
+			index, valid := known[id]
+			if !(valid) {
+				return nil, fmt.Errorf("unknown rule: %s", id)
+			}
+
+			rules[index].Severity = severity
+		}
+	}
+
+	var enabled []Rule
+	for _, rule := range rules {
+		if rule.Severity != Off {
+			enabled = append(enabled, rule)
+		}
+	}
+
+	return &Linter{rules: enabled}, nil
+}
+
+// Lint evaluates every document, returning unsuppressed finding(s) ordered by file, document, and path.
+func (l *Linter) Lint(documents ...*manifests.Document) []Finding {
+	var findings []Finding
+	for _, document := range documents {
+		ignored := make(map[*yaml.Node]map[string]bool)
+		suppress(document.Node, nil, ignored)
+
+		for _, rule := range l.rules {
+			rule.Check(document, func(node *yaml.Node, path manifests.Path, message string) {
+				if directives := ignored[node]; directives[rule.ID] || directives[""] {
+					return
+				}
+
+				findings = append(findings, Finding{
+					File:     document.File,
+					Document: document.Index,
+					Resource: document.String(),
+					Path:     path.String(),
+					Rule:     rule.ID,
+					Severity: rule.Severity,
+					Message:  message,
+				})
+			})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+
+		if findings[i].Document != findings[j].Document {
+			return findings[i].Document < findings[j].Document
+		}
+
+		return findings[i].Path < findings[j].Path
+	})
+
+	return findings
+}
+
+// suppress walks node, recording the set of rule ID(s) ignored for each node - inherited from its ancestors.
+func suppress(node *yaml.Node, inherited map[string]bool, ignored map[*yaml.Node]map[string]bool) {
+	if node == nil {
+		return
+	}
+
+	comments := []string{node.HeadComment, node.LineComment}
+
+	// --> directives on a mapping's first line apply to the entire mapping
+	if node.Kind == yaml.MappingNode && len(node.Content) >= 2 {
+		comments = append(comments, node.Content[0].HeadComment, node.Content[0].LineComment, node.Content[1].LineComment)
+	}
+
+	current := merge(inherited, comments...)
+
+	ignored[node] = current
+
+	if node.Kind == yaml.MappingNode {
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			key, value := node.Content[index], node.Content[index+1]
+
+			directives := merge(current, key.HeadComment, key.LineComment)
+
+			ignored[key] = directives
+
+			suppress(value, directives, ignored)
+		}
+
+		return
+	}
+
+	for _, child := range node.Content {
+		suppress(child, current, ignored)
+	}
+}
+
+// merge combines inherited directives with any found in comments. An empty string represents all rules.
+func merge(inherited map[string]bool, comments ...string) map[string]bool {
+	var result map[string]bool
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+			if !(strings.HasPrefix(line, Directive)) {
+				continue
+			}
+
+			if result == nil {
+				result = make(map[string]bool, len(inherited)+1)
+				for id := range inherited {
+					result[id] = true
+				}
+			}
+
+			ids := strings.FieldsFunc(strings.TrimPrefix(line, Directive), func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			})
+
+			if len(ids) == 0 {
+				result[""] = true
+			}
+
+			for _, id := range ids {
+				result[id] = true
+			}
+		}
+	}
+
+	if result == nil {
+		return inherited
+	}
+
+	return result
+}
+
+// Count returns the number of findings with the given severity.
+func Count(findings []Finding, severity Severity) (count int) {
+	for _, finding := range findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+
+	return
+}
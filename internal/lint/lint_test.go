@@ -0,0 +1,151 @@
+package lint
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// deployment is a workload that satisfies every rule.
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+    name: example
+spec:
+    template:
+        spec:
+            containers:
+                - name: example
+                  image: example:1.0.0
+                  readinessProbe: { httpGet: { path: /health, port: 8080 } }
+                  securityContext: { runAsNonRoot: true }
+                  resources:
+                      requests: { cpu: 100m, memory: 128Mi }
+                      limits: { cpu: 500m, memory: 512Mi }
+`
+
+func decode(t *testing.T, content string) []*manifests.Document {
+	t.Helper()
+
+	documents, e := manifests.Decode("test.yaml", strings.NewReader(content))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	return documents
+}
+
+// rules returns the IDs of the findings' rules.
+func rules(findings []Finding) []string {
+	var ids []string
+	for _, finding := range findings {
+		ids = append(ids, finding.Rule)
+	}
+
+	return ids
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		expected []string
+	}{
+		{
+			name:     "compliant",
+			manifest: deployment,
+		},
+		{
+			name:     "latest-image-tag",
+			manifest: strings.Replace(deployment, "example:1.0.0", "example:latest", 1),
+			expected: []string{"latest-image-tag"},
+		},
+		{
+			name:     "missing-limits",
+			manifest: strings.Replace(deployment, "limits: { cpu: 500m, memory: 512Mi }", "limits: { cpu: 500m }", 1),
+			expected: []string{"missing-resource-limits"},
+		},
+		{
+			name:     "pull-always-digest",
+			manifest: strings.Replace(deployment, "image: example:1.0.0", "image: example@sha256:0000000000000000000000000000000000000000000000000000000000000000\n                  imagePullPolicy: Always", 1),
+			expected: []string{"pull-always-digest"},
+		},
+		{
+			name:     "host-path-volume",
+			manifest: deployment + "            volumes:\n                - { name: host, hostPath: { path: /var/run } }\n",
+			expected: []string{"host-path-volume"},
+		},
+		{
+			name:     "ignored-rule",
+			manifest: strings.Replace(deployment, "image: example:1.0.0", "image: example:latest # ethr-lint:ignore latest-image-tag", 1),
+		},
+		{
+			name:     "ignored-document",
+			manifest: "# ethr-lint:ignore\n" + strings.Replace(deployment, "example:1.0.0", "example:latest", 1),
+		},
+		{
+			name:     "ignored-other-rule",
+			manifest: strings.Replace(deployment, "image: example:1.0.0", "image: example:latest # ethr-lint:ignore host-path-volume", 1),
+			expected: []string{"latest-image-tag"},
+		},
+	}
+
+	linter, e := New(nil)
+	if e != nil {
+		t.Fatalf("New() returned an unexpected error: %v", e)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			findings := linter.Lint(decode(t, test.manifest)...)
+			if ids := rules(findings); !(slices.Equal(ids, test.expected)) {
+				t.Errorf("Lint() = %v, expected %v", ids, test.expected)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	manifest := strings.Replace(deployment, "example:1.0.0", "example:latest", 1)
+
+	configuration := &Configuration{}
+	if e := configuration.Override("latest-image-tag=warning"); e != nil {
+		t.Fatalf("Override() returned an unexpected error: %v", e)
+	}
+
+	linter, e := New(configuration)
+	if e != nil {
+		t.Fatalf("New() returned an unexpected error: %v", e)
+	}
+
+	findings := linter.Lint(decode(t, manifest)...)
+	if len(findings) != 1 || findings[0].Severity != Warning {
+		t.Errorf("Lint() = %v, expected a single warning", findings)
+	}
+
+	if Count(findings, Error) != 0 {
+		t.Errorf("Count(Error) = %d, expected 0", Count(findings, Error))
+	}
+
+	if e := configuration.Override("latest-image-tag=off"); e != nil {
+		t.Fatalf("Override() returned an unexpected error: %v", e)
+	}
+
+	if linter, _ = New(configuration); len(linter.Lint(decode(t, manifest)...)) != 0 {
+		t.Errorf("Lint() with the rule disabled expected no findings")
+	}
+
+	if _, e := New(&Configuration{Rules: map[string]Severity{"unknown": Error}}); e == nil {
+		t.Errorf("New() with an unknown rule expected an error")
+	}
+}
+
+func TestConfigurationOverride(t *testing.T) {
+	for _, assignment := range []string{"latest-image-tag", "latest-image-tag=fatal"} {
+		if e := (&Configuration{}).Override(assignment); e == nil {
+			t.Errorf("Override(%q) expected an error", assignment)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Report is provided to a [Rule]'s check for recording a finding against a node and its path.
+type Report func(node *yaml.Node, path manifests.Path, message string)
+
+// Rule represents a single lint check.
+type Rule struct {
+	ID          string   // ID uniquely identifies the rule, and is used for configuration and ignore directive(s).
+	Description string   // Description summarizes what the rule checks.
+	Severity    Severity // Severity is the rule's default severity.
+
+	Check func(document *manifests.Document, report Report)
+}
+
+// Rules returns the built-in rule set.
+func Rules() []Rule {
+	return []Rule{
+		{
+			ID:          "missing-resource-requests",
+			Description: "containers should declare cpu and memory resource requests",
+			Severity:    Warning,
+			Check:       resources("requests"),
+		},
+		{
+			ID:          "missing-resource-limits",
+			Description: "containers should declare cpu and memory resource limits",
+			Severity:    Warning,
+			Check:       resources("limits"),
+		},
+		{
+			ID:          "missing-readiness-probe",
+			Description: "containers should declare a readiness probe",
+			Severity:    Warning,
+			Check: containers(func(container manifests.Container, report Report) {
+				if container.Type == "containers" && manifests.Value(container.Node, "readinessProbe") == nil {
+					report(container.Node, container.Path, fmt.Sprintf("container %q has no readiness probe", container.Name()))
+				}
+			}),
+		},
+		{
+			ID:          "latest-image-tag",
+			Description: "images should be pinned to a tag or digest other than \"latest\"",
+			Severity:    Error,
+			Check: containers(func(container manifests.Container, report Report) {
+				if image := container.Image(); image != "" && manifests.ParseImage(image).Mutable() {
+					report(manifests.Value(container.Node, "image"), container.Path.Key("image"), fmt.Sprintf("container %q uses a mutable image reference (%s)", container.Name(), image))
+				}
+			}),
+		},
+		{
+			ID:          "pull-always-digest",
+			Description: "digest-pinned images shouldn't use \"imagePullPolicy: Always\"",
+			Severity:    Info,
+			Check: containers(func(container manifests.Container, report Report) {
+				if manifests.ParseImage(container.Image()).Digest != "" && manifests.Scalar(container.Node, "imagePullPolicy") == "Always" {
+					report(manifests.Value(container.Node, "imagePullPolicy"), container.Path.Key("imagePullPolicy"), fmt.Sprintf("container %q pulls a digest-pinned image on every start", container.Name()))
+				}
+			}),
+		},
+		{
+			ID:          "missing-security-context",
+			Description: "containers should declare a security context",
+			Severity:    Warning,
+			Check: containers(func(container manifests.Container, report Report) {
+				if manifests.Value(container.Node, "securityContext") == nil {
+					report(container.Node, container.Path, fmt.Sprintf("container %q has no security context", container.Name()))
+				}
+			}),
+		},
+		{
+			ID:          "host-path-volume",
+			Description: "pods shouldn't mount hostPath volumes",
+			Severity:    Error,
+			Check: func(document *manifests.Document, report Report) {
+				spec, path := document.PodSpec()
+				for index, volume := range manifests.Items(manifests.Value(spec, "volumes")) {
+					if node := manifests.Value(volume, "hostPath"); node != nil {
+						report(node, path.Key("volumes").Index(index).Key("hostPath"), fmt.Sprintf("volume %q mounts a host path (%s)", manifests.Scalar(volume, "name"), manifests.Scalar(node, "path")))
+					}
+				}
+			},
+		},
+	}
+}
+
+// containers adapts a per-container check into a [Rule] check.
+func containers(check func(container manifests.Container, report Report)) func(document *manifests.Document, report Report) {
+	return func(document *manifests.Document, report Report) {
+		for _, container := range document.Containers() {
+			check(container, report)
+		}
+	}
+}
+
+// resources creates a check that verifies a container declares both cpu and memory for field ("requests" or "limits").
+func resources(field string) func(document *manifests.Document, report Report) {
+	return containers(func(container manifests.Container, report Report) {
+		if container.Type == "ephemeralContainers" {
+			return
+		}
+
+		node := manifests.Lookup(container.Node, "resources", field)
+
+		var missing []string
+		for _, resource := range []string{"cpu", "memory"} {
+			if manifests.Value(node, resource) == nil {
+				missing = append(missing, resource)
+			}
+		}
+
+		// --> report against the most specific node available so that ignore directive(s) can target it
+		target := container.Node
+		if resources := manifests.Value(container.Node, "resources"); resources != nil {
+			target = resources
+		}
+
+		if node != nil {
+			target = node
+		}
+
+		if len(missing) > 0 {
+			report(target, container.Path.Key("resources").Key(field), fmt.Sprintf("container %q is missing resource %s: %s", container.Name(), field, strings.Join(missing, ", ")))
+		}
+	})
+}
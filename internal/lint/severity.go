@@ -0,0 +1,41 @@
+package lint
+
+import (
+	"errors"
+)
+
+// Severity string that implements Cobra's Type interface for valid string enumeration values.
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+	Info    Severity = "info"
+	Off     Severity = "off"
+)
+
+// String is used both by fmt.Print and by Cobra in help text
+func (s *Severity) String() string {
+	return string(*s)
+}
+
+// Set must have pointer receiver so it doesn't change the value of a copy
+func (s *Severity) Set(v string) error {
+	switch v {
+	case "error", "warning", "info", "off":
+		*s = Severity(v)
+		return nil
+	default:
+		return errors.New("must be one of \"error\", \"warning\", \"info\" or \"off\"")
+	}
+}
+
+// Type is only used in help text
+func (s *Severity) Type() string {
+	return "[\"error\"|\"warning\"|\"info\"|\"off\"]"
+}
+
+// UnmarshalText allows a [Severity] to be decoded from configuration file(s).
+func (s *Severity) UnmarshalText(text []byte) error {
+	return s.Set(string(text))
+}
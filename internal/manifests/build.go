@@ -0,0 +1,143 @@
+package manifests
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Object builds an ordered mapping node from alternating key and value pairs. Values are converted via [Node]; nil
+// values, empty strings, and empty collections are omitted so optional fields can be passed unconditionally.
+func Object(pairs ...interface{}) *yaml.Node {
+	if len(pairs)%2 != 0 {
+		panic("manifests.Object: odd number of key-value arguments")
+	}
+
+	mapping := Mapping()
+	for index := 0; index < len(pairs); index += 2 {
+		key, valid := pairs[index].(string)
+		if !(valid) {
+			panic(fmt.Sprintf("manifests.Object: key %v isn't a string", pairs[index]))
+		}
+
+		if value := Node(pairs[index+1]); value != nil {
+			mapping.Content = append(mapping.Content, String(key), value)
+		}
+	}
+
+	return mapping
+}
+
+// List builds a sequence node, omitting nil item(s).
+func List(items ...*yaml.Node) *yaml.Node {
+	sequence := Sequence()
+	for _, item := range items {
+		if item != nil {
+			sequence.Content = append(sequence.Content, item)
+		}
+	}
+
+	return sequence
+}
+
+// Node converts a go value into a [yaml.Node], returning nil for nil and empty value(s). Maps are encoded with
+// sorted keys so that output is deterministic.
+func Node(value interface{}) *yaml.Node {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *yaml.Node:
+		if v == nil || ((v.Kind == yaml.MappingNode || v.Kind == yaml.SequenceNode) && len(v.Content) == 0 && v.Style != yaml.FlowStyle) {
+			return nil
+		}
+
+		return v
+	case string:
+		if v == "" {
+			return nil
+		}
+
+		return String(v)
+	case int:
+		return Integer(v)
+	case int32:
+		return Integer(int(v))
+	case int64:
+		return Integer(int(v))
+	case *int:
+		if v == nil {
+			return nil
+		}
+
+		return Integer(*v)
+	case bool:
+		return Boolean(v)
+	case *bool:
+		if v == nil {
+			return nil
+		}
+
+		return Boolean(*v)
+	case []string:
+		if len(v) == 0 {
+			return nil
+		}
+
+		sequence := Sequence()
+		for _, item := range v {
+			sequence.Content = append(sequence.Content, String(item))
+		}
+
+		return sequence
+	case []*yaml.Node:
+		if len(v) == 0 {
+			return nil
+		}
+
+		return List(v...)
+	case map[string]string:
+		if len(v) == 0 {
+			return nil
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		mapping := Mapping()
+		for _, key := range keys {
+			mapping.Content = append(mapping.Content, String(key), String(v[key]))
+		}
+
+		return mapping
+	default:
+		node, e := Encode(v)
+		if e != nil {
+			panic(fmt.Sprintf("manifests.Node: unable to encode %T: %v", value, e))
+		}
+
+		return node
+	}
+}
+
+// Empty creates an explicitly empty mapping node (i.e. "{}"), which unlike [Mapping] isn't omitted by [Object].
+func Empty() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Style: yaml.FlowStyle}
+}
+
+// Resource builds the top-level document node of a kubernetes resource from its apiVersion, kind, metadata, and any
+// additional ordered key and value pairs (e.g. "spec").
+func Resource(apiVersion, kind string, metadata *yaml.Node, pairs ...interface{}) *yaml.Node {
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{
+		Object(append([]interface{}{"apiVersion", apiVersion, "kind", kind, "metadata", metadata}, pairs...)...),
+	}}
+}
+
+// Metadata builds an object's metadata from its name, namespace, labels and annotations.
+func Metadata(name, namespace string, labels, annotations map[string]string) *yaml.Node {
+	return Object("name", name, "namespace", namespace, "labels", labels, "annotations", annotations)
+}
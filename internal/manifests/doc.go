@@ -0,0 +1,3 @@
+// Package manifests provides reading, traversal, and writing of multi-document kubernetes manifest streams. Documents
+// are retained as [yaml.Node] trees so that comments and ordering survive in-place modification(s).
+package manifests
@@ -0,0 +1,137 @@
+package manifests
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document represents a single document of a manifest stream.
+type Document struct {
+	File   string     // File is the path the document was read from ("-" for standard-input).
+	Index  int        // Index is the document's zero-based position within its file.
+	Node   *yaml.Node // Node is the document's [yaml.DocumentNode].
+	Indent int        // Indent is the indentation of the document's source, retained when the document is re-encoded.
+
+	source *source // source is the content the document was decoded from, shared by its stream's document(s).
+}
+
+// source is the content of a decoded stream, retained so that its document(s) can be written without re-encoding.
+type source struct {
+	content []byte
+}
+
+// Root returns the document's top-level node, or nil if the document is empty.
+func (d *Document) Root() *yaml.Node {
+	if d == nil || d.Node == nil {
+		return nil
+	}
+
+	if d.Node.Kind == yaml.DocumentNode {
+		if len(d.Node.Content) == 0 {
+			return nil
+		}
+
+		return d.Node.Content[0]
+	}
+
+	return d.Node
+}
+
+// Empty reports whether the document has no content (e.g. a stream consisting only of comments).
+func (d *Document) Empty() bool {
+	root := d.Root()
+
+	return root == nil || (root.Kind == yaml.ScalarNode && root.Tag == "!!null")
+}
+
+// APIVersion returns the document's "apiVersion" field.
+func (d *Document) APIVersion() string {
+	return Scalar(d.Root(), "apiVersion")
+}
+
+// Kind returns the document's "kind" field.
+func (d *Document) Kind() string {
+	return Scalar(d.Root(), "kind")
+}
+
+// Name returns the document's "metadata.name" field.
+func (d *Document) Name() string {
+	return Scalar(d.Root(), "metadata", "name")
+}
+
+// Namespace returns the document's "metadata.namespace" field.
+func (d *Document) Namespace() string {
+	return Scalar(d.Root(), "metadata", "namespace")
+}
+
+// Group returns the API group of the document's "apiVersion" - an empty string for the core group.
+func (d *Document) Group() string {
+	group, _ := Split(d.APIVersion())
+
+	return group
+}
+
+// Identity uniquely identifies the resource a document describes by its group, kind, namespace, and name (e.g.
+// "apps/Deployment/default/example"). The version is excluded, as it doesn't change the resource's identity.
+func (d *Document) Identity() string {
+	return fmt.Sprintf("%s/%s/%s/%s", d.Group(), d.Kind(), d.Namespace(), d.Name())
+}
+
+// Labels returns the document's "metadata.labels".
+func (d *Document) Labels() map[string]string {
+	return Map(Lookup(d.Root(), "metadata", "labels"))
+}
+
+// Blank reports whether the document is empty and has no comment(s) worth retaining.
+func (d *Document) Blank() bool {
+	if !(d.Empty()) {
+		return false
+	}
+
+	var commented func(node *yaml.Node) bool
+	commented = func(node *yaml.Node) bool {
+		if node == nil {
+			return false
+		}
+
+		if node.HeadComment != "" || node.LineComment != "" || node.FootComment != "" {
+			return true
+		}
+
+		for _, child := range node.Content {
+			if commented(child) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return !(commented(d.Node))
+}
+
+// Kustomization reports whether the document is a kustomize Kustomization or Component rather than a kubernetes resource.
+func (d *Document) Kustomization() bool {
+	return d.Group() == "kustomize.config.k8s.io"
+}
+
+// String returns a human-readable reference to the document (e.g. "deployment/example").
+func (d *Document) String() string {
+	return Reference(d.Kind(), d.Name())
+}
+
+// Location returns the document's source location (e.g. "application.yaml[2]").
+func (d *Document) Location() string {
+	return fmt.Sprintf("%s[%d]", d.File, d.Index)
+}
+
+// Decode unmarshals the document into v.
+func (d *Document) Decode(v interface{}) error {
+	root := d.Root()
+	if root == nil {
+		return nil
+	}
+
+	return root.Decode(v)
+}
@@ -0,0 +1,69 @@
+package manifests
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Change describes the modification(s) made to a single container.
+type Change struct {
+	File      string   `json:"file" yaml:"file"`
+	Resource  string   `json:"resource" yaml:"resource"`
+	Container string   `json:"container" yaml:"container"`
+	Fields    []string `json:"fields" yaml:"fields"`
+}
+
+// Mutation modifies a container's mapping node, returning a description of each changed field (e.g. a variable's name).
+type Mutation func(container *yaml.Node) ([]string, error)
+
+// Edit applies mutation to every selected container of the manifest file(s) found at path(s), saving modified files
+// in place. When test is true, or a path is [Stdin], the resulting document(s) are written to writer instead.
+func Edit(writer io.Writer, test bool, selector *Selector, mutation Mutation, paths ...string) ([]Change, error) {
+	files, e := Files(paths...)
+	if e != nil {
+		return nil, e
+	}
+
+	var changes []Change
+	for _, file := range files {
+		documents, e := File(file)
+		if e != nil {
+			return nil, e
+		}
+
+		var modified bool
+		for _, document := range documents {
+			if document.Empty() {
+				continue
+			}
+
+			for _, container := range selector.Containers(document) {
+				fields, e := mutation(container.Node)
+				if e != nil {
+					return nil, fmt.Errorf("%s: %s (%s): %w", document.Location(), document.String(), container.Name(), e)
+				}
+
+				if len(fields) > 0 {
+					changes = append(changes, Change{File: file, Resource: document.String(), Container: container.Name(), Fields: fields})
+
+					modified = true
+				}
+			}
+		}
+
+		switch {
+		case test || file == Stdin:
+			if e := Write(writer, Nodes(documents...)...); e != nil {
+				return nil, e
+			}
+		case modified:
+			if e := Save(file, documents...); e != nil {
+				return nil, e
+			}
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,47 @@
+package manifests
+
+import (
+	"strings"
+)
+
+// Image represents a parsed container image reference (e.g. "registry.io:5000/service:1.0.0@sha256:...").
+type Image struct {
+	Name   string // Name is the image's repository, including any registry (e.g. "registry.io:5000/service").
+	Tag    string // Tag is the image's tag, or an empty string if unspecified.
+	Digest string // Digest is the image's digest (e.g. "sha256:..."), or an empty string if unspecified.
+}
+
+// ParseImage separates an image reference into its name, tag, and digest.
+func ParseImage(reference string) Image {
+	var image Image
+
+	reference, image.Digest, _ = strings.Cut(reference, "@")
+
+	// --> a colon within the final path segment denotes a tag; otherwise it belongs to a registry's port
+	if index := strings.LastIndex(reference, ":"); index > strings.LastIndex(reference, "/") {
+		reference, image.Tag = reference[:index], reference[index+1:]
+	}
+
+	image.Name = reference
+
+	return image
+}
+
+// Mutable reports whether the image reference can change over time - i.e. it isn't pinned by digest, and its tag is
+// either unspecified or "latest".
+func (i Image) Mutable() bool {
+	return i.Digest == "" && (i.Tag == "" || i.Tag == "latest")
+}
+
+func (i Image) String() string {
+	reference := i.Name
+	if i.Tag != "" {
+		reference += ":" + i.Tag
+	}
+
+	if i.Digest != "" {
+		reference += "@" + i.Digest
+	}
+
+	return reference
+}
@@ -0,0 +1,254 @@
+package manifests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kustomizations are the file names recognized as a kustomization, in order of precedence.
+var Kustomizations = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// Kustomization resolves path to a kustomization file - path may be either the file or its directory.
+func Kustomization(path string) (string, error) {
+	information, e := os.Stat(path)
+	if e != nil {
+		return "", fmt.Errorf("unable to locate kustomization: %w", e)
+	}
+
+	if !(information.IsDir()) {
+		return path, nil
+	}
+
+	for _, name := range Kustomizations {
+		candidate := filepath.Join(path, name)
+		if _, e := os.Stat(candidate); e == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no kustomization file found in %s", path)
+}
+
+// Register adds resource (a file path) to a kustomization's "resources", preserving the kustomization's comments
+// and formatting. The resource is recorded relative to the kustomization's directory. Register reports whether
+// the kustomization was modified.
+func Register(kustomization, resource string) (bool, error) {
+	return edit(kustomization, resource, "resources", func(item *yaml.Node, relative string) bool {
+		return filepath.Clean(item.Value) == filepath.Clean(relative)
+	}, func(relative string) *yaml.Node {
+		return String(relative)
+	})
+}
+
+// RegisterPatch adds patch (a file path) to a kustomization's "patches" as a "path" entry. As with [Register], the
+// kustomization's comments and formatting are preserved, and RegisterPatch reports whether it was modified.
+func RegisterPatch(kustomization, patch string) (bool, error) {
+	return edit(kustomization, patch, "patches", func(item *yaml.Node, relative string) bool {
+		path := Scalar(item, "path")
+
+		return path != "" && filepath.Clean(path) == filepath.Clean(relative)
+	}, func(relative string) *yaml.Node {
+		return Object("path", relative)
+	})
+}
+
+// edit resolves file relative to the kustomization's directory and, unless an item of the kustomization's key
+// already references it (according to exists), adds the file's entry to the key's sequence.
+//
+// The entry is spliced into the kustomization's content, rather than re-encoding the kustomization, so that the
+// rest of the file is left untouched. Only a flow-style sequence (e.g. "resources: []") requires re-encoding.
+func edit(kustomization, file, key string, exists func(item *yaml.Node, relative string) bool, entry func(relative string) *yaml.Node) (bool, error) {
+	path, e := Kustomization(kustomization)
+	if e != nil {
+		return false, e
+	}
+
+	directory, e := filepath.Abs(filepath.Dir(path))
+	if e != nil {
+		return false, e
+	}
+
+	absolute, e := filepath.Abs(file)
+	if e != nil {
+		return false, e
+	}
+
+	relative, e := filepath.Rel(directory, absolute)
+	if e != nil {
+		return false, e
+	}
+
+	relative = filepath.ToSlash(relative)
+
+	content, e := os.ReadFile(path)
+	if e != nil {
+		return false, fmt.Errorf("unable to read kustomization: %w", e)
+	}
+
+	var document yaml.Node
+	if e := yaml.Unmarshal(content, &document); e != nil {
+		return false, fmt.Errorf("unable to unmarshal kustomization: %w", e)
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return false, errors.New("invalid kustomization - expecting a mapping")
+	}
+
+	root := document.Content[0]
+
+	name, value := Pair(root, key)
+	if value != nil && value.Kind == yaml.SequenceNode {
+		for _, item := range value.Content {
+			if exists(item, relative) {
+				return false, nil
+			}
+		}
+	}
+
+	indent := Indentation(content)
+
+	item, e := block(entry(relative), indent)
+	if e != nil {
+		return false, e
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var modified []string
+	switch {
+	case value == nil:
+		// --> add the key to the end of the kustomization
+		modified = append(lines, key+":\n", sequence(item, strings.Repeat(" ", indent)+"- "))
+		if len(lines) > 0 && !(strings.HasSuffix(lines[len(lines)-1], "\n")) {
+			modified[len(lines)-1] += "\n"
+		}
+	case value.Kind == yaml.ScalarNode && value.Tag == "!!null" && value.Value == "" && value.Line == name.Line:
+		// --> an empty value (e.g. "resources:") is replaced by the sequence
+		prefix := strings.Repeat(" ", name.Column-1+indent) + "- "
+
+		modified = splice(lines, name.Line, sequence(item, prefix))
+	case value.Kind == yaml.SequenceNode && value.Style&yaml.FlowStyle == 0 && len(value.Content) > 0:
+		last := value.Content[len(value.Content)-1]
+
+		// --> the entry is aligned with the last item - both its "- " prefix, and the item's content
+		line := lines[last.Line-1]
+		dash := strings.LastIndex(line[:last.Column-1], "-")
+		if dash < 0 {
+			return false, fmt.Errorf("unable to locate the last %s item (line %d)", key, last.Line)
+		}
+
+		prefix := line[:dash] + "-" + strings.Repeat(" ", last.Column-dash-2)
+
+		modified = splice(lines, end(lines, last.Line, dash), sequence(item, prefix))
+	default:
+		if e := reencode(path, &document, indent, key, entry(relative)); e != nil {
+			return false, e
+		}
+
+		return true, nil
+	}
+
+	return true, os.WriteFile(path, []byte(strings.Join(modified, "")), 0o644)
+}
+
+// block encodes a node as block-style yaml.
+func block(node *yaml.Node, indent int) (string, error) {
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(indent)
+	if e := encoder.Encode(node); e != nil {
+		return "", fmt.Errorf("unable to marshal kustomization entry: %w", e)
+	}
+
+	if e := encoder.Close(); e != nil {
+		return "", e
+	}
+
+	return buffer.String(), nil
+}
+
+// sequence formats encoded content as a sequence item - the first line is preceded by prefix (e.g. "  - "), and any
+// subsequent line(s) are aligned with it.
+func sequence(content, prefix string) string {
+	lines := strings.SplitAfter(strings.TrimSuffix(content, "\n"), "\n")
+	for index := range lines {
+		if index == 0 {
+			lines[index] = prefix + lines[index]
+		} else {
+			lines[index] = strings.Repeat(" ", len(prefix)) + lines[index]
+		}
+	}
+
+	return strings.Join(lines, "") + "\n"
+}
+
+// splice inserts text after the given (1-based) line.
+func splice(lines []string, line int, text string) []string {
+	modified := make([]string, 0, len(lines)+1)
+	modified = append(modified, lines[:line]...)
+	if line > 0 && !(strings.HasSuffix(modified[line-1], "\n")) {
+		modified[line-1] += "\n"
+	}
+
+	modified = append(modified, text)
+
+	return append(modified, lines[line:]...)
+}
+
+// end returns the (1-based) last line of the sequence item that starts on line, whose "- " is at column dash - the
+// item continues through every following line that's indented beyond its dash. Trailing blank and comment lines
+// aren't considered part of the item.
+func end(lines []string, line, dash int) int {
+	last := line
+	for index := line; index < len(lines); index++ {
+		trimmed := strings.TrimSpace(lines[index])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if len(lines[index])-len(strings.TrimLeft(lines[index], " ")) <= dash {
+			break
+		}
+
+		last = index + 1
+	}
+
+	return last
+}
+
+// reencode adds an entry to the kustomization's key, re-encoding the kustomization with its indentation.
+func reencode(path string, document *yaml.Node, indent int, key string, entry *yaml.Node) error {
+	root := document.Content[0]
+
+	value := Value(root, key)
+	if value == nil || value.Kind != yaml.SequenceNode {
+		value = Sequence()
+		Set(root, key, value)
+	}
+
+	value.Content = append(value.Content, entry)
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(indent)
+	if e := encoder.Encode(document); e != nil {
+		return fmt.Errorf("unable to marshal kustomization: %w", e)
+	}
+
+	if e := encoder.Close(); e != nil {
+		return e
+	}
+
+	return os.WriteFile(path, buffer.Bytes(), 0o644)
+}
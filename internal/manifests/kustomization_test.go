@@ -0,0 +1,122 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		modified bool
+	}{
+		{
+			name:     "four-space-indentation",
+			content:  "# kustomization\napiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n    - deployment.yaml # workload\nimages:\n    -   name: example\n        newTag: 1.0.0\n",
+			expected: "# kustomization\napiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n    - deployment.yaml # workload\n    - hpa.yaml\nimages:\n    -   name: example\n        newTag: 1.0.0\n",
+			modified: true,
+		},
+		{
+			name:     "compact-sequence",
+			content:  "resources:\n- deployment.yaml\n\n# trailing comment\n",
+			expected: "resources:\n- deployment.yaml\n- hpa.yaml\n\n# trailing comment\n",
+			modified: true,
+		},
+		{
+			name:     "missing-key",
+			content:  "namespace: example\nlabels:\n  - pairs: { app: example }",
+			expected: "namespace: example\nlabels:\n  - pairs: { app: example }\nresources:\n  - hpa.yaml\n",
+			modified: true,
+		},
+		{
+			name:     "empty-key",
+			content:  "resources:\nnamespace: example\n",
+			expected: "resources:\n    - hpa.yaml\nnamespace: example\n",
+			modified: true,
+		},
+		{
+			name:     "flow-sequence",
+			content:  "resources: []\n",
+			expected: "resources: [hpa.yaml]\n",
+			modified: true,
+		},
+		{
+			name:     "registered",
+			content:  "resources:\n    - ./hpa.yaml\n",
+			expected: "resources:\n    - ./hpa.yaml\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			directory := t.TempDir()
+
+			path := filepath.Join(directory, "kustomization.yaml")
+			if e := os.WriteFile(path, []byte(test.content), 0o644); e != nil {
+				t.Fatalf("unable to write kustomization: %v", e)
+			}
+
+			modified, e := Register(directory, filepath.Join(directory, "hpa.yaml"))
+			if e != nil {
+				t.Fatalf("Register() returned an unexpected error: %v", e)
+			}
+
+			if modified != test.modified {
+				t.Errorf("Register() = %t, expected %t", modified, test.modified)
+			}
+
+			if content, _ := os.ReadFile(path); string(content) != test.expected {
+				t.Errorf("kustomization =\n%s\nexpected:\n%s", content, test.expected)
+			}
+		})
+	}
+}
+
+func TestRegisterPatch(t *testing.T) {
+	directory := t.TempDir()
+
+	path := filepath.Join(directory, "kustomization.yaml")
+
+	content := "patches:\n    -   path: replicas.yaml\n        target:\n            kind: Deployment\nresources:\n    - deployment.yaml\n"
+	if e := os.WriteFile(path, []byte(content), 0o644); e != nil {
+		t.Fatalf("unable to write kustomization: %v", e)
+	}
+
+	for _, expected := range []bool{true, false} {
+		if modified, e := RegisterPatch(path, filepath.Join(directory, "patches", "pull-secret.yaml")); e != nil || modified != expected {
+			t.Errorf("RegisterPatch() = (%t, %v), expected %t", modified, e, expected)
+		}
+	}
+
+	expected := "patches:\n    -   path: replicas.yaml\n        target:\n            kind: Deployment\n    -   path: patches/pull-secret.yaml\nresources:\n    - deployment.yaml\n"
+	if content, _ := os.ReadFile(path); string(content) != expected {
+		t.Errorf("kustomization =\n%s\nexpected:\n%s", content, expected)
+	}
+}
+
+func TestIndentation(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected int
+	}{
+		{name: "two-spaces", content: "metadata:\n  name: example\n", expected: 2},
+		{name: "four-space-sequence", content: "resources:\n    - a.yaml\n", expected: 4},
+		{name: "sequence-item-mapping", content: "images:\n-   name: example\n    newTag: 1.0.0\nspec:\n      replicas: 1\n", expected: 6},
+		{name: "yaml-encoder-sequence", content: "kind: Kustomization\nimages:\n    - name: example\n      newTag: 1.0.0\n", expected: 4},
+		{name: "compact-sequence", content: "resources:\n- a.yaml\n", expected: Indent},
+		{name: "flat", content: "name: example\n", expected: Indent},
+		{name: "subsequent-document", content: "name: example\n---\nmetadata:\n   name: example\n", expected: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if indentation := Indentation([]byte(test.content)); indentation != test.expected {
+				t.Errorf("Indentation() = %d, expected %d", indentation, test.expected)
+			}
+		})
+	}
+}
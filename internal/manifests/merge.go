@@ -0,0 +1,52 @@
+package manifests
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Merge concatenates documents into a single stream. Identical resources - the same [Document.Identity] and content,
+// regardless of comments or formatting - are only retained once. Resources that share an identity but differ are
+// conflicts, which are all reported as a single error.
+func Merge(documents ...*Document) ([]*Document, error) {
+	var merged []*Document
+
+	var conflicts []error
+
+	seen := make(map[string]*Document)
+	for _, document := range documents {
+		if document.Empty() || document.Kind() == "" {
+			merged = append(merged, document)
+			continue
+		}
+
+		identity := document.Identity()
+
+		previous, exists := seen[identity]
+		if !(exists) {
+			seen[identity] = document
+			merged = append(merged, document)
+			continue
+		}
+
+		var left, right interface{}
+		if e := previous.Decode(&left); e != nil {
+			return nil, fmt.Errorf("%s: %w", previous.Location(), e)
+		}
+
+		if e := document.Decode(&right); e != nil {
+			return nil, fmt.Errorf("%s: %w", document.Location(), e)
+		}
+
+		if !(reflect.DeepEqual(left, right)) {
+			conflicts = append(conflicts, fmt.Errorf("conflicting definitions of %s: %s and %s", identity, previous.Location(), document.Location()))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, errors.Join(conflicts...)
+	}
+
+	return merged, nil
+}
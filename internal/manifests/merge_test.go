@@ -0,0 +1,41 @@
+package manifests
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	decode := func(file, content string) []*Document {
+		documents, e := Decode(file, strings.NewReader(content))
+		if e != nil {
+			t.Fatalf("unable to decode %s: %v", file, e)
+		}
+
+		return documents
+	}
+
+	first := decode("first.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata: { name: example }\ndata: { key: value }\n---\n# comment only\n")
+	duplicate := decode("second.yaml", "# formatted differently\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: example\ndata:\n  key: value # comment\n")
+	namespaced := decode("third.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata: { name: example, namespace: other }\ndata: { key: other }\n")
+	conflict := decode("fourth.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata: { name: example }\ndata: { key: other }\n")
+
+	merged, e := Merge(append(append(first, duplicate...), namespaced...)...)
+	if e != nil {
+		t.Fatalf("Merge() returned an unexpected error: %v", e)
+	}
+
+	var locations []string
+	for _, document := range merged {
+		locations = append(locations, document.Location())
+	}
+
+	if expected := "first.yaml[0],first.yaml[1],third.yaml[0]"; strings.Join(locations, ",") != expected {
+		t.Errorf("Merge() = %v, expected %s", locations, expected)
+	}
+
+	_, e = Merge(append(append(first, conflict...), conflict...)...)
+	if e == nil || !(strings.Contains(e.Error(), "first.yaml[0] and fourth.yaml[0]")) {
+		t.Errorf("Merge() of conflicting definitions = %v, expected an error locating both", e)
+	}
+}
@@ -0,0 +1,242 @@
+package manifests
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lookup traverses mapping node(s) by key(s), returning nil if any key isn't found.
+func Lookup(node *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		node = Value(node, key)
+		if node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+// Value returns the value of a mapping node's key, or nil if either node isn't a mapping or the key doesn't exist.
+func Value(node *yaml.Node, key string) *yaml.Node {
+	_, value := Pair(node, key)
+
+	return value
+}
+
+// Pair returns both the key and value node(s) of a mapping node's key.
+func Pair(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil {
+		return nil, nil
+	}
+
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		if node.Content[index].Value == key {
+			return node.Content[index], node.Content[index+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// Scalar returns the string value of the scalar found at key(s), or an empty string.
+func Scalar(node *yaml.Node, keys ...string) string {
+	node = Lookup(node, keys...)
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		return ""
+	}
+
+	return node.Value
+}
+
+// Map converts a mapping node of scalars into a map.
+func Map(node *yaml.Node) map[string]string {
+	mapping := make(map[string]string)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return mapping
+	}
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		mapping[node.Content[index].Value] = node.Content[index+1].Value
+	}
+
+	return mapping
+}
+
+// Items returns the content of a sequence node, or nil.
+func Items(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
+
+// Keys returns the ordered keys of a mapping node.
+func Keys(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	keys := make([]string, 0, len(node.Content)/2)
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		keys = append(keys, node.Content[index].Value)
+	}
+
+	return keys
+}
+
+// Set assigns value to a mapping node's key, appending the key if it doesn't exist.
+func Set(node *yaml.Node, key string, value *yaml.Node) {
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		if node.Content[index].Value == key {
+			node.Content[index+1] = value
+			return
+		}
+	}
+
+	node.Content = append(node.Content, String(key), value)
+}
+
+// Delete removes a mapping node's key, and reports whether it was found.
+func Delete(node *yaml.Node, key string) bool {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return false
+	}
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		if node.Content[index].Value == key {
+			node.Content = append(node.Content[:index], node.Content[index+2:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ensure traverses mapping node(s) by key(s), creating empty mapping(s) for any key that doesn't exist.
+func Ensure(node *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		value := Value(node, key)
+		if value == nil || value.Tag == "!!null" {
+			value = Mapping()
+			Set(node, key, value)
+		}
+
+		node = value
+	}
+
+	return node
+}
+
+// String creates a scalar string node. Values that would otherwise be resolved as another type (e.g. "true", "8080"),
+// including by YAML 1.1 parsers such as kubectl's (e.g. "yes", "0755"), are explicitly tagged so they're quoted when
+// encoded.
+func String(value string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+
+	var resolved interface{}
+	if e := yaml.Unmarshal([]byte(value), &resolved); e != nil {
+		node.Style = yaml.DoubleQuotedStyle
+	} else if _, valid := resolved.(string); !(valid) || value == "" || Ambiguous(value) {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+
+	node.Tag = "!!str"
+
+	return node
+}
+
+// booleans are the plain scalars YAML 1.1 resolves as booleans, but YAML 1.2 resolves as strings.
+var booleans = []string{"y", "Y", "yes", "Yes", "YES", "n", "N", "no", "No", "NO", "on", "On", "ON", "off", "Off", "OFF"}
+
+// numbers matches the plain scalars YAML 1.1 resolves as numbers that YAML 1.2 resolves differently - base 60 integers
+// and floats (e.g. "1:30"), and zero-prefixed integers, which YAML 1.1 reads as octal (e.g. "0755").
+var numbers = regexp.MustCompile(`^[-+]?([0-9][0-9_]*(:[0-5]?[0-9])+(\.[0-9_]*)?|0[0-9_]+)$`)
+
+// Ambiguous reports whether YAML 1.1 parsers, such as kubectl's, resolve a plain scalar of value differently than YAML
+// 1.2 parsers, including this package's (e.g. "yes", "on", "0755").
+func Ambiguous(value string) bool {
+	return slices.Contains(booleans, value) || numbers.MatchString(value)
+}
+
+// Integer creates a scalar integer node.
+func Integer(value int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: fmt.Sprintf("%d", value)}
+}
+
+// Boolean creates a scalar boolean node.
+func Boolean(value bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprintf("%t", value)}
+}
+
+// Mapping creates an empty mapping node.
+func Mapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// Sequence creates a sequence node of the given item(s).
+func Sequence(items ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: items}
+}
+
+// Encode converts any go value into a [yaml.Node].
+func Encode(v interface{}) (*yaml.Node, error) {
+	var node yaml.Node
+	if e := node.Encode(v); e != nil {
+		return nil, e
+	}
+
+	return &node, nil
+}
+
+// Split separates an "apiVersion" into its group and version.
+func Split(apiVersion string) (group, version string) {
+	if index := strings.LastIndex(apiVersion, "/"); index >= 0 {
+		return apiVersion[:index], apiVersion[index+1:]
+	}
+
+	return "", apiVersion
+}
+
+// Reference formats a kind and name into their conventional, "kubectl"-style reference (e.g. "deployment/example").
+func Reference(kind, name string) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(kind), name)
+}
+
+// Target parses a "kind/name" reference.
+func Target(reference string) (kind, name string, e error) {
+	partials := strings.SplitN(reference, "/", 2)
+	if len(partials) != 2 || partials[0] == "" || partials[1] == "" {
+		return "", "", fmt.Errorf("invalid reference - expecting \"<kind>/<name>\": %s", reference)
+	}
+
+	return partials[0], partials[1], nil
+}
+
+// Copy returns a deep copy of node.
+func Copy(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	duplicate := *node
+	duplicate.Content = make([]*yaml.Node, len(node.Content))
+	for index, child := range node.Content {
+		duplicate.Content[index] = Copy(child)
+	}
+
+	return &duplicate
+}
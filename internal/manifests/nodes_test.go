@@ -0,0 +1,38 @@
+package manifests
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestString(t *testing.T) {
+	tests := map[string]string{
+		"example":    "example\n",
+		"":           "\"\"\n",
+		"true":       "\"true\"\n",
+		"8080":       "\"8080\"\n",
+		"1.5":        "\"1.5\"\n",
+		"null":       "\"null\"\n",
+		"yes":        "\"yes\"\n",
+		"on":         "\"on\"\n",
+		"Off":        "\"Off\"\n",
+		"y":          "\"y\"\n",
+		"0755":       "\"0755\"\n",
+		"1:30":       "\"1:30\"\n",
+		"yesterday":  "yesterday\n",
+		"10.0.0.1":   "10.0.0.1\n",
+		"example:80": "example:80\n",
+	}
+
+	for value, expected := range tests {
+		content, e := yaml.Marshal(String(value))
+		if e != nil {
+			t.Fatalf("unable to marshal String(%q): %v", value, e)
+		}
+
+		if string(content) != expected {
+			t.Errorf("String(%q) = %q, expected %q", value, content, expected)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package manifests
+
+import (
+	"sort"
+)
+
+// order ranks kinds by the sequence they can be safely applied in: namespaces, custom resource definitions, and
+// cluster-scoped prerequisites first, then service-accounts and rbac, configuration, services, and workloads - with
+// the resources that reference workloads, and admission webhooks, last.
+var order = map[string]int{
+	"Namespace":     0,
+	"ResourceQuota": 1,
+	"LimitRange":    1,
+
+	"CustomResourceDefinition": 2,
+
+	"PriorityClass":     3,
+	"StorageClass":      3,
+	"IngressClass":      3,
+	"RuntimeClass":      3,
+	"PersistentVolume":  3,
+	"PodSecurityPolicy": 3,
+
+	"ServiceAccount":     4,
+	"ClusterRole":        5,
+	"ClusterRoleList":    5,
+	"Role":               5,
+	"RoleList":           5,
+	"ClusterRoleBinding": 6,
+	"RoleBinding":        6,
+
+	"ConfigMap":             7,
+	"Secret":                7,
+	"PersistentVolumeClaim": 8,
+
+	"Service":   9,
+	"Endpoints": 9,
+
+	"DaemonSet":             10,
+	"Pod":                   10,
+	"ReplicationController": 10,
+	"ReplicaSet":            10,
+	"Deployment":            10,
+	"StatefulSet":           10,
+	"Job":                   10,
+	"CronJob":               10,
+
+	"HorizontalPodAutoscaler": 11,
+	"PodDisruptionBudget":     11,
+	"NetworkPolicy":           11,
+	"Ingress":                 11,
+
+	"APIService":                     13,
+	"MutatingWebhookConfiguration":   13,
+	"ValidatingWebhookConfiguration": 13,
+}
+
+// unknown is the rank of kinds without an explicit order (e.g. custom resources) - after workloads, as their
+// definitions and controllers must exist first.
+const unknown = 12
+
+// Rank returns a kind's position within the apply order. Lower ranks are applied first.
+func Rank(kind string) int {
+	if rank, valid := order[kind]; valid {
+		return rank
+	}
+
+	return unknown
+}
+
+// Sort stably reorders documents into apply order. Empty documents are ranked first, and documents of the same rank
+// retain their relative order. A stream's header comment is written at the top of the stream by [Stream] and [Save],
+// regardless of its first document's new position.
+func Sort(documents []*Document) {
+	rank := func(document *Document) int {
+		if document.Empty() {
+			return -1
+		}
+
+		return Rank(document.Kind())
+	}
+
+	sort.SliceStable(documents, func(i, j int) bool {
+		return rank(documents[i]) < rank(documents[j])
+	})
+}
@@ -0,0 +1,84 @@
+package manifests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	kinds := []string{"Namespace", "CustomResourceDefinition", "ServiceAccount", "Role", "RoleBinding", "ConfigMap", "Service", "Deployment", "Ingress", "Certificate", "ValidatingWebhookConfiguration"}
+
+	for index := 1; index < len(kinds); index++ {
+		if Rank(kinds[index-1]) >= Rank(kinds[index]) {
+			t.Errorf("Rank(%s) = %d, expected it to precede Rank(%s) = %d", kinds[index-1], Rank(kinds[index-1]), kinds[index], Rank(kinds[index]))
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	content := `# header
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: first
+---
+# comment only
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: second
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: example
+`
+
+	documents, e := Decode("test.yaml", strings.NewReader(content))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	Sort(documents)
+
+	var buffer bytes.Buffer
+	if e := Stream(&buffer, documents...); e != nil {
+		t.Fatalf("Stream() returned an unexpected error: %v", e)
+	}
+
+	expected := `# header
+---
+# comment only
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: example
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: first
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: second
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+`
+	if buffer.String() != expected {
+		t.Errorf("Sort() =\n%s\nexpected\n%s", buffer.String(), expected)
+	}
+}
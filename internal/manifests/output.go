@@ -0,0 +1,80 @@
+package manifests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output represents the destination of generated document(s). Without either [Output.Append] or [Output.File], the
+// document(s) are written to [Output.Writer].
+type Output struct {
+	Writer        io.Writer // Writer receives the document(s) when no file is configured; defaults to standard-output.
+	Append        string    // Append is an existing manifest file to append the document(s) to.
+	File          string    // File is a new manifest file to write the document(s) to.
+	Kustomization string    // Kustomization is an optional kustomization (file or directory) to register File in.
+}
+
+// Validate verifies the output's option(s) are compatible.
+func (o *Output) Validate() error {
+	if o.Append != "" && o.File != "" {
+		return errors.New("an output file and an append target are mutually exclusive")
+	}
+
+	if o.Kustomization != "" && o.File == "" {
+		return errors.New("registering a kustomization resource requires an output file")
+	}
+
+	return nil
+}
+
+// Write emits the document node(s) to the configured destination.
+func (o *Output) Write(nodes ...*yaml.Node) error {
+	if e := o.Validate(); e != nil {
+		return e
+	}
+
+	var buffer bytes.Buffer
+	if e := Write(&buffer, nodes...); e != nil {
+		return e
+	}
+
+	switch {
+	case o.Append != "":
+		content, e := os.ReadFile(o.Append)
+		if e != nil {
+			return fmt.Errorf("unable to read append target: %w", e)
+		}
+
+		if len(content) > 0 && !(bytes.HasSuffix(content, []byte("\n"))) {
+			content = append(content, '\n')
+		}
+
+		return os.WriteFile(o.Append, append(content, buffer.Bytes()...), 0o644)
+	case o.File != "":
+		if e := os.WriteFile(o.File, buffer.Bytes(), 0o644); e != nil {
+			return e
+		}
+
+		if o.Kustomization != "" {
+			if _, e := Register(o.Kustomization, o.File); e != nil {
+				return fmt.Errorf("unable to register resource in kustomization: %w", e)
+			}
+		}
+
+		return nil
+	default:
+		writer := o.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+
+		_, e := writer.Write(buffer.Bytes())
+
+		return e
+	}
+}
@@ -0,0 +1,36 @@
+package manifests
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Path represents the location of a node within a document (e.g. "spec.template.spec.containers[0].image").
+type Path []string
+
+// Key returns a copy of the path extended with a mapping key.
+func (p Path) Key(key string) Path {
+	if strings.ContainsAny(key, ".[]") {
+		key = fmt.Sprintf("[%q]", key)
+	}
+
+	return append(p[:len(p):len(p)], key)
+}
+
+// Index returns a copy of the path extended with a sequence index.
+func (p Path) Index(index int) Path {
+	return append(p[:len(p):len(p)], fmt.Sprintf("[%d]", index))
+}
+
+func (p Path) String() string {
+	var builder strings.Builder
+	for index, segment := range p {
+		if index > 0 && !(strings.HasPrefix(segment, "[")) {
+			builder.WriteString(".")
+		}
+
+		builder.WriteString(segment)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,219 @@
+package manifests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Stdin is the path that represents standard-input.
+const Stdin = "-"
+
+// Decode parses every document of a manifest stream. Empty documents are retained so that
+// each [Document.Index] corresponds to its position within the stream.
+func Decode(file string, reader io.Reader) ([]*Document, error) {
+	content, e := io.ReadAll(reader)
+	if e != nil {
+		e = fmt.Errorf("unable to read %s: %w", file, e)
+		return nil, e
+	}
+
+	indent := Indentation(content)
+
+	origin := &source{content: content}
+
+	header, content := prelude(content)
+
+	var documents []*Document
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for index := 0; ; index++ {
+		var node yaml.Node
+		if e := decoder.Decode(&node); e != nil {
+			if errors.Is(e, io.EOF) {
+				break
+			}
+
+			e = fmt.Errorf("unable to decode document %d of %s: %w", index, file, e)
+			return nil, e
+		}
+
+		documents = append(documents, &Document{File: file, Index: index, Node: &node, Indent: indent, source: origin})
+	}
+
+	if header != "" && len(documents) > 0 {
+		documents[0].Node.HeadComment = strings.TrimSpace(header + "\n\n" + documents[0].Node.HeadComment)
+	}
+
+	return documents, nil
+}
+
+// prelude separates a stream's leading comment block - comment(s) followed by a "---" separator, such as a file's
+// header - from its content. Without the separation, the comment(s) would be attributed to the first document's
+// first key, rather than the stream.
+func prelude(content []byte) (string, []byte) {
+	var comments []string
+
+	remainder := content
+	for len(remainder) > 0 {
+		line := remainder
+		next := []byte(nil)
+		if index := bytes.IndexByte(remainder, '\n'); index >= 0 {
+			line, next = remainder[:index], remainder[index+1:]
+		}
+
+		trimmed := strings.TrimSpace(string(line))
+		switch {
+		case trimmed == "":
+		case strings.HasPrefix(trimmed, "#"):
+			comments = append(comments, trimmed)
+		case trimmed == "---" && len(comments) > 0:
+			return strings.Join(comments, "\n"), remainder
+		default:
+			return "", content
+		}
+
+		remainder = next
+	}
+
+	return "", content
+}
+
+// File reads and decodes a single manifest file, or standard-input if path is [Stdin].
+func File(path string) ([]*Document, error) {
+	var reader io.Reader = os.Stdin
+	if path != Stdin {
+		content, e := os.ReadFile(path)
+		if e != nil {
+			e = fmt.Errorf("unable to read file: %w", e)
+			return nil, e
+		}
+
+		reader = bytes.NewReader(content)
+	}
+
+	return Decode(path, reader)
+}
+
+// Files expands path(s) into manifest file(s). Directories are walked recursively for ".yaml" and ".yml" files, which
+// are returned in lexical order.
+func Files(paths ...string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		if path == Stdin {
+			files = append(files, path)
+			continue
+		}
+
+		information, e := os.Stat(path)
+		if e != nil {
+			if errors.Is(e, os.ErrNotExist) {
+				e = fmt.Errorf("file does not exist: %s", path)
+			}
+
+			return nil, e
+		}
+
+		if !(information.IsDir()) {
+			files = append(files, path)
+			continue
+		}
+
+		var partials []string
+		e = filepath.WalkDir(path, func(path string, entry fs.DirEntry, e error) error {
+			if e != nil {
+				return e
+			}
+
+			if !(entry.IsDir()) && Extension(path) {
+				partials = append(partials, path)
+			}
+
+			return nil
+		})
+
+		if e != nil {
+			e = fmt.Errorf("unable to walk directory (%s): %w", path, e)
+			return nil, e
+		}
+
+		sort.Strings(partials)
+
+		files = append(files, partials...)
+	}
+
+	return files, nil
+}
+
+// Read expands path(s) via [Files], and returns the non-empty document(s) of every file.
+func Read(paths ...string) ([]*Document, error) {
+	files, e := Files(paths...)
+	if e != nil {
+		return nil, e
+	}
+
+	var documents []*Document
+	for _, file := range files {
+		partials, e := File(file)
+		if e != nil {
+			return nil, e
+		}
+
+		for _, document := range partials {
+			if !(document.Empty()) {
+				documents = append(documents, document)
+			}
+		}
+	}
+
+	return documents, nil
+}
+
+// Load expands path(s) via [Files] like [Read], but retains empty document(s) that carry comment(s) - for commands
+// that rewrite documents and should preserve them.
+func Load(paths ...string) ([]*Document, error) {
+	files, e := Files(paths...)
+	if e != nil {
+		return nil, e
+	}
+
+	var documents []*Document
+	for _, file := range files {
+		partials, e := File(file)
+		if e != nil {
+			return nil, e
+		}
+
+		for _, document := range partials {
+			if !(document.Blank()) {
+				documents = append(documents, document)
+			}
+		}
+	}
+
+	return documents, nil
+}
+
+// Extension reports whether path has a ".yaml" or ".yml" file extension.
+func Extension(path string) bool {
+	return filepath.Ext(path) == ".yaml" || filepath.Ext(path) == ".yml"
+}
+
+// Find returns the first document matching kind (case-insensitive) and name, or nil.
+func Find(documents []*Document, kind, name string) *Document {
+	for _, document := range documents {
+		if strings.EqualFold(document.Kind(), kind) && document.Name() == name {
+			return document
+		}
+	}
+
+	return nil
+}
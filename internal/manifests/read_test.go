@@ -0,0 +1,159 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	content := strings.Join([]string{
+		"# header",
+		"---",
+		"apiVersion: v1",
+		"kind: ConfigMap",
+		"metadata:",
+		"    name: first",
+		"---",
+		"# comment only",
+		"---",
+		"apiVersion: apps/v1",
+		"kind: Deployment",
+		"metadata:",
+		"    name: second",
+		"    namespace: example",
+	}, "\n")
+
+	documents, e := Decode("test.yaml", strings.NewReader(content))
+	if e != nil {
+		t.Fatalf("Decode() returned an unexpected error: %v", e)
+	}
+
+	if len(documents) != 3 {
+		t.Fatalf("Decode() returned %d documents, expected 3", len(documents))
+	}
+
+	if documents[0].Node.HeadComment != "# header" {
+		t.Errorf("the stream's header = %q, expected it to be attributed to the first document", documents[0].Node.HeadComment)
+	}
+
+	if !(documents[1].Empty()) || documents[1].Blank() {
+		t.Errorf("a comment-only document expected to be empty, but not blank")
+	}
+
+	second := documents[2]
+	if second.Index != 2 || second.Indent != 4 {
+		t.Errorf("Document = (index: %d, indent: %d), expected (index: 2, indent: 4)", second.Index, second.Indent)
+	}
+
+	if identity := second.Identity(); identity != "apps/Deployment/example/second" {
+		t.Errorf("Identity() = %s, expected apps/Deployment/example/second", identity)
+	}
+
+	if reference := second.String(); reference != "deployment/second" {
+		t.Errorf("String() = %s, expected deployment/second", reference)
+	}
+
+	if location := second.Location(); location != "test.yaml[2]" {
+		t.Errorf("Location() = %s, expected test.yaml[2]", location)
+	}
+
+	if _, e := Decode("invalid.yaml", strings.NewReader("key: [")); e == nil {
+		t.Errorf("Decode() of invalid yaml expected an error")
+	}
+}
+
+func TestFiles(t *testing.T) {
+	directory := t.TempDir()
+
+	for _, name := range []string{"b.yaml", "a.yml", "nested/c.yaml", "ignored.txt"} {
+		path := filepath.Join(directory, name)
+		if e := os.MkdirAll(filepath.Dir(path), 0o755); e != nil {
+			t.Fatalf("unable to create directory: %v", e)
+		}
+
+		if e := os.WriteFile(path, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata: { name: example }\n"), 0o644); e != nil {
+			t.Fatalf("unable to write file: %v", e)
+		}
+	}
+
+	files, e := Files(directory, Stdin)
+	if e != nil {
+		t.Fatalf("Files() returned an unexpected error: %v", e)
+	}
+
+	expected := []string{filepath.Join(directory, "a.yml"), filepath.Join(directory, "b.yaml"), filepath.Join(directory, "nested", "c.yaml"), Stdin}
+	if !(slices.Equal(files, expected)) {
+		t.Errorf("Files() = %v, expected %v", files, expected)
+	}
+
+	if _, e := Files(filepath.Join(directory, "missing.yaml")); e == nil {
+		t.Errorf("Files() of a missing path expected an error")
+	}
+
+	documents, e := Read(directory)
+	if e != nil || len(documents) != 3 {
+		t.Errorf("Read() = (%d documents, %v), expected 3", len(documents), e)
+	}
+
+	if Find(documents, "configmap", "example") == nil {
+		t.Errorf("Find() expected a case-insensitive match of the document's kind")
+	}
+}
+
+func TestParseImage(t *testing.T) {
+	tests := []struct {
+		reference string
+		expected  Image
+		mutable   bool
+	}{
+		{reference: "nginx", expected: Image{Name: "nginx"}, mutable: true},
+		{reference: "nginx:latest", expected: Image{Name: "nginx", Tag: "latest"}, mutable: true},
+		{reference: "nginx:1.25", expected: Image{Name: "nginx", Tag: "1.25"}},
+		{reference: "registry.io:5000/team/service", expected: Image{Name: "registry.io:5000/team/service"}, mutable: true},
+		{reference: "registry.io:5000/team/service:1.0.0@sha256:abc", expected: Image{Name: "registry.io:5000/team/service", Tag: "1.0.0", Digest: "sha256:abc"}},
+		{reference: "service@sha256:abc", expected: Image{Name: "service", Digest: "sha256:abc"}},
+	}
+
+	for _, test := range tests {
+		image := ParseImage(test.reference)
+		if image != test.expected {
+			t.Errorf("ParseImage(%q) = %+v, expected %+v", test.reference, image, test.expected)
+		}
+
+		if image.Mutable() != test.mutable {
+			t.Errorf("ParseImage(%q).Mutable() = %t, expected %t", test.reference, image.Mutable(), test.mutable)
+		}
+
+		if image.String() != test.reference {
+			t.Errorf("ParseImage(%q).String() = %q", test.reference, image.String())
+		}
+	}
+}
+
+func TestPath(t *testing.T) {
+	path := Path{"spec"}.Key("template").Key("metadata").Key("annotations").Key("example.io/name").Key("containers").Index(0)
+	if expected := `spec.template.metadata.annotations["example.io/name"].containers[0]`; path.String() != expected {
+		t.Errorf("Path.String() = %s, expected %s", path, expected)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.yaml")
+	if e := os.WriteFile(path, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata: { name: example }\n---\n# comment only\n---\n---\n"), 0o644); e != nil {
+		t.Fatalf("unable to write file: %v", e)
+	}
+
+	documents, e := Read(path)
+	if e != nil || len(documents) != 1 {
+		t.Errorf("Read() = (%d documents, %v), expected 1", len(documents), e)
+	}
+
+	// --> only the blank document is omitted
+	documents, e = Load(path)
+	if e != nil || len(documents) != 2 {
+		t.Errorf("Load() = (%d documents, %v), expected 2", len(documents), e)
+	}
+}
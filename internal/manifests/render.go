@@ -0,0 +1,47 @@
+package manifests
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Render builds the kustomization at path (either the file or its directory), returning the rendered document(s). Each
+// document's [Document.File] is the kustomization's path.
+func Render(path string) ([]*Document, error) {
+	kustomization, e := Kustomization(path)
+	if e != nil {
+		return nil, e
+	}
+
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+
+	resources, e := kustomizer.Run(filesys.MakeFsOnDisk(), filepath.Dir(kustomization))
+	if e != nil {
+		e = fmt.Errorf("unable to build kustomization (%s): %w", path, e)
+		return nil, e
+	}
+
+	content, e := resources.AsYaml()
+	if e != nil {
+		e = fmt.Errorf("unable to encode kustomization (%s): %w", path, e)
+		return nil, e
+	}
+
+	documents, e := Decode(path, bytes.NewReader(content))
+	if e != nil {
+		return nil, e
+	}
+
+	var rendered []*Document
+	for _, document := range documents {
+		if !(document.Empty()) {
+			rendered = append(rendered, document)
+		}
+	}
+
+	return rendered, nil
+}
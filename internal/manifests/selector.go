@@ -0,0 +1,101 @@
+package manifests
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Selector represents the user-provided flags that select workload(s) and their container(s). Empty fields match
+// everything.
+type Selector struct {
+	Kinds     []string // Kinds are case-insensitive workload kinds (e.g. "deployment").
+	Name      string   // Name is a workload's "metadata.name".
+	Container string   // Container is a container's name.
+	Labels    []string // Labels are "<key>=<value>" pairs the workload's "metadata.labels" must all contain.
+}
+
+// Register adds the selector flags to cmd.
+func (s *Selector) Register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	flags.StringSliceVar(&s.Kinds, "kind", nil, "only select workloads of the given kind(s) (e.g. \"deployment,statefulset\")")
+	flags.StringVar(&s.Name, "name", "", "only select the workload with the given name")
+	flags.StringVarP(&s.Container, "container", "c", "", "only select the container with the given name")
+	flags.StringSliceVarP(&s.Labels, "selector", "l", nil, "only select workloads with the given label(s) (e.g. \"app=example,tier=backend\")")
+}
+
+// Validate verifies the selector's label(s) are "<key>=<value>" pairs.
+func (s *Selector) Validate() error {
+	for _, label := range s.Labels {
+		if key, _, valid := strings.Cut(label, "="); !(valid) || key == "" {
+			return fmt.Errorf("invalid label selector - expecting \"<key>=<value>\": %s", label)
+		}
+	}
+
+	return nil
+}
+
+// Match reports whether a workload document satisfies the selector's kind, name, and label(s).
+func (s *Selector) Match(document *Document) bool {
+	if !(document.Workload()) {
+		return false
+	}
+
+	if len(s.Kinds) > 0 {
+		var found bool
+		for _, kind := range s.Kinds {
+			if strings.EqualFold(kind, document.Kind()) {
+				found = true
+				break
+			}
+		}
+
+		if !(found) {
+			return false
+		}
+	}
+
+	if s.Name != "" && s.Name != document.Name() {
+		return false
+	}
+
+	labels := document.Labels()
+	for _, label := range s.Labels {
+		key, value, _ := strings.Cut(label, "=")
+		if current, valid := labels[key]; !(valid) || current != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Matches reports whether labels satisfy every key and value of a label selector (e.g. a Service's "spec.selector").
+// An empty selector matches any labels.
+func Matches(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if current, valid := labels[key]; !(valid) || current != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Containers returns a matching document's container(s), filtered by [Selector.Container].
+func (s *Selector) Containers(document *Document) []Container {
+	if !(s.Match(document)) {
+		return nil
+	}
+
+	var containers []Container
+	for _, container := range document.Containers() {
+		if s.Container == "" || s.Container == container.Name() {
+			containers = append(containers, container)
+		}
+	}
+
+	return containers
+}
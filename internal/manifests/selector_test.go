@@ -0,0 +1,63 @@
+package manifests
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectorMatch(t *testing.T) {
+	documents, e := Decode("test.yaml", strings.NewReader("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n    name: example\n    labels: { app: example, tier: backend }\n---\napiVersion: v1\nkind: Service\nmetadata:\n    name: example\n"))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	tests := []struct {
+		name     string
+		selector Selector
+		expected bool
+	}{
+		{name: "empty", selector: Selector{}, expected: true},
+		{name: "kind", selector: Selector{Kinds: []string{"statefulset", "DEPLOYMENT"}}, expected: true},
+		{name: "other-kind", selector: Selector{Kinds: []string{"statefulset"}}},
+		{name: "name", selector: Selector{Name: "example"}, expected: true},
+		{name: "labels", selector: Selector{Labels: []string{"app=example", "tier=backend"}}, expected: true},
+		{name: "other-label", selector: Selector{Labels: []string{"tier=frontend"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if matched := test.selector.Match(documents[0]); matched != test.expected {
+				t.Errorf("Match() = %t, expected %t", matched, test.expected)
+			}
+		})
+	}
+
+	if (&Selector{}).Match(documents[1]) {
+		t.Errorf("Match() of a non-workload expected false")
+	}
+
+	if e := (&Selector{Labels: []string{"app"}}).Validate(); e == nil {
+		t.Errorf("Validate() of a label without a value expected an error")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	labels := map[string]string{"app": "example", "version": "v1"}
+
+	tests := []struct {
+		selector map[string]string
+		expected bool
+	}{
+		{selector: map[string]string{"app": "example"}, expected: true},
+		{selector: map[string]string{"app": "example", "version": "v1"}, expected: true},
+		{selector: map[string]string{"app": "example", "version": "v2"}},
+		{selector: map[string]string{"tier": ""}},
+		{selector: nil, expected: true},
+	}
+
+	for _, test := range tests {
+		if matched := Matches(test.selector, labels); matched != test.expected {
+			t.Errorf("Matches(%v) = %t, expected %t", test.selector, matched, test.expected)
+		}
+	}
+}
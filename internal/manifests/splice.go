@@ -0,0 +1,415 @@
+package manifests
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// separator matches a "---" document separator line.
+var separator = regexp.MustCompile(`^---(\s.*)?$`)
+
+// position identifies a node by its location within the original content.
+type position struct {
+	line, column int
+}
+
+// replacement represents an edit of the original content's line(s).
+type replacement struct {
+	start, end int    // start and end are the (1-based, inclusive) line(s) replaced - an insertion's end precedes its start.
+	text       string // text replaces the line(s).
+}
+
+// splicer computes the minimal line edit(s) that transform a document's original content into its modification.
+//
+// A modified document is the same node tree that was decoded from the content, so any node that existed
+// retains its original position, and a node added by the modification has none. Nodes are matched to their
+// pristine counterparts by position. Only a changed mapping entry or sequence item is rewritten. Added entries
+// and items are inserted after their collection's last existing one, and removed ones are deleted. Everything
+// else (formatting, comments, quoting) is left untouched.
+type splicer struct {
+	lines        []string // lines are the original content's lines, each including its line-break.
+	indent       int      // indent is the indentation used to encode rewritten node(s).
+	replacements []replacement
+}
+
+// node records the edits of a modified node's child(ren), reporting whether the node must instead be rewritten as a
+// whole - in which case any edit(s) of its child(ren) are discarded.
+func (s *splicer) node(original, modified *yaml.Node) bool {
+	if original.Line != modified.Line || original.Column != modified.Column || !(attributes(original, modified)) {
+		return true
+	}
+
+	recorded := len(s.replacements)
+
+	var rewrite bool
+	switch {
+	case original.Kind == yaml.DocumentNode:
+		rewrite = len(original.Content) != len(modified.Content)
+		for index := 0; !(rewrite) && index < len(original.Content); index++ {
+			rewrite = s.node(original.Content[index], modified.Content[index])
+		}
+	case original.Style&yaml.FlowStyle != 0:
+		rewrite = !(equal(original, modified))
+	case original.Kind == yaml.MappingNode:
+		rewrite = s.mapping(original, modified)
+	case original.Kind == yaml.SequenceNode:
+		rewrite = s.sequence(original, modified)
+	default:
+		rewrite = !(equal(original, modified))
+	}
+
+	if rewrite {
+		s.replacements = s.replacements[:recorded]
+	}
+
+	return rewrite
+}
+
+// mapping records the edits of a block mapping's entries, reporting whether it must be rewritten as a whole.
+func (s *splicer) mapping(original, modified *yaml.Node) bool {
+	if len(original.Content) == 0 || len(modified.Content) == 0 {
+		return true
+	}
+
+	indices := make(map[position]int, len(original.Content)/2)
+	for index := 0; index+1 < len(original.Content); index += 2 {
+		indices[position{original.Content[index].Line, original.Content[index].Column}] = index
+	}
+
+	retained := make(map[int]bool, len(indices))
+
+	var added []*yaml.Node
+
+	previous := -1
+	for index := 0; index+1 < len(modified.Content); index += 2 {
+		key, value := modified.Content[index], modified.Content[index+1]
+		if key.Line == 0 {
+			added = append(added, key, value)
+			continue
+		}
+
+		// --> existing entries must retain their order, and additions must follow them
+		match, found := indices[position{key.Line, key.Column}]
+		if !(found) || match <= previous || len(added) > 0 {
+			return true
+		}
+
+		previous, retained[match] = match, true
+
+		okey, ovalue := original.Content[match], original.Content[match+1]
+		if okey.Value != key.Value || !(attributes(okey, key)) || s.node(ovalue, value) {
+			if !(s.rewrite(okey, ovalue, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{key, value}})) {
+				return true
+			}
+		}
+	}
+
+	for index := 0; index+1 < len(original.Content); index += 2 {
+		if !(retained[index]) && !(s.remove(original.Content[index], original.Content[index+1])) {
+			return true
+		}
+	}
+
+	if len(added) > 0 {
+		first := original.Content[0]
+		last := original.Content[len(original.Content)-2]
+
+		text, valid := s.render(strings.Repeat(" ", first.Column-1), &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: added})
+		if !(valid) {
+			return true
+		}
+
+		line := s.end(last, original.Content[len(original.Content)-1])
+
+		s.replacements = append(s.replacements, replacement{start: line + 1, end: line, text: text})
+	}
+
+	return false
+}
+
+// sequence records the edits of a block sequence's items, reporting whether it must be rewritten as a whole.
+func (s *splicer) sequence(original, modified *yaml.Node) bool {
+	if len(original.Content) == 0 || len(modified.Content) == 0 {
+		return true
+	}
+
+	indices := make(map[position]int, len(original.Content))
+	for index, item := range original.Content {
+		// --> only items that begin on their "- " line can be rewritten in isolation
+		if prefix := s.prefix(item); strings.TrimSpace(prefix) != "-" {
+			return true
+		}
+
+		indices[position{item.Line, item.Column}] = index
+	}
+
+	retained := make(map[int]bool, len(indices))
+
+	var added []*yaml.Node
+
+	previous := -1
+	for _, item := range modified.Content {
+		if item.Line == 0 {
+			added = append(added, item)
+			continue
+		}
+
+		match, found := indices[position{item.Line, item.Column}]
+		if !(found) || match <= previous || len(added) > 0 {
+			return true
+		}
+
+		previous, retained[match] = match, true
+
+		if s.node(original.Content[match], item) {
+			if !(s.rewrite(original.Content[match], nil, item)) {
+				return true
+			}
+		}
+	}
+
+	for index, item := range original.Content {
+		if !(retained[index]) && !(s.remove(item, nil)) {
+			return true
+		}
+	}
+
+	if len(added) > 0 {
+		last := original.Content[len(original.Content)-1]
+
+		var text strings.Builder
+		for _, item := range added {
+			rendered, valid := s.render(s.prefix(last), item)
+			if !(valid) {
+				return true
+			}
+
+			text.WriteString(rendered)
+		}
+
+		line := s.end(last, nil)
+
+		s.replacements = append(s.replacements, replacement{start: line + 1, end: line, text: text.String()})
+	}
+
+	return false
+}
+
+// rewrite replaces the original mapping entry (key and value) or sequence item (value is nil) with node - either a
+// mapping of the modified entry, or the modified item.
+func (s *splicer) rewrite(key, value, node *yaml.Node) bool {
+	// --> the comment(s) above an entry's key, or an item's first key, precede the rewritten line(s)
+	detached := []*yaml.Node{node}
+	if node.Kind == yaml.MappingNode && len(node.Content) > 0 {
+		detached = append(detached, node.Content[0])
+	}
+
+	defer detach(detached...)()
+
+	text, valid := s.render(s.prefix(key), node)
+	if !(valid) {
+		return false
+	}
+
+	s.replacements = append(s.replacements, replacement{start: key.Line, end: s.end(key, value), text: text})
+
+	return true
+}
+
+// remove deletes the original mapping entry (key and value) or sequence item (value is nil), along with its head
+// comment. An entry that shares its line with a sequence item's "- " can't be removed in isolation.
+func (s *splicer) remove(key, value *yaml.Node) bool {
+	prefix := s.prefix(key)
+	if value != nil && strings.TrimSpace(prefix) != "" {
+		return false
+	}
+
+	start := key.Line
+	for comments := strings.Count(key.HeadComment, "\n") + 1; key.HeadComment != "" && comments > 0 && start > 1; comments-- {
+		if !(strings.HasPrefix(strings.TrimSpace(s.lines[start-2]), "#")) {
+			break
+		}
+
+		start--
+	}
+
+	s.replacements = append(s.replacements, replacement{start: start, end: s.end(key, value)})
+
+	return true
+}
+
+// prefix returns the original content that precedes a node on its line - its indentation, and any "- " marker(s).
+func (s *splicer) prefix(node *yaml.Node) string {
+	line := strings.TrimRight(s.lines[node.Line-1], "\r\n")
+	if node.Column-1 > len(line) {
+		return line
+	}
+
+	return line[:node.Column-1]
+}
+
+// end returns the last line of the original mapping entry (key and value) or sequence item (value is nil) that starts
+// at key - the entry continues through every following line that's indented beyond its start. A block sequence at
+// the key's own indentation (e.g. "resources:\n- a.yaml") also continues the entry. Trailing blank line(s) aren't
+// considered part of the entry.
+func (s *splicer) end(key, value *yaml.Node) int {
+	prefix := s.prefix(key)
+
+	indentation := len(prefix)
+	if value == nil {
+		// --> a sequence item continues beyond its "- ", rather than its content
+		indentation = strings.Index(prefix, "-")
+	}
+
+	compact := value != nil && value.Kind == yaml.SequenceNode && value.Style&yaml.FlowStyle == 0 && value.Column == key.Column
+
+	last := key.Line
+	for index := key.Line; index < len(s.lines); index++ {
+		line := strings.TrimRight(s.lines[index], "\r\n")
+
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" {
+			continue
+		}
+
+		width := len(line) - len(trimmed)
+		if width < indentation || (width == indentation && !(compact && strings.HasPrefix(trimmed, "-"))) {
+			break
+		}
+
+		last = index + 1
+	}
+
+	return last
+}
+
+// render encodes node as block-style yaml, preceding its first line with prefix and aligning any subsequent line(s)
+// with it.
+func (s *splicer) render(prefix string, node *yaml.Node) (string, bool) {
+	if strings.Trim(prefix, " -") != "" {
+		return "", false
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(s.indent)
+	if e := encoder.Encode(node); e != nil {
+		return "", false
+	}
+
+	if e := encoder.Close(); e != nil {
+		return "", false
+	}
+
+	lines := strings.SplitAfter(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	for index := range lines {
+		if index == 0 {
+			lines[index] = prefix + lines[index]
+		} else if strings.TrimSpace(lines[index]) != "" {
+			lines[index] = strings.Repeat(" ", len(prefix)) + lines[index]
+		}
+	}
+
+	return strings.Join(lines, "") + "\n", true
+}
+
+// detach clears the head and foot comments of rewritten node(s) - the comments remain in the original content -
+// returning a function that restores them.
+func detach(nodes ...*yaml.Node) func() {
+	comments := make([][2]string, len(nodes))
+	for index, node := range nodes {
+		comments[index] = [2]string{node.HeadComment, node.FootComment}
+		node.HeadComment, node.FootComment = "", ""
+	}
+
+	return func() {
+		for index, node := range nodes {
+			node.HeadComment, node.FootComment = comments[index][0], comments[index][1]
+		}
+	}
+}
+
+// apply returns the original line(s), from start through end (1-based, inclusive), with the recorded edits applied.
+func (s *splicer) apply(start, end int) string {
+	// --> edits are applied bottom-up; of those that share a start line, the later-recorded edit is applied first so
+	// the earlier one lands above it - nested edits are recorded before their enclosing node's, e.g. an item appended
+	// to a container's last sequence precedes a key added to the container
+	replacements := make([]replacement, len(s.replacements))
+	for index := range s.replacements {
+		replacements[index] = s.replacements[len(s.replacements)-1-index]
+	}
+
+	sort.SliceStable(replacements, func(i, j int) bool {
+		return replacements[i].start > replacements[j].start
+	})
+
+	lines := append([]string(nil), s.lines[start-1:end]...)
+	for _, replacement := range replacements {
+		from, to := replacement.start-start, replacement.end-start+1
+		if from > 0 && !(strings.HasSuffix(lines[from-1], "\n")) {
+			lines[from-1] += "\n"
+		}
+
+		lines = append(lines[:from], append([]string{replacement.text}, lines[to:]...)...)
+	}
+
+	return strings.Join(lines, "")
+}
+
+// attributes reports whether two nodes share their kind, style, tag, anchor, and comments.
+func attributes(a, b *yaml.Node) bool {
+	return a.Kind == b.Kind && a.Style == b.Style && a.Tag == b.Tag && a.Anchor == b.Anchor &&
+		a.HeadComment == b.HeadComment && a.LineComment == b.LineComment && a.FootComment == b.FootComment
+}
+
+// equal reports whether two nodes are structurally identical, regardless of their position(s).
+func equal(a, b *yaml.Node) bool {
+	if !(attributes(a, b)) || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+
+	for index := range a.Content {
+		if !(equal(a.Content[index], b.Content[index])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// chunks separates content into its document(s), returning each document's line range (1-based, inclusive) and
+// whether a "---" separator preceded it. Leading content without a document (e.g. blank lines, or comments) isn't
+// considered a document.
+func chunks(lines []string) (ranges [][2]int, separated []bool) {
+	start := 1
+	for index := 0; index <= len(lines); index++ {
+		if index < len(lines) && !(separator.MatchString(strings.TrimRight(lines[index], "\r\n"))) {
+			continue
+		}
+
+		if len(ranges) > 0 || start > 1 || content(lines[start-1:index]) {
+			ranges = append(ranges, [2]int{start, index})
+			separated = append(separated, start > 1)
+		}
+
+		start = index + 2
+	}
+
+	return ranges, separated
+}
+
+// content reports whether any line is neither blank nor a comment.
+func content(lines []string) bool {
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !(strings.HasPrefix(trimmed, "#")) {
+			return true
+		}
+	}
+
+	return false
+}
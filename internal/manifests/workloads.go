@@ -0,0 +1,95 @@
+package manifests
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templates maps pod-bearing kinds to the path of their pod specification.
+var templates = map[string]Path{
+	"Pod":                   {"spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// Workload reports whether the document's kind carries a pod specification.
+func (d *Document) Workload() bool {
+	_, valid := templates[d.Kind()]
+
+	return valid
+}
+
+// PodSpec returns a pod-bearing document's pod specification and its path, or nil if the document isn't a workload.
+func (d *Document) PodSpec() (*yaml.Node, Path) {
+	path, valid := templates[d.Kind()]
+	if !(valid) {
+		return nil, nil
+	}
+
+	return Lookup(d.Root(), path...), path
+}
+
+// PodTemplate returns the metadata of a workload's pod template and its path, or nil if the document doesn't have one
+// (e.g. a Pod, whose metadata is the document's own).
+func (d *Document) PodTemplate() (*yaml.Node, Path) {
+	path, valid := templates[d.Kind()]
+	if !(valid) || len(path) < 2 {
+		return nil, nil
+	}
+
+	path = append(path[:len(path)-1:len(path)-1], "metadata")
+
+	return Lookup(d.Root(), path...), path
+}
+
+// Replicas returns a workload's "spec.replicas", defaulting to 1.
+func (d *Document) Replicas() int {
+	switch d.Kind() {
+	case "Deployment", "StatefulSet", "ReplicaSet", "ReplicationController":
+		if value, e := strconv.Atoi(Scalar(d.Root(), "spec", "replicas")); e == nil {
+			return value
+		}
+	}
+
+	return 1
+}
+
+// Container represents a single container of a pod specification.
+type Container struct {
+	Node *yaml.Node // Node is the container's mapping node.
+	Path Path       // Path is the container's location within its document.
+	Type string     // Type is the container's field - "containers", "initContainers", or "ephemeralContainers".
+}
+
+// Name returns the container's name.
+func (c Container) Name() string {
+	return Scalar(c.Node, "name")
+}
+
+// Image returns the container's image.
+func (c Container) Image() string {
+	return Scalar(c.Node, "image")
+}
+
+// Containers returns every container, init-container, and ephemeral-container of a workload document.
+func (d *Document) Containers() []Container {
+	spec, path := d.PodSpec()
+	if spec == nil {
+		return nil
+	}
+
+	var containers []Container
+	for _, field := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		for index, node := range Items(Value(spec, field)) {
+			containers = append(containers, Container{Node: node, Path: path.Key(field).Index(index), Type: field})
+		}
+	}
+
+	return containers
+}
@@ -0,0 +1,336 @@
+package manifests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Indent is the indentation used when encoding manifests.
+const Indent = 4
+
+// Write encodes document node(s) as a multi-document stream, with each document preceded by a "---" separator.
+func Write(writer io.Writer, nodes ...*yaml.Node) error {
+	return encode(writer, Indent, true, nodes...)
+}
+
+// Stream writes document(s) as a multi-document stream, each preceded by a "---" separator. A document decoded by
+// [Decode] is written with its source's formatting and comments, with any change(s) spliced in as by [Save]. When the
+// source's first document is written, the source's header comment precedes the first of its document(s) - wherever a
+// sort placed it. Other documents are encoded with their source's indentation.
+func Stream(writer io.Writer, documents ...*Document) error {
+	originals := make(map[*source]*original)
+
+	// --> headers are the sources whose header is yet to be written
+	headers := make(map[*source]bool)
+	for _, document := range documents {
+		if document.source != nil && document.Index == 0 {
+			headers[document.source] = true
+		}
+	}
+
+	for _, document := range documents {
+		if document.source != nil {
+			original, parsed := originals[document.source]
+			if !(parsed) {
+				// --> content that can't be separated by line is re-encoded instead
+				original, _ = parse(document.File, document.source.content)
+				originals[document.source] = original
+			}
+
+			if original != nil {
+				if text, e := original.text(document); e == nil {
+					if original.header != "" && headers[document.source] {
+						text = original.header + "\n---\n" + text
+					} else {
+						text = "---\n" + text
+					}
+
+					delete(headers, document.source)
+
+					if _, e := io.WriteString(writer, text); e != nil {
+						return e
+					}
+
+					continue
+				}
+			}
+		}
+
+		indent := document.Indent
+		if indent == 0 {
+			indent = Indent
+		}
+
+		if e := encode(writer, indent, true, document.Node); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Save writes a file's document(s) in place. Only the node(s) the document(s) changed are rewritten - see [splicer] -
+// keeping the file's formatting and comments otherwise intact. Documents may also be reordered, or omitted. When a
+// document's content can't be matched to its source, the file is re-encoded with its indentation, and whether it
+// began with a "---" separator, retained.
+func Save(path string, documents ...*Document) error {
+	information, e := os.Stat(path)
+	if e != nil {
+		return e
+	}
+
+	content, e := os.ReadFile(path)
+	if e != nil {
+		e = fmt.Errorf("unable to read file: %w", e)
+		return e
+	}
+
+	var buffer bytes.Buffer
+	if e := amend(&buffer, path, content, documents...); e != nil {
+		buffer.Reset()
+
+		header, remainder := prelude(content)
+
+		leading := bytes.HasPrefix(bytes.TrimLeft(remainder, " \t\r\n"), []byte("---")) || header != ""
+
+		if e := encode(&buffer, Indentation(content), leading, Nodes(documents...)...); e != nil {
+			return e
+		}
+	}
+
+	return os.WriteFile(path, buffer.Bytes(), information.Mode().Perm())
+}
+
+// errUnmatched is returned by [amend] when document(s) can't be matched to the original content.
+var errUnmatched = errors.New("unable to match document(s) to their source")
+
+// amend writes the document(s), which were decoded from content, by editing content rather than re-encoding it.
+func amend(writer io.Writer, path string, content []byte, documents ...*Document) error {
+	original, e := parse(path, content)
+	if e != nil {
+		return e
+	}
+
+	if _, e := io.WriteString(writer, original.prefix); e != nil {
+		return e
+	}
+
+	for index, document := range documents {
+		if document.Index < 0 || document.Index >= len(original.pristine) {
+			return errUnmatched
+		}
+
+		if index > 0 || original.separated[documents[0].Index] {
+			if _, e := io.WriteString(writer, "---\n"); e != nil {
+				return e
+			}
+		}
+
+		text, e := original.text(document)
+		if e != nil {
+			return e
+		}
+
+		if _, e := io.WriteString(writer, text); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// original is a stream's content, separated into its document(s) so that they can be edited in place.
+type original struct {
+	header    string      // header is the stream's leading comment block - see [prelude].
+	prefix    string      // prefix is the content preceding the first document (e.g. the header, and its "---").
+	lines     []string    // lines are the content's line(s) following the prefix.
+	ranges    [][2]int    // ranges are each document's line range - see [chunks].
+	separated []bool      // separated reports whether each document was preceded by a "---" separator.
+	pristine  []*Document // pristine are the content's unmodified document(s).
+}
+
+// parse separates content into its document(s), returning [errUnmatched] if they can't be told apart by line.
+func parse(path string, content []byte) (*original, error) {
+	pristine, e := Decode(path, bytes.NewReader(content))
+	if e != nil {
+		return nil, e
+	}
+
+	header, remainder := prelude(content)
+
+	lines := strings.SplitAfter(string(remainder), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	ranges, separated := chunks(lines)
+	if len(ranges) != len(pristine) {
+		return nil, errUnmatched
+	}
+
+	return &original{
+		header:    header,
+		prefix:    string(content[:len(content)-len(remainder)]),
+		lines:     lines,
+		ranges:    ranges,
+		separated: separated,
+		pristine:  pristine,
+	}, nil
+}
+
+// text returns a document's original content, without its "---" separator, with the document's changes spliced in.
+// A document that can't be edited in place is re-encoded - without the stream's header, which is retained separately.
+func (o *original) text(document *Document) (string, error) {
+	if document.Index < 0 || document.Index >= len(o.pristine) {
+		return "", errUnmatched
+	}
+
+	start, end := o.ranges[document.Index][0], o.ranges[document.Index][1]
+
+	splicer := &splicer{lines: o.lines, indent: document.Indent}
+	if splicer.indent == 0 {
+		splicer.indent = Indent
+	}
+
+	var text string
+	if splicer.node(o.pristine[document.Index].Node, document.Node) {
+		var buffer bytes.Buffer
+
+		comment := document.Node.HeadComment
+		if document.Index == 0 && o.header != "" {
+			document.Node.HeadComment = strings.TrimSpace(strings.TrimPrefix(comment, o.header))
+		}
+
+		e := encode(&buffer, splicer.indent, false, document.Node)
+
+		document.Node.HeadComment = comment
+
+		if e != nil {
+			return "", e
+		}
+
+		text = buffer.String()
+	} else if end >= start {
+		text = splicer.apply(start, end)
+	}
+
+	if text != "" && !(strings.HasSuffix(text, "\n")) {
+		text += "\n"
+	}
+
+	return text, nil
+}
+
+// Indentation returns the indentation of content's nested block mapping(s) and sequence(s), defaulting to [Indent].
+// Only nesting outside of sequence items is measured - content within an item is offset by the item's "- " prefix,
+// rather than the file's indentation.
+func Indentation(content []byte) int {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var node yaml.Node
+		if e := decoder.Decode(&node); e != nil {
+			return Indent
+		}
+
+		if indentation := nesting(&node); indentation >= 2 {
+			return indentation
+		}
+	}
+}
+
+// nesting returns the column offset between the first block mapping key, outside of any sequence, and its nested
+// block mapping or sequence - or 0 if there's no such key.
+func nesting(node *yaml.Node) int {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode || node.Style&yaml.FlowStyle != 0 {
+		return 0
+	}
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		key, value := node.Content[index], node.Content[index+1]
+		if value.Style&yaml.FlowStyle != 0 || value.Line == key.Line {
+			continue
+		}
+
+		switch value.Kind {
+		case yaml.MappingNode, yaml.SequenceNode:
+			// --> compact sequences (a "- " at the key's column) don't reflect the indentation
+			if offset := value.Column - key.Column; offset > 0 {
+				return offset
+			}
+		}
+	}
+
+	for index := 1; index < len(node.Content); index += 2 {
+		if offset := nesting(node.Content[index]); offset > 0 {
+			return offset
+		}
+	}
+
+	return 0
+}
+
+// encode writes node(s) as a multi-document stream. Documents after the first are always separated by "---". When the
+// first document is preceded by a separator, its header comment is written above the separator.
+func encode(writer io.Writer, indent int, leading bool, nodes ...*yaml.Node) error {
+	for index, node := range nodes {
+		if index == 0 && leading && node.Kind == yaml.DocumentNode && node.HeadComment != "" {
+			for _, line := range strings.Split(node.HeadComment, "\n") {
+				if _, e := fmt.Fprintf(writer, "%s\n", line); e != nil {
+					return e
+				}
+			}
+
+			header := node.HeadComment
+
+			node.HeadComment = ""
+			defer func() { node.HeadComment = header }()
+		}
+
+		var buffer bytes.Buffer
+
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(indent)
+
+		if e := encoder.Encode(node); e != nil {
+			e = fmt.Errorf("unable to encode document: %w", e)
+			return e
+		}
+
+		if e := encoder.Close(); e != nil {
+			return e
+		}
+
+		if index > 0 || leading {
+			if _, e := io.WriteString(writer, "---\n"); e != nil {
+				return e
+			}
+		}
+
+		// --> a comment-only document is encoded as an empty scalar, preceding its comment(s) with blank lines
+		if _, e := writer.Write(bytes.TrimLeft(buffer.Bytes(), "\n")); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Nodes returns the [Document.Node] of every document.
+func Nodes(documents ...*Document) []*yaml.Node {
+	nodes := make([]*yaml.Node, 0, len(documents))
+	for _, document := range documents {
+		nodes = append(nodes, document.Node)
+	}
+
+	return nodes
+}
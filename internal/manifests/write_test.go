@@ -0,0 +1,269 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workload is a Deployment formatted with 4-space indentation and padded sequence items ("-   name:").
+const workload = `# header comment
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+    name: example # trailing comment
+spec:
+    template:
+        spec:
+            containers:
+                -   name: example
+                    image: "example:1.0.0"
+                    env:
+                        # head comment
+                        -   name: FIRST
+                            value: "1"
+                        -   name: SECOND
+                            value: '2'
+                    ports: [ { containerPort: 8080 } ]
+`
+
+func TestSave(t *testing.T) {
+	container := func(documents []*Document) *yaml.Node {
+		return documents[0].Containers()[0].Node
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		mutation func(documents []*Document) []*Document
+		expected string
+	}{
+		{
+			name:    "unchanged",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				return documents
+			},
+			expected: workload,
+		},
+		{
+			name:    "append-item",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				env := Value(container(documents), "env")
+				env.Content = append(env.Content, Object("name", "THIRD", "value", String("3")))
+
+				return documents
+			},
+			expected: strings.Replace(workload, "value: '2'\n", "value: '2'\n                        -   name: THIRD\n                            value: \"3\"\n", 1),
+		},
+		{
+			name:    "append-item-and-key",
+			content: strings.Replace(workload, "                    ports: [ { containerPort: 8080 } ]\n", "", 1),
+			mutation: func(documents []*Document) []*Document {
+				env := Value(container(documents), "env")
+				env.Content = append(env.Content, Object("name", "THIRD", "value", String("3")))
+
+				Set(container(documents), "envFrom", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{Object("configMapRef", Object("name", "settings"))}})
+
+				return documents
+			},
+			expected: strings.Replace(workload, "value: '2'\n                    ports: [ { containerPort: 8080 } ]\n", "value: '2'\n                        -   name: THIRD\n                            value: \"3\"\n                    envFrom:\n                        - configMapRef:\n                            name: settings\n", 1),
+		},
+		{
+			name:    "change-scalar",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				Value(container(documents), "image").Value = "example:2.0.0"
+
+				return documents
+			},
+			expected: strings.Replace(workload, `image: "example:1.0.0"`, `image: "example:2.0.0"`, 1),
+		},
+		{
+			name:    "add-key",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				Set(Ensure(container(documents), "resources", "limits"), "memory", String("512Mi"))
+
+				return documents
+			},
+			expected: strings.Replace(workload, "ports: [ { containerPort: 8080 } ]\n", "ports: [ { containerPort: 8080 } ]\n                    resources:\n                        limits:\n                            memory: 512Mi\n", 1),
+		},
+		{
+			name:    "remove-item",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				env := Value(container(documents), "env")
+				env.Content = env.Content[1:]
+
+				return documents
+			},
+			expected: strings.Replace(workload, "                        # head comment\n                        -   name: FIRST\n                            value: \"1\"\n", "", 1),
+		},
+		{
+			name:    "remove-key",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				Delete(container(documents), "env")
+
+				return documents
+			},
+			expected: strings.Replace(workload, workload[strings.Index(workload, "                    env:"):strings.Index(workload, "                    ports")], "", 1),
+		},
+		{
+			name:    "rename-key",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				key, _ := Pair(documents[0].Root(), "kind")
+				key.Value = "type"
+
+				return documents
+			},
+			expected: strings.Replace(workload, "kind: Deployment", "type: Deployment", 1),
+		},
+		{
+			name:    "change-flow",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				Set(Items(Value(container(documents), "ports"))[0], "containerPort", Integer(9090))
+
+				return documents
+			},
+			expected: strings.Replace(workload, "ports: [ { containerPort: 8080 } ]", "ports: [{containerPort: 9090}]", 1),
+		},
+		{
+			name:    "change-first-key-of-item",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				Value(Items(Value(container(documents), "env"))[1], "name").Value = "RENAMED"
+
+				return documents
+			},
+			expected: strings.Replace(workload, "-   name: SECOND", "-   name: RENAMED", 1),
+		},
+		{
+			name:    "remove-first-key-of-item",
+			content: workload,
+			mutation: func(documents []*Document) []*Document {
+				Delete(Items(Value(container(documents), "env"))[1], "name")
+
+				return documents
+			},
+			expected: strings.Replace(workload, "-   name: SECOND\n                            value: '2'", "-   value: '2'", 1),
+		},
+		{
+			name:    "reorder-documents",
+			content: "# header\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: b\n---\n# configuration\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n",
+			mutation: func(documents []*Document) []*Document {
+				return []*Document{documents[1], documents[0]}
+			},
+			expected: "# header\n---\n# configuration\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: b\n",
+		},
+		{
+			name:    "sort-documents",
+			content: "# header\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: b\nspec:\n  ports:\n  -   port: 80\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: a\n",
+			mutation: func(documents []*Document) []*Document {
+				Sort(documents)
+
+				return documents
+			},
+			expected: "# header\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: b\nspec:\n  ports:\n  -   port: 80\n",
+		},
+		{
+			name:    "compact-sequence",
+			content: "resources:\n- a.yaml\n- b.yaml\nnamespace: example\n",
+			mutation: func(documents []*Document) []*Document {
+				Set(documents[0].Root(), "namespace", String("other"))
+
+				return documents
+			},
+			expected: "resources:\n- a.yaml\n- b.yaml\nnamespace: other\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "manifest.yaml")
+			if e := os.WriteFile(path, []byte(test.content), 0o600); e != nil {
+				t.Fatalf("unable to write manifest: %v", e)
+			}
+
+			documents, e := File(path)
+			if e != nil {
+				t.Fatalf("unable to read manifest: %v", e)
+			}
+
+			if e := Save(path, test.mutation(documents)...); e != nil {
+				t.Fatalf("Save() returned an unexpected error: %v", e)
+			}
+
+			content, _ := os.ReadFile(path)
+			if string(content) != test.expected {
+				t.Errorf("Save() =\n%s\nexpected:\n%s", content, test.expected)
+			}
+
+			if information, _ := os.Stat(path); information.Mode().Perm() != 0o600 {
+				t.Errorf("Save() changed the file's permissions to %v", information.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	service := "# header\n---\napiVersion: v1\nkind: Service\nmetadata:\n    name: example # trailing comment\nspec:\n    ports:\n        -   port: 80\n            targetPort: http\n    selector: { app: example }\n"
+
+	tests := []struct {
+		name     string
+		mutation func(documents []*Document) []*Document
+		expected string
+	}{
+		{
+			name: "unchanged",
+			mutation: func(documents []*Document) []*Document {
+				return documents
+			},
+			expected: service,
+		},
+		{
+			name: "changed",
+			mutation: func(documents []*Document) []*Document {
+				Set(Items(Lookup(documents[0].Root(), "spec", "ports"))[0], "port", Integer(8080))
+
+				return documents
+			},
+			expected: strings.Replace(service, "port: 80\n", "port: 8080\n", 1),
+		},
+		{
+			name: "sources",
+			mutation: func(documents []*Document) []*Document {
+				others, _ := Decode("other.yaml", strings.NewReader("kind: Namespace\nmetadata: { name: example }\n"))
+
+				return append(others, documents...)
+			},
+			expected: "---\nkind: Namespace\nmetadata: { name: example }\n" + service,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documents, e := Decode("service.yaml", strings.NewReader(service))
+			if e != nil {
+				t.Fatalf("unable to decode manifest: %v", e)
+			}
+
+			var buffer strings.Builder
+			if e := Stream(&buffer, test.mutation(documents)...); e != nil {
+				t.Fatalf("Stream() returned an unexpected error: %v", e)
+			}
+
+			if buffer.String() != test.expected {
+				t.Errorf("Stream() =\n%s\nexpected:\n%s", buffer.String(), test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,422 @@
+package pss
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Report is provided to a [Control]'s check for recording a violation - by container name, or by an empty name for
+// violations of the pod's own specification.
+type Report func(container string, path manifests.Path, message string)
+
+// Control represents a single Pod Security Standards control.
+type Control struct {
+	ID          string   // ID is the control's upstream check ID.
+	Name        string   // Name is the control's name in the Pod Security Standards.
+	Level       Level    // Level is the lowest level that enforces the control.
+	Overrides   []string // Overrides are the ID(s) of baseline control(s) the control supersedes.
+	Description string   // Description summarizes what the control checks.
+
+	Check func(pod *Pod, report Report)
+}
+
+// capabilities are the capabilities the baseline level allows containers to add.
+var capabilities = set("AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD", "NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT")
+
+// selinux are the SELinux types the baseline level allows.
+var selinux = set("", "container_t", "container_init_t", "container_kvm_t", "container_engine_t")
+
+// sysctls are the "safe" sysctls the baseline level allows.
+var sysctls = set("kernel.shm_rmid_forced", "net.ipv4.ip_local_port_range", "net.ipv4.ip_unprivileged_port_start", "net.ipv4.tcp_syncookies", "net.ipv4.ping_group_range", "net.ipv4.ip_local_reserved_ports", "net.ipv4.tcp_keepalive_time", "net.ipv4.tcp_fin_timeout", "net.ipv4.tcp_keepalive_intvl", "net.ipv4.tcp_keepalive_probes")
+
+// volumes are the volume types the restricted level allows.
+var volumes = set("configMap", "csi", "downwardAPI", "emptyDir", "ephemeral", "persistentVolumeClaim", "projected", "secret")
+
+// Controls returns the controls level enforces, in the order of the Pod Security Standards.
+func Controls(level Level) []Control {
+	controls := []Control{
+		{
+			ID:          "hostProcess",
+			Name:        "HostProcess",
+			Level:       Baseline,
+			Description: "windows pods mustn't run as host processes",
+			Check: func(pod *Pod, report Report) {
+				if enabled(pod.Spec, "securityContext", "windowsOptions", "hostProcess") {
+					report("", pod.Path.Key("securityContext").Key("windowsOptions").Key("hostProcess"), "the pod runs as a host process")
+				}
+
+				for _, container := range pod.Containers {
+					if enabled(container.Node, "securityContext", "windowsOptions", "hostProcess") {
+						report(container.Name(), container.Path.Key("securityContext").Key("windowsOptions").Key("hostProcess"), fmt.Sprintf("container %q runs as a host process", container.Name()))
+					}
+				}
+			},
+		},
+		{
+			ID:          "hostNamespaces",
+			Name:        "Host Namespaces",
+			Level:       Baseline,
+			Description: "pods mustn't share the host's network, process, or ipc namespaces",
+			Check: func(pod *Pod, report Report) {
+				for _, field := range []string{"hostNetwork", "hostPID", "hostIPC"} {
+					if enabled(pod.Spec, field) {
+						report("", pod.Path.Key(field), fmt.Sprintf("the pod sets %s", field))
+					}
+				}
+			},
+		},
+		{
+			ID:          "privileged",
+			Name:        "Privileged Containers",
+			Level:       Baseline,
+			Description: "containers mustn't be privileged",
+			Check: func(pod *Pod, report Report) {
+				for _, container := range pod.Containers {
+					if enabled(container.Node, "securityContext", "privileged") {
+						report(container.Name(), container.Path.Key("securityContext").Key("privileged"), fmt.Sprintf("container %q is privileged", container.Name()))
+					}
+				}
+			},
+		},
+		{
+			ID:          "capabilities_baseline",
+			Name:        "Capabilities",
+			Level:       Baseline,
+			Description: "containers may only add capabilities of the default set",
+			Check: func(pod *Pod, report Report) {
+				for _, container := range pod.Containers {
+					var invalid []string
+					for _, capability := range values(container.Node, "securityContext", "capabilities", "add") {
+						if !(capabilities[capability]) {
+							invalid = append(invalid, capability)
+						}
+					}
+
+					if len(invalid) > 0 {
+						report(container.Name(), container.Path.Key("securityContext").Key("capabilities").Key("add"), fmt.Sprintf("container %q adds non-default capabilities: %s", container.Name(), strings.Join(invalid, ", ")))
+					}
+				}
+			},
+		},
+		{
+			ID:          "hostPathVolumes",
+			Name:        "HostPath Volumes",
+			Level:       Baseline,
+			Description: "pods mustn't mount hostPath volumes",
+			Check: func(pod *Pod, report Report) {
+				for index, volume := range manifests.Items(manifests.Value(pod.Spec, "volumes")) {
+					if manifests.Value(volume, "hostPath") != nil {
+						report("", pod.Path.Key("volumes").Index(index).Key("hostPath"), fmt.Sprintf("volume %q mounts a host path", manifests.Scalar(volume, "name")))
+					}
+				}
+			},
+		},
+		{
+			ID:          "hostPorts",
+			Name:        "Host Ports",
+			Level:       Baseline,
+			Description: "containers mustn't bind host ports",
+			Check: func(pod *Pod, report Report) {
+				for _, container := range pod.Containers {
+					for index, port := range manifests.Items(manifests.Value(container.Node, "ports")) {
+						if value := manifests.Scalar(port, "hostPort"); value != "" && value != "0" {
+							report(container.Name(), container.Path.Key("ports").Index(index).Key("hostPort"), fmt.Sprintf("container %q binds host port %s", container.Name(), value))
+						}
+					}
+				}
+			},
+		},
+		{
+			ID:          "appArmorProfile",
+			Name:        "AppArmor",
+			Level:       Baseline,
+			Description: "the AppArmor profile mustn't be unconfined",
+			Check: func(pod *Pod, report Report) {
+				permitted := set("", "RuntimeDefault", "Localhost")
+
+				if value := manifests.Scalar(pod.Spec, "securityContext", "appArmorProfile", "type"); !(permitted[value]) {
+					report("", pod.Path.Key("securityContext").Key("appArmorProfile").Key("type"), fmt.Sprintf("the pod's AppArmor profile is %s", value))
+				}
+
+				for _, container := range pod.Containers {
+					if value := manifests.Scalar(container.Node, "securityContext", "appArmorProfile", "type"); !(permitted[value]) {
+						report(container.Name(), container.Path.Key("securityContext").Key("appArmorProfile").Key("type"), fmt.Sprintf("container %q's AppArmor profile is %s", container.Name(), value))
+					}
+				}
+
+				// --> the deprecated, per-container annotation(s)
+				const prefix = "container.apparmor.security.beta.kubernetes.io/"
+				for _, key := range manifests.Keys(manifests.Value(pod.Metadata, "annotations")) {
+					name, annotated := strings.CutPrefix(key, prefix)
+					if !(annotated) {
+						continue
+					}
+
+					if value := manifests.Scalar(pod.Metadata, "annotations", key); value != "runtime/default" && !(strings.HasPrefix(value, "localhost/")) {
+						report(name, pod.Location.Key("annotations").Key(key), fmt.Sprintf("container %q's AppArmor profile annotation is %s", name, value))
+					}
+				}
+			},
+		},
+		{
+			ID:          "seLinuxOptions",
+			Name:        "SELinux",
+			Level:       Baseline,
+			Description: "SELinux options may only set a container type, and mustn't set a user or role",
+			Check: func(pod *Pod, report Report) {
+				check := func(container string, node *yaml.Node, path manifests.Path, subject string) {
+					options := manifests.Lookup(node, "securityContext", "seLinuxOptions")
+					if options == nil {
+						return
+					}
+
+					path = path.Key("securityContext").Key("seLinuxOptions")
+					if value := manifests.Scalar(options, "type"); !(selinux[value]) {
+						report(container, path.Key("type"), fmt.Sprintf("%s sets the SELinux type %s", subject, value))
+					}
+
+					for _, field := range []string{"user", "role"} {
+						if value := manifests.Scalar(options, field); value != "" {
+							report(container, path.Key(field), fmt.Sprintf("%s sets the SELinux %s %s", subject, field, value))
+						}
+					}
+				}
+
+				check("", pod.Spec, pod.Path, "the pod")
+				for _, container := range pod.Containers {
+					check(container.Name(), container.Node, container.Path, fmt.Sprintf("container %q", container.Name()))
+				}
+			},
+		},
+		{
+			ID:          "procMount",
+			Name:        "/proc Mount Type",
+			Level:       Baseline,
+			Description: "containers must use the default /proc mount",
+			Check: func(pod *Pod, report Report) {
+				for _, container := range pod.Containers {
+					if value := manifests.Scalar(container.Node, "securityContext", "procMount"); value != "" && value != "Default" {
+						report(container.Name(), container.Path.Key("securityContext").Key("procMount"), fmt.Sprintf("container %q uses the %s /proc mount", container.Name(), value))
+					}
+				}
+			},
+		},
+		{
+			ID:          "seccompProfile_baseline",
+			Name:        "Seccomp",
+			Level:       Baseline,
+			Description: "the seccomp profile mustn't be unconfined",
+			Check: func(pod *Pod, report Report) {
+				if manifests.Scalar(pod.Spec, "securityContext", "seccompProfile", "type") == "Unconfined" {
+					report("", pod.Path.Key("securityContext").Key("seccompProfile").Key("type"), "the pod's seccomp profile is Unconfined")
+				}
+
+				for _, container := range pod.Containers {
+					if manifests.Scalar(container.Node, "securityContext", "seccompProfile", "type") == "Unconfined" {
+						report(container.Name(), container.Path.Key("securityContext").Key("seccompProfile").Key("type"), fmt.Sprintf("container %q's seccomp profile is Unconfined", container.Name()))
+					}
+				}
+			},
+		},
+		{
+			ID:          "sysctls",
+			Name:        "Sysctls",
+			Level:       Baseline,
+			Description: "pods may only set safe sysctls",
+			Check: func(pod *Pod, report Report) {
+				for index, sysctl := range manifests.Items(manifests.Lookup(pod.Spec, "securityContext", "sysctls")) {
+					if name := manifests.Scalar(sysctl, "name"); !(sysctls[name]) {
+						report("", pod.Path.Key("securityContext").Key("sysctls").Index(index).Key("name"), fmt.Sprintf("the pod sets the unsafe sysctl %s", name))
+					}
+				}
+			},
+		},
+		{
+			ID:          "restrictedVolumes",
+			Name:        "Volume Types",
+			Level:       Restricted,
+			Description: "pods may only use configMap, csi, downwardAPI, emptyDir, ephemeral, persistentVolumeClaim, projected, and secret volumes",
+			Check: func(pod *Pod, report Report) {
+				for index, volume := range manifests.Items(manifests.Value(pod.Spec, "volumes")) {
+					for _, key := range manifests.Keys(volume) {
+						if key != "name" && !(volumes[key]) {
+							report("", pod.Path.Key("volumes").Index(index).Key(key), fmt.Sprintf("volume %q is of the restricted type %s", manifests.Scalar(volume, "name"), key))
+						}
+					}
+				}
+			},
+		},
+		{
+			ID:          "allowPrivilegeEscalation",
+			Name:        "Privilege Escalation",
+			Level:       Restricted,
+			Description: "containers must set allowPrivilegeEscalation to false",
+			Check: func(pod *Pod, report Report) {
+				if pod.Windows() {
+					return
+				}
+
+				for _, container := range pod.Containers {
+					if manifests.Scalar(container.Node, "securityContext", "allowPrivilegeEscalation") != "false" {
+						report(container.Name(), container.Path.Key("securityContext").Key("allowPrivilegeEscalation"), fmt.Sprintf("container %q must set allowPrivilegeEscalation to false", container.Name()))
+					}
+				}
+			},
+		},
+		{
+			ID:          "runAsNonRoot",
+			Name:        "Running as Non-root",
+			Level:       Restricted,
+			Description: "containers must set runAsNonRoot to true, directly or through the pod",
+			Check: func(pod *Pod, report Report) {
+				inherited := manifests.Scalar(pod.Spec, "securityContext", "runAsNonRoot")
+				if inherited == "false" {
+					report("", pod.Path.Key("securityContext").Key("runAsNonRoot"), "the pod sets runAsNonRoot to false")
+				}
+
+				for _, container := range pod.Containers {
+					switch manifests.Scalar(container.Node, "securityContext", "runAsNonRoot") {
+					case "true":
+					case "false":
+						report(container.Name(), container.Path.Key("securityContext").Key("runAsNonRoot"), fmt.Sprintf("container %q sets runAsNonRoot to false", container.Name()))
+					default:
+						if inherited != "true" {
+							report(container.Name(), container.Path.Key("securityContext").Key("runAsNonRoot"), fmt.Sprintf("container %q must set runAsNonRoot to true, or inherit it from the pod", container.Name()))
+						}
+					}
+				}
+			},
+		},
+		{
+			ID:          "runAsUser",
+			Name:        "Running as Non-root user",
+			Level:       Restricted,
+			Description: "pods and containers mustn't set runAsUser to 0",
+			Check: func(pod *Pod, report Report) {
+				if root(pod.Spec) {
+					report("", pod.Path.Key("securityContext").Key("runAsUser"), "the pod runs as the root user (0)")
+				}
+
+				for _, container := range pod.Containers {
+					if root(container.Node) {
+						report(container.Name(), container.Path.Key("securityContext").Key("runAsUser"), fmt.Sprintf("container %q runs as the root user (0)", container.Name()))
+					}
+				}
+			},
+		},
+		{
+			ID:          "seccompProfile_restricted",
+			Name:        "Seccomp",
+			Level:       Restricted,
+			Overrides:   []string{"seccompProfile_baseline"},
+			Description: "the seccomp profile must be RuntimeDefault or Localhost, set on the pod or on every container",
+			Check: func(pod *Pod, report Report) {
+				if pod.Windows() {
+					return
+				}
+
+				permitted := set("RuntimeDefault", "Localhost")
+
+				inherited := manifests.Scalar(pod.Spec, "securityContext", "seccompProfile", "type")
+				if inherited != "" && !(permitted[inherited]) {
+					report("", pod.Path.Key("securityContext").Key("seccompProfile").Key("type"), fmt.Sprintf("the pod's seccomp profile is %s", inherited))
+				}
+
+				for _, container := range pod.Containers {
+					path := container.Path.Key("securityContext").Key("seccompProfile").Key("type")
+					switch value := manifests.Scalar(container.Node, "securityContext", "seccompProfile", "type"); {
+					case value == "" && !(permitted[inherited]):
+						report(container.Name(), path, fmt.Sprintf("container %q must set a RuntimeDefault or Localhost seccomp profile, or inherit one from the pod", container.Name()))
+					case value != "" && !(permitted[value]):
+						report(container.Name(), path, fmt.Sprintf("container %q's seccomp profile is %s", container.Name(), value))
+					}
+				}
+			},
+		},
+		{
+			ID:          "capabilities_restricted",
+			Name:        "Capabilities",
+			Level:       Restricted,
+			Overrides:   []string{"capabilities_baseline"},
+			Description: "containers must drop ALL capabilities, and may only add NET_BIND_SERVICE",
+			Check: func(pod *Pod, report Report) {
+				if pod.Windows() {
+					return
+				}
+
+				for _, container := range pod.Containers {
+					path := container.Path.Key("securityContext").Key("capabilities")
+					if !(slices.Contains(values(container.Node, "securityContext", "capabilities", "drop"), "ALL")) {
+						report(container.Name(), path.Key("drop"), fmt.Sprintf("container %q must drop ALL capabilities", container.Name()))
+					}
+
+					var invalid []string
+					for _, capability := range values(container.Node, "securityContext", "capabilities", "add") {
+						if capability != "NET_BIND_SERVICE" {
+							invalid = append(invalid, capability)
+						}
+					}
+
+					if len(invalid) > 0 {
+						report(container.Name(), path.Key("add"), fmt.Sprintf("container %q adds capabilities other than NET_BIND_SERVICE: %s", container.Name(), strings.Join(invalid, ", ")))
+					}
+				}
+			},
+		},
+	}
+
+	overridden := make(map[string]bool)
+	for _, control := range controls {
+		if level.Includes(control.Level) {
+			for _, id := range control.Overrides {
+				overridden[id] = true
+			}
+		}
+	}
+
+	var enabled []Control
+	for _, control := range controls {
+		if level.Includes(control.Level) && !(overridden[control.ID]) {
+			enabled = append(enabled, control)
+		}
+	}
+
+	return enabled
+}
+
+// enabled reports whether the boolean at the keys' path is true.
+func enabled(node *yaml.Node, keys ...string) bool {
+	value, e := strconv.ParseBool(manifests.Scalar(node, keys...))
+
+	return e == nil && value
+}
+
+// root reports whether a pod or container's security context sets runAsUser to 0.
+func root(node *yaml.Node) bool {
+	return manifests.Scalar(node, "securityContext", "runAsUser") == "0"
+}
+
+// values returns the scalar items of the sequence at the keys' path.
+func values(node *yaml.Node, keys ...string) []string {
+	var items []string
+	for _, item := range manifests.Items(manifests.Lookup(node, keys...)) {
+		items = append(items, item.Value)
+	}
+
+	return items
+}
+
+func set(values ...string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+
+	return result
+}
@@ -0,0 +1,6 @@
+// Package pss evaluates the pod specifications of kubernetes manifests against the Pod Security Standards.
+//
+// Controls are identified by the check IDs of the upstream pod-security admission controller (e.g. "runAsNonRoot",
+// "capabilities_restricted"), so that findings correspond to the violations reported at admission time. The
+// "restricted" level includes every "baseline" control, other than those a restricted control supersedes.
+package pss
@@ -0,0 +1,39 @@
+package pss
+
+import (
+	"errors"
+)
+
+// Level string that implements Cobra's Type interface for valid string enumeration values.
+type Level string
+
+const (
+	Baseline   Level = "baseline"
+	Restricted Level = "restricted"
+)
+
+// String is used both by fmt.Print and by Cobra in help text
+func (l *Level) String() string {
+	return string(*l)
+}
+
+// Set must have pointer receiver so it doesn't change the value of a copy
+func (l *Level) Set(v string) error {
+	switch v {
+	case "baseline", "restricted":
+		*l = Level(v)
+		return nil
+	default:
+		return errors.New("must be one of \"baseline\" or \"restricted\"")
+	}
+}
+
+// Type is only used in help text
+func (l *Level) Type() string {
+	return "[\"baseline\"|\"restricted\"]"
+}
+
+// Includes reports whether the level enforces the controls of level c.
+func (l Level) Includes(c Level) bool {
+	return l == Restricted || c == Baseline
+}
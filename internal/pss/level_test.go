@@ -0,0 +1,37 @@
+package pss
+
+import (
+	"testing"
+)
+
+func TestLevelSet(t *testing.T) {
+	for _, value := range []string{"baseline", "restricted"} {
+		var level Level
+		if e := level.Set(value); e != nil || level.String() != value {
+			t.Errorf("Set(%s) = (%s, %v), expected %s", value, level.String(), e, value)
+		}
+	}
+
+	var level Level
+	if e := level.Set("privileged"); e == nil {
+		t.Errorf("Set(privileged) expected an error")
+	}
+}
+
+func TestLevelIncludes(t *testing.T) {
+	tests := []struct {
+		level, control Level
+		expected       bool
+	}{
+		{level: Baseline, control: Baseline, expected: true},
+		{level: Baseline, control: Restricted, expected: false},
+		{level: Restricted, control: Baseline, expected: true},
+		{level: Restricted, control: Restricted, expected: true},
+	}
+
+	for _, test := range tests {
+		if included := test.level.Includes(test.control); included != test.expected {
+			t.Errorf("%s.Includes(%s) = %t, expected %t", test.level, test.control, included, test.expected)
+		}
+	}
+}
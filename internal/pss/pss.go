@@ -0,0 +1,85 @@
+package pss
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Finding represents a single control violation.
+type Finding struct {
+	File      string `json:"file" yaml:"file"`
+	Document  int    `json:"document" yaml:"document"`
+	Resource  string `json:"resource" yaml:"resource"`
+	Container string `json:"container,omitempty" yaml:"container,omitempty"` // Container is empty for violations of the pod's own specification.
+	Path      string `json:"path" yaml:"path"`
+	Control   string `json:"control" yaml:"control"`
+	Level     Level  `json:"level" yaml:"level"`
+	Message   string `json:"message" yaml:"message"`
+}
+
+// Pod represents the pod specification of a pod-bearing document.
+type Pod struct {
+	Spec       *yaml.Node     // Spec is the pod specification.
+	Path       manifests.Path // Path is the pod specification's location within its document.
+	Metadata   *yaml.Node     // Metadata is the pod's - or pod template's - metadata.
+	Location   manifests.Path // Location is the metadata's location within its document.
+	Containers []manifests.Container
+}
+
+// Windows reports whether the pod declares the windows operating system - which exempts it from the linux-only
+// restricted controls.
+func (p *Pod) Windows() bool {
+	return manifests.Scalar(p.Spec, "os", "name") == "windows"
+}
+
+// Check evaluates every pod-bearing document against the controls of level, returning violations ordered by file,
+// document, and path.
+func Check(level Level, documents ...*manifests.Document) []Finding {
+	controls := Controls(level)
+
+	var findings []Finding
+	for _, document := range documents {
+		spec, path := document.PodSpec()
+		if spec == nil {
+			continue
+		}
+
+		metadata, location := document.PodTemplate()
+		if location == nil {
+			metadata, location = manifests.Value(document.Root(), "metadata"), manifests.Path{"metadata"}
+		}
+
+		pod := &Pod{Spec: spec, Path: path, Metadata: metadata, Location: location, Containers: document.Containers()}
+		for _, control := range controls {
+			control.Check(pod, func(container string, path manifests.Path, message string) {
+				findings = append(findings, Finding{
+					File:      document.File,
+					Document:  document.Index,
+					Resource:  document.String(),
+					Container: container,
+					Path:      path.String(),
+					Control:   control.ID,
+					Level:     control.Level,
+					Message:   message,
+				})
+			})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+
+		if findings[i].Document != findings[j].Document {
+			return findings[i].Document < findings[j].Document
+		}
+
+		return findings[i].Path < findings[j].Path
+	})
+
+	return findings
+}
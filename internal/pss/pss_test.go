@@ -0,0 +1,176 @@
+package pss
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// compliant is a Deployment that satisfies the restricted level; each test case replaces one of its lines.
+const compliant = `apiVersion: apps/v1
+kind: Deployment
+metadata: { name: api }
+spec:
+    template:
+        metadata:
+            annotations: { example.io/annotation: value }
+        spec:
+            securityContext: { runAsNonRoot: true, seccompProfile: { type: RuntimeDefault } }
+            volumes: [ { name: cache, emptyDir: {} } ]
+            containers:
+                - name: api
+                  image: api:1.0.0
+                  securityContext: { allowPrivilegeEscalation: false, capabilities: { drop: [ ALL ] } }
+`
+
+func TestCheck(t *testing.T) {
+	const (
+		pod       = "            securityContext: { runAsNonRoot: true, seccompProfile: { type: RuntimeDefault } }\n"
+		container = "                  securityContext: { allowPrivilegeEscalation: false, capabilities: { drop: [ ALL ] } }\n"
+		volume    = "            volumes: [ { name: cache, emptyDir: {} } ]\n"
+	)
+
+	tests := []struct {
+		name       string
+		old, new   string
+		baseline   []string // baseline are the expected findings at the baseline level - "<control> <path>"
+		restricted []string // restricted are the expected findings at the restricted level
+	}{
+		{name: "compliant"},
+		{
+			name:       "host-namespaces",
+			old:        pod,
+			new:        pod + "            hostNetwork: true\n            hostPID: false\n",
+			baseline:   []string{"hostNamespaces spec.template.spec.hostNetwork"},
+			restricted: []string{"hostNamespaces spec.template.spec.hostNetwork"},
+		},
+		{
+			name:       "privileged",
+			old:        container,
+			new:        "                  securityContext: { privileged: true, allowPrivilegeEscalation: false, capabilities: { drop: [ ALL ], add: [ NET_ADMIN ] } }\n",
+			baseline:   []string{"capabilities_baseline spec.template.spec.containers[0].securityContext.capabilities.add", "privileged spec.template.spec.containers[0].securityContext.privileged"},
+			restricted: []string{"capabilities_restricted spec.template.spec.containers[0].securityContext.capabilities.add", "privileged spec.template.spec.containers[0].securityContext.privileged"},
+		},
+		{
+			name:       "host-path",
+			old:        volume,
+			new:        "            volumes: [ { name: host, hostPath: { path: /var } } ]\n",
+			baseline:   []string{"hostPathVolumes spec.template.spec.volumes[0].hostPath"},
+			restricted: []string{"hostPathVolumes spec.template.spec.volumes[0].hostPath", "restrictedVolumes spec.template.spec.volumes[0].hostPath"},
+		},
+		{
+			name:       "unconfined-seccomp",
+			old:        pod,
+			new:        "            securityContext: { runAsNonRoot: true, seccompProfile: { type: Unconfined } }\n",
+			baseline:   []string{"seccompProfile_baseline spec.template.spec.securityContext.seccompProfile.type"},
+			restricted: []string{"seccompProfile_restricted spec.template.spec.containers[0].securityContext.seccompProfile.type", "seccompProfile_restricted spec.template.spec.securityContext.seccompProfile.type"},
+		},
+		{
+			name: "restricted-container",
+			old:  container,
+			new:  "                  securityContext: { runAsUser: 0, capabilities: { add: [ NET_BIND_SERVICE ] } }\n",
+			restricted: []string{
+				"allowPrivilegeEscalation spec.template.spec.containers[0].securityContext.allowPrivilegeEscalation",
+				"capabilities_restricted spec.template.spec.containers[0].securityContext.capabilities.drop",
+				"runAsUser spec.template.spec.containers[0].securityContext.runAsUser",
+			},
+		},
+		{
+			name:       "inherited-non-root",
+			old:        pod,
+			new:        "            securityContext: { seccompProfile: { type: RuntimeDefault } }\n",
+			restricted: []string{"runAsNonRoot spec.template.spec.containers[0].securityContext.runAsNonRoot"},
+		},
+		{
+			name:       "apparmor-annotation",
+			old:        "            annotations: { example.io/annotation: value }\n",
+			new:        "            annotations: { container.apparmor.security.beta.kubernetes.io/api: unconfined }\n",
+			baseline:   []string{"appArmorProfile spec.template.metadata.annotations[\"container.apparmor.security.beta.kubernetes.io/api\"]"},
+			restricted: []string{"appArmorProfile spec.template.metadata.annotations[\"container.apparmor.security.beta.kubernetes.io/api\"]"},
+		},
+		{
+			name: "windows",
+			old:  container,
+			new:  "                  securityContext: {}\n            os: { name: windows }\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !(strings.Contains(compliant, test.old)) {
+				t.Fatalf("the compliant deployment doesn't contain %q", test.old)
+			}
+
+			documents, e := manifests.Decode("deployment.yaml", strings.NewReader(strings.Replace(compliant, test.old, test.new, 1)))
+			if e != nil {
+				t.Fatalf("unable to decode document: %v", e)
+			}
+
+			for level, expected := range map[Level][]string{Baseline: test.baseline, Restricted: test.restricted} {
+				var findings []string
+				for _, finding := range Check(level, documents...) {
+					findings = append(findings, fmt.Sprintf("%s %s", finding.Control, finding.Path))
+				}
+
+				slices.Sort(findings)
+
+				if strings.Join(findings, "\n") != strings.Join(expected, "\n") {
+					t.Errorf("Check(%s) =\n%s\nexpected:\n%s", level, strings.Join(findings, "\n"), strings.Join(expected, "\n"))
+				}
+			}
+		})
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	documents, e := manifests.Decode("pods.yaml", strings.NewReader("apiVersion: v1\nkind: ConfigMap\nmetadata: { name: settings }\n---\napiVersion: v1\nkind: Pod\nmetadata: { name: first }\nspec: { hostPID: true, hostIPC: true, containers: [ { name: first, image: first } ] }\n---\napiVersion: batch/v1\nkind: CronJob\nmetadata: { name: second }\nspec: { jobTemplate: { spec: { template: { spec: { hostNetwork: true, containers: [ { name: second, image: second } ] } } } } }\n"))
+	if e != nil {
+		t.Fatalf("unable to decode documents: %v", e)
+	}
+
+	var findings []string
+	for _, finding := range Check(Baseline, documents...) {
+		findings = append(findings, fmt.Sprintf("%s[%d] %s: %s", finding.Resource, finding.Document, finding.Path, finding.Message))
+	}
+
+	expected := []string{
+		"pod/first[1] spec.hostIPC: the pod sets hostIPC",
+		"pod/first[1] spec.hostPID: the pod sets hostPID",
+		"cronjob/second[2] spec.jobTemplate.spec.template.spec.hostNetwork: the pod sets hostNetwork",
+	}
+
+	if strings.Join(findings, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Check() =\n%s\nexpected:\n%s", strings.Join(findings, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestControls(t *testing.T) {
+	identifiers := func(controls []Control) []string {
+		var ids []string
+		for _, control := range controls {
+			ids = append(ids, control.ID)
+		}
+
+		return ids
+	}
+
+	baseline, restricted := identifiers(Controls(Baseline)), identifiers(Controls(Restricted))
+
+	if !(slices.Contains(baseline, "seccompProfile_baseline")) || slices.Contains(baseline, "seccompProfile_restricted") || slices.Contains(baseline, "runAsNonRoot") {
+		t.Errorf("Controls(baseline) = %v, expected only baseline controls", baseline)
+	}
+
+	// --> restricted controls replace the baseline controls they supersede
+	for _, id := range []string{"seccompProfile_baseline", "capabilities_baseline"} {
+		if slices.Contains(restricted, id) {
+			t.Errorf("Controls(restricted) = %v, expected %s to be overridden", restricted, id)
+		}
+	}
+
+	if !(slices.Contains(restricted, "hostNamespaces")) || !(slices.Contains(restricted, "seccompProfile_restricted")) {
+		t.Errorf("Controls(restricted) = %v, expected both baseline and restricted controls", restricted)
+	}
+}
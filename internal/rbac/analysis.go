@@ -0,0 +1,281 @@
+package rbac
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Cluster is the [Grant.Scope] of permissions granted by a ClusterRoleBinding.
+const Cluster = "*"
+
+// builtin are the default ClusterRoles whose rules are evaluated even when they aren't part of the bundle.
+var builtin = map[string][]Rule{
+	"cluster-admin": {{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+}
+
+// Subject represents a binding's subject.
+type Subject struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+func (s Subject) String() string {
+	if s.Namespace != "" {
+		return fmt.Sprintf("%s/%s/%s", strings.ToLower(s.Kind), s.Namespace, s.Name)
+	}
+
+	return fmt.Sprintf("%s/%s", strings.ToLower(s.Kind), s.Name)
+}
+
+// Grant represents a subject's permission, and the binding and role that grant it.
+type Grant struct {
+	Subject Subject `json:"subject" yaml:"subject"`
+	Scope   string  `json:"scope" yaml:"scope"`     // Scope is the namespace the permission applies to, or [Cluster].
+	Binding string  `json:"binding" yaml:"binding"` // Binding is the kubectl-style reference of the granting binding.
+	Role    string  `json:"role" yaml:"role"`       // Role is the kubectl-style reference of the bound role.
+}
+
+// Analysis indexes the RBAC resources of a manifest bundle.
+type Analysis struct {
+	roles    map[string]*manifests.Document // roles are Roles by "<namespace>/<name>"
+	clusters map[string]*manifests.Document // clusters are ClusterRoles by name
+	bindings []*manifests.Document
+}
+
+// Analyze indexes the Roles, ClusterRoles, RoleBindings, and ClusterRoleBindings of the documents. Namespaced resources
+// that don't declare a namespace are attributed to namespace.
+func Analyze(namespace string, documents ...*manifests.Document) *Analysis {
+	analysis := &Analysis{roles: make(map[string]*manifests.Document), clusters: make(map[string]*manifests.Document)}
+	for _, document := range documents {
+		if document.Empty() || document.Group() != "rbac.authorization.k8s.io" {
+			continue
+		}
+
+		switch document.Kind() {
+		case "Role":
+			analysis.roles[scope(document, namespace)+"/"+document.Name()] = document
+		case "ClusterRole":
+			analysis.clusters[document.Name()] = document
+		case "RoleBinding", "ClusterRoleBinding":
+			analysis.bindings = append(analysis.bindings, document)
+		}
+	}
+
+	return analysis
+}
+
+// WhoCan returns every subject granted verb on resource, sorted by subject. A non-empty namespace limits the
+// RoleBindings considered to those of that namespace; otherwise, every namespace is considered, and each grant's Scope
+// reports where it applies. Only ClusterRoleBindings grant access to cluster-scoped resources. Resources that don't
+// declare a namespace are attributed to fallback. WhoCan also returns the role references it couldn't evaluate - roles
+// that aren't part of the bundle (e.g. the built-in "edit").
+func (a *Analysis) WhoCan(verb string, resource Resource, namespace, fallback string) ([]Grant, []string) {
+	var grants []Grant
+
+	unresolved := make(map[string]bool)
+	for _, binding := range a.bindings {
+		cluster := binding.Kind() == "ClusterRoleBinding"
+
+		where := Cluster
+		if !(cluster) {
+			where = scope(binding, fallback)
+			if clustered[resource.Resource] || (namespace != "" && where != namespace) {
+				continue
+			}
+		}
+
+		kind, name := manifests.Scalar(binding.Root(), "roleRef", "kind"), manifests.Scalar(binding.Root(), "roleRef", "name")
+
+		var rules []Rule
+		var resolved bool
+		switch kind {
+		case "Role":
+			if cluster {
+				continue
+			}
+
+			var role *manifests.Document
+			if role, resolved = a.roles[where+"/"+name]; resolved {
+				rules = decode(role)
+			}
+		case "ClusterRole":
+			rules, resolved = a.aggregate(name, make(map[string]bool))
+		}
+
+		reference := manifests.Reference(kind, name)
+		if !(resolved) {
+			unresolved[reference] = true
+			continue
+		}
+
+		if !(allows(rules, verb, resource)) {
+			continue
+		}
+
+		for _, item := range manifests.Items(manifests.Value(binding.Root(), "subjects")) {
+			subject := Subject{Kind: manifests.Scalar(item, "kind"), Name: manifests.Scalar(item, "name"), Namespace: manifests.Scalar(item, "namespace")}
+			if subject.Kind == "ServiceAccount" && subject.Namespace == "" && !(cluster) {
+				subject.Namespace = where
+			}
+
+			grants = append(grants, Grant{Subject: subject, Scope: where, Binding: binding.String(), Role: reference})
+		}
+	}
+
+	sort.SliceStable(grants, func(i, j int) bool {
+		return grants[i].Subject.String() < grants[j].Subject.String()
+	})
+
+	references := make([]string, 0, len(unresolved))
+	for reference := range unresolved {
+		references = append(references, reference)
+	}
+
+	sort.Strings(references)
+
+	return grants, references
+}
+
+// aggregate returns a ClusterRole's rules - including those of the ClusterRoles its "aggregationRule" selects - and
+// whether the ClusterRole could be resolved.
+func (a *Analysis) aggregate(name string, visited map[string]bool) ([]Rule, bool) {
+	if visited[name] {
+		return nil, true
+	}
+
+	visited[name] = true
+
+	role, exists := a.clusters[name]
+	if !(exists) {
+		rules, exists := builtin[name]
+		return rules, exists
+	}
+
+	rules := decode(role)
+	for _, selector := range manifests.Items(manifests.Lookup(role.Root(), "aggregationRule", "clusterRoleSelectors")) {
+		for _, candidate := range sortedRoles(a.clusters) {
+			if candidate.Name() != name && selects(selector, candidate.Labels()) {
+				aggregated, _ := a.aggregate(candidate.Name(), visited)
+				rules = append(rules, aggregated...)
+			}
+		}
+	}
+
+	return rules, true
+}
+
+// allows reports whether any rule grants verb on resource - following the API server's rule matching, including
+// "*" wildcards and "*/<subresource>" resources.
+func allows(rules []Rule, verb string, resource Resource) bool {
+	for _, rule := range rules {
+		if !(slices.Contains(rule.Verbs, "*") || slices.Contains(rule.Verbs, verb)) {
+			continue
+		}
+
+		if !(slices.Contains(rule.APIGroups, "*") || slices.Contains(rule.APIGroups, resource.Group)) {
+			continue
+		}
+
+		matched := false
+		for _, candidate := range rule.Resources {
+			if candidate == "*" || candidate == resource.Path() || (resource.Subresource != "" && candidate == "*/"+resource.Subresource) {
+				matched = true
+				break
+			}
+		}
+
+		if !(matched) {
+			continue
+		}
+
+		if len(rule.ResourceNames) == 0 || (resource.Name != "" && slices.Contains(rule.ResourceNames, resource.Name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selects reports whether a label selector's "matchLabels" and "matchExpressions" all match labels.
+func selects(selector *yaml.Node, labels map[string]string) bool {
+	if !(manifests.Matches(manifests.Map(manifests.Value(selector, "matchLabels")), labels)) {
+		return false
+	}
+
+	for _, expression := range manifests.Items(manifests.Value(selector, "matchExpressions")) {
+		key := manifests.Scalar(expression, "key")
+
+		var values []string
+		for _, item := range manifests.Items(manifests.Value(expression, "values")) {
+			values = append(values, item.Value)
+		}
+
+		value, exists := labels[key]
+		switch manifests.Scalar(expression, "operator") {
+		case "In":
+			if !(exists) || !(slices.Contains(values, value)) {
+				return false
+			}
+		case "NotIn":
+			if exists && slices.Contains(values, value) {
+				return false
+			}
+		case "Exists":
+			if !(exists) {
+				return false
+			}
+		case "DoesNotExist":
+			if exists {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// decode returns a role's rules - rules that can't be decoded are skipped.
+func decode(role *manifests.Document) []Rule {
+	var rules []Rule
+	for _, item := range manifests.Items(manifests.Value(role.Root(), "rules")) {
+		var rule Rule
+		if e := item.Decode(&rule); e == nil {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// scope returns a namespaced document's namespace, or fallback if it doesn't declare one.
+func scope(document *manifests.Document, fallback string) string {
+	if namespace := document.Namespace(); namespace != "" {
+		return namespace
+	}
+
+	return fallback
+}
+
+// sortedRoles returns the ClusterRoles ordered by name, so that aggregation is deterministic.
+func sortedRoles(roles map[string]*manifests.Document) []*manifests.Document {
+	names := make(map[string]bool, len(roles))
+	for name := range roles {
+		names[name] = true
+	}
+
+	documents := make([]*manifests.Document, 0, len(roles))
+	for _, name := range sorted(names) {
+		documents = append(documents, roles[name])
+	}
+
+	return documents
+}
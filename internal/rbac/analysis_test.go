@@ -0,0 +1,172 @@
+package rbac
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// bundle grants secret access through a Role, an aggregated ClusterRole, the built-in "cluster-admin", and the
+// unresolvable built-in "edit".
+const bundle = `apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata: { name: secrets }
+rules:
+    - { apiGroups: [ "" ], resources: [ secrets ], resourceNames: [ database ], verbs: [ get ] }
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata: { name: api }
+roleRef: { apiGroup: rbac.authorization.k8s.io, kind: Role, name: secrets }
+subjects: [ { kind: ServiceAccount, name: api } ]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata: { name: reader }
+aggregationRule:
+    clusterRoleSelectors:
+        - matchLabels: { aggregate: reader }
+          matchExpressions: [ { key: tier, operator: In, values: [ read ] } ]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata: { name: secret-reader, labels: { aggregate: reader, tier: read } }
+rules:
+    - { apiGroups: [ "" ], resources: [ secrets, "*/status" ], verbs: [ get, list ] }
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata: { name: secret-writer, labels: { aggregate: reader, tier: write } }
+rules:
+    - { apiGroups: [ "*" ], resources: [ "*" ], verbs: [ "*" ] }
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata: { name: readers, namespace: staging }
+roleRef: { apiGroup: rbac.authorization.k8s.io, kind: ClusterRole, name: reader }
+subjects: [ { kind: Group, name: readers } ]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata: { name: administrators }
+roleRef: { apiGroup: rbac.authorization.k8s.io, kind: ClusterRole, name: cluster-admin }
+subjects: [ { kind: User, name: administrator } ]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata: { name: editors }
+roleRef: { apiGroup: rbac.authorization.k8s.io, kind: ClusterRole, name: edit }
+subjects: [ { kind: Group, name: editors } ]
+`
+
+func TestAnalysisWhoCan(t *testing.T) {
+	documents, e := manifests.Decode("bundle.yaml", strings.NewReader(bundle))
+	if e != nil {
+		t.Fatalf("unable to decode documents: %v", e)
+	}
+
+	analysis := Analyze("default", documents...)
+
+	tests := []struct {
+		name      string
+		verb      string
+		resource  string
+		namespace string
+		expected  []string // expected are the grants - "<subject> (<scope>): <binding> -> <role>"
+	}{
+		{
+			name:     "named-resource",
+			verb:     "get",
+			resource: "secrets/database",
+			expected: []string{
+				"group/readers (staging): rolebinding/readers -> clusterrole/reader",
+				"serviceaccount/default/api (default): rolebinding/api -> role/secrets",
+				"user/administrator (*): clusterrolebinding/administrators -> clusterrole/cluster-admin",
+			},
+		},
+		{
+			name:     "unnamed-resource",
+			verb:     "get",
+			resource: "secrets",
+			expected: []string{
+				"group/readers (staging): rolebinding/readers -> clusterrole/reader",
+				"user/administrator (*): clusterrolebinding/administrators -> clusterrole/cluster-admin",
+			},
+		},
+		{
+			name:      "namespace",
+			verb:      "list",
+			resource:  "secrets",
+			namespace: "default",
+			expected:  []string{"user/administrator (*): clusterrolebinding/administrators -> clusterrole/cluster-admin"},
+		},
+		{
+			name:     "subresource-wildcard",
+			verb:     "get",
+			resource: "pods/status",
+			expected: []string{
+				"group/readers (staging): rolebinding/readers -> clusterrole/reader",
+				"user/administrator (*): clusterrolebinding/administrators -> clusterrole/cluster-admin",
+			},
+		},
+		{
+			name:     "cluster-scoped",
+			verb:     "get",
+			resource: "nodes",
+			expected: []string{"user/administrator (*): clusterrolebinding/administrators -> clusterrole/cluster-admin"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			grants, unresolved := analysis.WhoCan(test.verb, ParseResource(test.resource), test.namespace, "default")
+
+			var results []string
+			for _, grant := range grants {
+				results = append(results, fmt.Sprintf("%s (%s): %s -> %s", grant.Subject, grant.Scope, grant.Binding, grant.Role))
+			}
+
+			if strings.Join(results, "\n") != strings.Join(test.expected, "\n") {
+				t.Errorf("WhoCan(%s, %s) =\n%s\nexpected:\n%s", test.verb, test.resource, strings.Join(results, "\n"), strings.Join(test.expected, "\n"))
+			}
+
+			// --> the "edit" RoleBinding (of the "default" namespace) is only considered for namespaced resources
+			expected := []string{"clusterrole/edit"}
+			if test.resource == "nodes" {
+				expected = nil
+			}
+
+			if !(slices.Equal(unresolved, expected)) {
+				t.Errorf("WhoCan(%s, %s) unresolved = %v, expected %v", test.verb, test.resource, unresolved, expected)
+			}
+		})
+	}
+}
+
+func TestSelects(t *testing.T) {
+	documents, e := manifests.Decode("selector.yaml", strings.NewReader("matchLabels: { app: api }\nmatchExpressions:\n    - { key: tier, operator: NotIn, values: [ write ] }\n    - { key: deprecated, operator: DoesNotExist }\n    - { key: team, operator: Exists }\n"))
+	if e != nil {
+		t.Fatalf("unable to decode selector: %v", e)
+	}
+
+	tests := []struct {
+		labels   map[string]string
+		expected bool
+	}{
+		{labels: map[string]string{"app": "api", "team": "platform"}, expected: true},
+		{labels: map[string]string{"app": "api", "team": "platform", "tier": "read"}, expected: true},
+		{labels: map[string]string{"app": "api", "team": "platform", "tier": "write"}, expected: false},
+		{labels: map[string]string{"app": "api", "team": "platform", "deprecated": "true"}, expected: false},
+		{labels: map[string]string{"app": "api"}, expected: false},
+		{labels: map[string]string{"app": "web", "team": "platform"}, expected: false},
+	}
+
+	for _, test := range tests {
+		if matched := selects(documents[0].Root(), test.labels); matched != test.expected {
+			t.Errorf("selects(%v) = %t, expected %t", test.labels, matched, test.expected)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package rbac generates kubernetes RBAC resources from concise permission specifications, and evaluates the
+// permissions a manifest bundle's Roles, ClusterRoles, and bindings grant - without cluster access.
+package rbac
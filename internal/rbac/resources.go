@@ -0,0 +1,158 @@
+package rbac
+
+import (
+	"strings"
+)
+
+// groups maps the plural names of common, non-core resources to their API group, so they can be referenced without one
+// (e.g. "deployments" rather than "deployments.apps"). Any other resource without a group is a core resource.
+var groups = map[string]string{
+	"controllerrevisions":             "apps",
+	"daemonsets":                      "apps",
+	"deployments":                     "apps",
+	"replicasets":                     "apps",
+	"statefulsets":                    "apps",
+	"cronjobs":                        "batch",
+	"jobs":                            "batch",
+	"horizontalpodautoscalers":        "autoscaling",
+	"poddisruptionbudgets":            "policy",
+	"ingresses":                       "networking.k8s.io",
+	"ingressclasses":                  "networking.k8s.io",
+	"networkpolicies":                 "networking.k8s.io",
+	"clusterrolebindings":             "rbac.authorization.k8s.io",
+	"clusterroles":                    "rbac.authorization.k8s.io",
+	"rolebindings":                    "rbac.authorization.k8s.io",
+	"roles":                           "rbac.authorization.k8s.io",
+	"csidrivers":                      "storage.k8s.io",
+	"csinodes":                        "storage.k8s.io",
+	"storageclasses":                  "storage.k8s.io",
+	"volumeattachments":               "storage.k8s.io",
+	"leases":                          "coordination.k8s.io",
+	"endpointslices":                  "discovery.k8s.io",
+	"priorityclasses":                 "scheduling.k8s.io",
+	"runtimeclasses":                  "node.k8s.io",
+	"certificatesigningrequests":      "certificates.k8s.io",
+	"customresourcedefinitions":       "apiextensions.k8s.io",
+	"mutatingwebhookconfigurations":   "admissionregistration.k8s.io",
+	"validatingwebhookconfigurations": "admissionregistration.k8s.io",
+}
+
+// subresources are the names recognized as a subresource - rather than a resource name - after a resource's "/".
+var subresources = map[string]bool{
+	"attach":              true,
+	"binding":             true,
+	"ephemeralcontainers": true,
+	"eviction":            true,
+	"exec":                true,
+	"finalizers":          true,
+	"log":                 true,
+	"portforward":         true,
+	"proxy":               true,
+	"scale":               true,
+	"status":              true,
+	"token":               true,
+}
+
+// clustered are the common cluster-scoped resources - which a RoleBinding can't grant access to.
+var clustered = map[string]bool{
+	"certificatesigningrequests":      true,
+	"clusterrolebindings":             true,
+	"clusterroles":                    true,
+	"csidrivers":                      true,
+	"csinodes":                        true,
+	"customresourcedefinitions":       true,
+	"ingressclasses":                  true,
+	"mutatingwebhookconfigurations":   true,
+	"nodes":                           true,
+	"persistentvolumes":               true,
+	"priorityclasses":                 true,
+	"runtimeclasses":                  true,
+	"storageclasses":                  true,
+	"validatingwebhookconfigurations": true,
+	"volumeattachments":               true,
+}
+
+// verbs are the valid RBAC verbs.
+var verbs = map[string]bool{
+	"*":                true,
+	"get":              true,
+	"list":             true,
+	"watch":            true,
+	"create":           true,
+	"update":           true,
+	"patch":            true,
+	"delete":           true,
+	"deletecollection": true,
+	"use":              true,
+	"bind":             true,
+	"escalate":         true,
+	"impersonate":      true,
+	"approve":          true,
+	"sign":             true,
+}
+
+// Resource represents a resource reference - "<resource>[.<group>][/<subresource>][/<name>]" (e.g. "pods/log",
+// "deployments.apps/scale", "secrets/database"). A resource without a group is resolved through a table of common
+// resources, and is otherwise a core resource.
+type Resource struct {
+	Group       string `json:"group" yaml:"group"`
+	Resource    string `json:"resource" yaml:"resource"`
+	Subresource string `json:"subresource,omitempty" yaml:"subresource,omitempty"`
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// ParseResource parses a resource reference.
+func ParseResource(reference string) Resource {
+	partials := strings.Split(strings.TrimSpace(reference), "/")
+
+	var resource Resource
+
+	resource.Resource = strings.ToLower(partials[0])
+	if name, group, qualified := strings.Cut(resource.Resource, "."); qualified {
+		resource.Resource, resource.Group = name, group
+	} else {
+		resource.Group = groups[resource.Resource]
+	}
+
+	partials = partials[1:]
+	if len(partials) > 0 && subresources[partials[0]] {
+		resource.Subresource, partials = partials[0], partials[1:]
+	}
+
+	if len(partials) > 0 {
+		resource.Name = strings.Join(partials, "/")
+	}
+
+	return resource
+}
+
+// Path returns the resource as an RBAC rule names it - including its subresource (e.g. "pods/log").
+func (r Resource) Path() string {
+	if r.Subresource != "" {
+		return r.Resource + "/" + r.Subresource
+	}
+
+	return r.Resource
+}
+
+func (r Resource) String() string {
+	reference := r.Resource
+	if r.Group != "" {
+		reference += "." + r.Group
+	}
+
+	if r.Subresource != "" {
+		reference += "/" + r.Subresource
+	}
+
+	if r.Name != "" {
+		reference += "/" + r.Name
+	}
+
+	return reference
+}
+
+// Verb reports whether verb is a valid RBAC verb.
+func Verb(verb string) bool {
+	return verbs[verb]
+}
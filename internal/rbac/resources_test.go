@@ -0,0 +1,37 @@
+package rbac
+
+import (
+	"testing"
+)
+
+func TestParseResource(t *testing.T) {
+	tests := []struct {
+		reference string
+		expected  Resource
+		path      string
+	}{
+		{reference: "pods", expected: Resource{Resource: "pods"}, path: "pods"},
+		{reference: "Deployments", expected: Resource{Group: "apps", Resource: "deployments"}, path: "deployments"},
+		{reference: "deployments.apps/scale", expected: Resource{Group: "apps", Resource: "deployments", Subresource: "scale"}, path: "deployments/scale"},
+		{reference: "pods/log/example", expected: Resource{Resource: "pods", Subresource: "log", Name: "example"}, path: "pods/log"},
+		{reference: "secrets/database", expected: Resource{Resource: "secrets", Name: "database"}, path: "secrets"},
+		{reference: "widgets.example.io", expected: Resource{Group: "example.io", Resource: "widgets"}, path: "widgets"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			resource := ParseResource(test.reference)
+			if resource != test.expected {
+				t.Errorf("ParseResource(%s) = %+v, expected %+v", test.reference, resource, test.expected)
+			}
+
+			if resource.Path() != test.path {
+				t.Errorf("Path() = %s, expected %s", resource.Path(), test.path)
+			}
+
+			if reparsed := ParseResource(resource.String()); reparsed != resource {
+				t.Errorf("ParseResource(%s) = %+v, expected the String() round trip to be %+v", resource.String(), reparsed, resource)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package rbac
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Rule represents a Role or ClusterRole policy rule.
+type Rule struct {
+	APIGroups       []string `json:"apiGroups,omitempty" yaml:"apiGroups,omitempty"`
+	Resources       []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+	ResourceNames   []string `json:"resourceNames,omitempty" yaml:"resourceNames,omitempty"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty" yaml:"nonResourceURLs,omitempty"`
+	Verbs           []string `json:"verbs" yaml:"verbs"`
+}
+
+// Node converts the rule into its kubernetes representation.
+func (r Rule) Node() *yaml.Node {
+	groups := manifests.Sequence()
+	for _, group := range r.APIGroups {
+		groups.Content = append(groups.Content, manifests.String(group))
+	}
+
+	return manifests.Object(
+		"apiGroups", groups,
+		"resources", r.Resources,
+		"resourceNames", r.ResourceNames,
+		"nonResourceURLs", r.NonResourceURLs,
+		"verbs", r.Verbs,
+	)
+}
+
+// ParseRules parses a permission specification - ";"-separated "<resource>[,<resource>...]:<verb>[,<verb>...]" entries,
+// where each resource is a [Resource] reference (e.g. "pods:get,list;pods/log:get;secrets/database:get"). Entries are
+// consolidated into as few rules as possible: resources of the same group that share verbs form a single rule, while
+// named resources form a rule per resource and verbs, listing every name.
+func ParseRules(specification string) ([]Rule, error) {
+	type key struct {
+		group, verbs, resource string
+	}
+
+	var order []key
+	rules := make(map[key]*Rule)
+
+	for _, entry := range strings.Split(specification, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		references, actions, valid := strings.Cut(entry, ":")
+		if !(valid) || strings.TrimSpace(references) == "" || strings.TrimSpace(actions) == "" {
+			return nil, fmt.Errorf("invalid permission - expecting \"<resource>[,<resource>...]:<verb>[,<verb>...]\": %s", entry)
+		}
+
+		var list []string
+		for _, verb := range strings.Split(actions, ",") {
+			verb = strings.ToLower(strings.TrimSpace(verb))
+			if !(Verb(verb)) {
+				return nil, fmt.Errorf("invalid permission (%s) - unknown verb: %s", entry, verb)
+			}
+
+			if !(slices.Contains(list, verb)) {
+				list = append(list, verb)
+			}
+		}
+
+		for _, reference := range strings.Split(references, ",") {
+			resource := ParseResource(reference)
+			if resource.Resource == "" {
+				return nil, fmt.Errorf("invalid permission (%s) - empty resource", entry)
+			}
+
+			identifier := key{group: resource.Group, verbs: strings.Join(list, ",")}
+			if resource.Name != "" {
+				identifier.resource = resource.Path()
+			}
+
+			rule, exists := rules[identifier]
+			if !(exists) {
+				rule = &Rule{APIGroups: []string{resource.Group}, Verbs: list}
+				rules[identifier] = rule
+				order = append(order, identifier)
+			}
+
+			if !(slices.Contains(rule.Resources, resource.Path())) {
+				rule.Resources = append(rule.Resources, resource.Path())
+			}
+
+			if resource.Name != "" && !(slices.Contains(rule.ResourceNames, resource.Name)) {
+				rule.ResourceNames = append(rule.ResourceNames, resource.Name)
+			}
+		}
+	}
+
+	if len(order) == 0 {
+		return nil, errors.New("the permission specification is empty")
+	}
+
+	result := make([]Rule, 0, len(order))
+	for _, identifier := range order {
+		result = append(result, *rules[identifier])
+	}
+
+	return result, nil
+}
+
+// Permissions represents a ServiceAccount's Role (or ClusterRole) and binding.
+type Permissions struct {
+	Name      string // Name is the role's, and binding's, name.
+	Namespace string // Namespace is the namespace of the Role and RoleBinding, and of the ServiceAccount.
+	Cluster   bool   // Cluster generates a ClusterRole and ClusterRoleBinding rather than a Role and RoleBinding.
+	Account   string // Account is the ServiceAccount's name.
+	Create    bool   // Create includes the ServiceAccount itself.
+	Rules     []Rule
+}
+
+// Generate produces the ServiceAccount (if Create is set), the Role or ClusterRole, and its binding.
+func (p Permissions) Generate() ([]*yaml.Node, error) {
+	if p.Name == "" || p.Account == "" || p.Namespace == "" {
+		return nil, errors.New("a name, service account, and namespace are required")
+	}
+
+	if len(p.Rules) == 0 {
+		return nil, errors.New("at least one rule is required")
+	}
+
+	kind, binding, namespace := "Role", "RoleBinding", p.Namespace
+	if p.Cluster {
+		kind, binding, namespace = "ClusterRole", "ClusterRoleBinding", ""
+	}
+
+	var documents []*yaml.Node
+	if p.Create {
+		documents = append(documents, manifests.Resource("v1", "ServiceAccount", manifests.Metadata(p.Account, p.Namespace, nil, nil)))
+	}
+
+	rules := make([]*yaml.Node, 0, len(p.Rules))
+	for _, rule := range p.Rules {
+		rules = append(rules, rule.Node())
+	}
+
+	documents = append(documents,
+		manifests.Resource("rbac.authorization.k8s.io/v1", kind, manifests.Metadata(p.Name, namespace, nil, nil),
+			"rules", rules,
+		),
+		manifests.Resource("rbac.authorization.k8s.io/v1", binding, manifests.Metadata(p.Name, namespace, nil, nil),
+			"roleRef", manifests.Object("apiGroup", "rbac.authorization.k8s.io", "kind", kind, "name", p.Name),
+			"subjects", manifests.List(manifests.Object("kind", "ServiceAccount", "name", p.Account, "namespace", p.Namespace)),
+		),
+	)
+
+	return documents, nil
+}
+
+func sorted(values map[string]bool) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,132 @@
+package rbac
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		specification string
+		expected      []Rule
+		invalid       bool
+	}{
+		{
+			specification: "pods,services:get,list;pods/log:get",
+			expected: []Rule{
+				{APIGroups: []string{""}, Resources: []string{"pods", "services"}, Verbs: []string{"get", "list"}},
+				{APIGroups: []string{""}, Resources: []string{"pods/log"}, Verbs: []string{"get"}},
+			},
+		},
+		{
+			specification: "deployments:get; deployments/scale:GET, get ;statefulsets:get",
+			expected: []Rule{
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments", "deployments/scale", "statefulsets"}, Verbs: []string{"get"}},
+			},
+		},
+		{
+			specification: "secrets/database:get;secrets/cache:get;configmaps/settings:get",
+			expected: []Rule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"database", "cache"}, Verbs: []string{"get"}},
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"settings"}, Verbs: []string{"get"}},
+			},
+		},
+		{specification: "", invalid: true},
+		{specification: "pods", invalid: true},
+		{specification: "pods:", invalid: true},
+		{specification: "pods:read", invalid: true},
+		{specification: ",:get", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.specification, func(t *testing.T) {
+			rules, e := ParseRules(test.specification)
+			if test.invalid {
+				if e == nil {
+					t.Errorf("ParseRules(%q) = %+v, expected an error", test.specification, rules)
+				}
+
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("ParseRules(%q) returned an unexpected error: %v", test.specification, e)
+			}
+
+			if !(reflect.DeepEqual(rules, test.expected)) {
+				t.Errorf("ParseRules(%q) = %+v, expected %+v", test.specification, rules, test.expected)
+			}
+		})
+	}
+}
+
+func TestPermissionsGenerate(t *testing.T) {
+	rules := []Rule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}}
+
+	tests := []struct {
+		name        string
+		permissions Permissions
+		expected    []string // expected are the generated documents' "<kind>/<namespace>/<name>"
+		invalid     bool
+	}{
+		{
+			name:        "namespaced",
+			permissions: Permissions{Name: "reader", Namespace: "development", Account: "api", Rules: rules},
+			expected:    []string{"Role/development/reader", "RoleBinding/development/reader"},
+		},
+		{
+			name:        "cluster",
+			permissions: Permissions{Name: "reader", Namespace: "development", Account: "api", Create: true, Cluster: true, Rules: rules},
+			expected:    []string{"ServiceAccount/development/api", "ClusterRole//reader", "ClusterRoleBinding//reader"},
+		},
+		{name: "without-account", permissions: Permissions{Name: "reader", Namespace: "development", Rules: rules}, invalid: true},
+		{name: "without-rules", permissions: Permissions{Name: "reader", Namespace: "development", Account: "api"}, invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes, e := test.permissions.Generate()
+			if test.invalid {
+				if e == nil {
+					t.Errorf("Generate() expected an error")
+				}
+
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("Generate() returned an unexpected error: %v", e)
+			}
+
+			var documents []string
+			for _, node := range nodes {
+				root := node.Content[0]
+				documents = append(documents, manifests.Scalar(root, "kind")+"/"+manifests.Scalar(root, "metadata", "namespace")+"/"+manifests.Scalar(root, "metadata", "name"))
+			}
+
+			if strings.Join(documents, ", ") != strings.Join(test.expected, ", ") {
+				t.Errorf("Generate() = %v, expected %v", documents, test.expected)
+			}
+
+			binding := nodes[len(nodes)-1].Content[0]
+			if manifests.Scalar(binding, "roleRef", "name") != "reader" || manifests.Scalar(manifests.Items(manifests.Value(binding, "subjects"))[0], "namespace") != "development" {
+				t.Errorf("Generate() binding doesn't bind the role to the service account")
+			}
+		})
+	}
+}
+
+func TestRuleNode(t *testing.T) {
+	var output strings.Builder
+	if e := manifests.Write(&output, Rule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}.Node()); e != nil {
+		t.Fatalf("unable to write rule: %v", e)
+	}
+
+	// --> the core group is an explicitly quoted empty string
+	if expected := "---\napiGroups:\n    - \"\"\nresources:\n    - pods\nverbs:\n    - get\n"; output.String() != expected {
+		t.Errorf("Node() =\n%s\nexpected:\n%s", output.String(), expected)
+	}
+}
@@ -0,0 +1,3 @@
+// Package resources parses kubernetes quantities, sets container resource requests and limits, and aggregates the
+// resource footprint of workloads.
+package resources
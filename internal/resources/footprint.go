@@ -0,0 +1,248 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Resources represents cpu and memory amounts.
+type Resources struct {
+	CPU    Quantity
+	Memory Quantity
+}
+
+// amount is the serialized form of [Resources], in both display and base units.
+type amount struct {
+	CPU        string `json:"cpu" yaml:"cpu"`
+	Memory     string `json:"memory" yaml:"memory"`
+	Millicores int64  `json:"millicores" yaml:"millicores"`
+	Bytes      int64  `json:"bytes" yaml:"bytes"`
+}
+
+func (r Resources) amount() amount {
+	return amount{CPU: r.CPU.CPU(), Memory: r.Memory.Memory(), Millicores: r.CPU.Millicores(), Bytes: r.Memory.Bytes()}
+}
+
+// MarshalJSON implements [json.Marshaler].
+func (r Resources) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.amount())
+}
+
+// MarshalYAML implements [yaml.Marshaler].
+func (r Resources) MarshalYAML() (interface{}, error) {
+	return r.amount(), nil
+}
+
+// Add returns the sum of r and other.
+func (r Resources) Add(other Resources) Resources {
+	return Resources{CPU: r.CPU.Add(other.CPU), Memory: r.Memory.Add(other.Memory)}
+}
+
+// Multiply returns r scaled by factor.
+func (r Resources) Multiply(factor int) Resources {
+	return Resources{CPU: r.CPU.Multiply(factor), Memory: r.Memory.Multiply(factor)}
+}
+
+// Max returns the per-resource maximum of r and other.
+func (r Resources) Max(other Resources) Resources {
+	return Resources{CPU: r.CPU.Max(other.CPU), Memory: r.Memory.Max(other.Memory)}
+}
+
+// Footprint represents the summed cpu and memory requests and limits of container(s).
+type Footprint struct {
+	Requests        Resources `json:"requests" yaml:"requests"`
+	Limits          Resources `json:"limits" yaml:"limits"`
+	CPUUnbounded    bool      `json:"cpuUnbounded" yaml:"cpuUnbounded"`       // CPUUnbounded is true if any container lacks a cpu limit.
+	MemoryUnbounded bool      `json:"memoryUnbounded" yaml:"memoryUnbounded"` // MemoryUnbounded is true if any container lacks a memory limit.
+}
+
+// Add returns the sum of f and other.
+func (f Footprint) Add(other Footprint) Footprint {
+	return Footprint{Requests: f.Requests.Add(other.Requests), Limits: f.Limits.Add(other.Limits), CPUUnbounded: f.CPUUnbounded || other.CPUUnbounded, MemoryUnbounded: f.MemoryUnbounded || other.MemoryUnbounded}
+}
+
+// Multiply returns f scaled by factor.
+func (f Footprint) Multiply(factor int) Footprint {
+	return Footprint{Requests: f.Requests.Multiply(factor), Limits: f.Limits.Multiply(factor), CPUUnbounded: f.CPUUnbounded, MemoryUnbounded: f.MemoryUnbounded}
+}
+
+// Max returns the per-resource maximum of f and other.
+func (f Footprint) Max(other Footprint) Footprint {
+	return Footprint{Requests: f.Requests.Max(other.Requests), Limits: f.Limits.Max(other.Limits), CPUUnbounded: f.CPUUnbounded || other.CPUUnbounded, MemoryUnbounded: f.MemoryUnbounded || other.MemoryUnbounded}
+}
+
+// Unbounded reports whether either the cpu or memory limit is a lower bound.
+func (f Footprint) Unbounded() bool {
+	return f.CPUUnbounded || f.MemoryUnbounded
+}
+
+// Container returns a container's footprint. As with the kubernetes api-server, a request that isn't declared defaults
+// to its limit.
+func Container(container *yaml.Node) (Footprint, error) {
+	var footprint Footprint
+
+	values := make(map[string]Quantity)
+	for _, field := range []string{"limits", "requests"} {
+		for _, name := range []string{"cpu", "memory"} {
+			value := manifests.Scalar(container, "resources", field, name)
+			if value == "" {
+				continue
+			}
+
+			quantity, e := ParseQuantity(value)
+			if e != nil {
+				return Footprint{}, fmt.Errorf("invalid resources.%s.%s: %w", field, name, e)
+			}
+
+			values[field+"."+name] = quantity
+		}
+	}
+
+	for _, name := range []string{"cpu", "memory"} {
+		limit, bounded := values["limits."+name]
+
+		request, valid := values["requests."+name]
+		if !(valid) {
+			request = limit
+		}
+
+		switch name {
+		case "cpu":
+			footprint.Requests.CPU, footprint.Limits.CPU, footprint.CPUUnbounded = request, limit, !(bounded)
+		case "memory":
+			footprint.Requests.Memory, footprint.Limits.Memory, footprint.MemoryUnbounded = request, limit, !(bounded)
+		}
+	}
+
+	return footprint, nil
+}
+
+// Pod returns a workload's effective, per-pod footprint: the sum of its containers (including sidecar init-containers,
+// whose "restartPolicy" is "Always"), or its largest init-container if greater. Ephemeral containers aren't counted.
+func Pod(document *manifests.Document) (Footprint, error) {
+	var sum, initialization Footprint
+	for _, container := range document.Containers() {
+		footprint, e := Container(container.Node)
+		if e != nil {
+			return Footprint{}, fmt.Errorf("%s: %s: %w", document.String(), container.Path, e)
+		}
+
+		switch {
+		case container.Type == "containers", container.Type == "initContainers" && manifests.Scalar(container.Node, "restartPolicy") == "Always":
+			sum = sum.Add(footprint)
+		case container.Type == "initContainers":
+			initialization = initialization.Max(footprint)
+		}
+	}
+
+	return sum.Max(initialization), nil
+}
+
+// Replicas returns the number of pods a workload runs: "spec.replicas" for replicated kinds, "parallelism" for jobs,
+// and nodes for daemon-sets.
+func Replicas(document *manifests.Document, nodes int) int {
+	var parallelism string
+
+	switch document.Kind() {
+	case "DaemonSet":
+		return nodes
+	case "Job":
+		parallelism = manifests.Scalar(document.Root(), "spec", "parallelism")
+	case "CronJob":
+		parallelism = manifests.Scalar(document.Root(), "spec", "jobTemplate", "spec", "parallelism")
+	default:
+		return document.Replicas()
+	}
+
+	if value, e := strconv.Atoi(parallelism); e == nil {
+		return value
+	}
+
+	return 1
+}
+
+// Workload represents a single workload's footprint.
+type Workload struct {
+	File      string    `json:"file" yaml:"file"`
+	Namespace string    `json:"namespace" yaml:"namespace"`
+	Resource  string    `json:"resource" yaml:"resource"`
+	Replicas  int       `json:"replicas" yaml:"replicas"`
+	Pod       Footprint `json:"pod" yaml:"pod"`
+	Total     Footprint `json:"total" yaml:"total"`
+}
+
+// Namespace represents the summed footprint of a namespace's workload(s).
+type Namespace struct {
+	Name      string    `json:"name" yaml:"name"`
+	Workloads int       `json:"workloads" yaml:"workloads"`
+	Total     Footprint `json:"total" yaml:"total"`
+}
+
+// Report represents the footprint of a set of manifests, per workload, per namespace, and overall.
+type Report struct {
+	Workloads  []Workload  `json:"workloads" yaml:"workloads"`
+	Namespaces []Namespace `json:"namespaces" yaml:"namespaces"`
+	Total      Footprint   `json:"total" yaml:"total"`
+}
+
+// Aggregate computes the footprint of every workload document. Documents without a namespace are attributed to
+// fallback, and daemon-sets are assumed to run on the given number of nodes.
+func Aggregate(documents []*manifests.Document, fallback string, nodes int) (*Report, error) {
+	report := &Report{Workloads: []Workload{}, Namespaces: []Namespace{}}
+
+	namespaces := make(map[string]*Namespace)
+	for _, document := range documents {
+		if !(document.Workload()) {
+			continue
+		}
+
+		pod, e := Pod(document)
+		if e != nil {
+			return nil, e
+		}
+
+		namespace := document.Namespace()
+		if namespace == "" {
+			namespace = fallback
+		}
+
+		replicas := Replicas(document, nodes)
+
+		workload := Workload{File: document.File, Namespace: namespace, Resource: document.String(), Replicas: replicas, Pod: pod, Total: pod.Multiply(replicas)}
+
+		report.Workloads = append(report.Workloads, workload)
+
+		if _, valid := namespaces[namespace]; !(valid) {
+			namespaces[namespace] = &Namespace{Name: namespace}
+		}
+
+		namespaces[namespace].Workloads++
+		namespaces[namespace].Total = namespaces[namespace].Total.Add(workload.Total)
+
+		report.Total = report.Total.Add(workload.Total)
+	}
+
+	sort.SliceStable(report.Workloads, func(i, j int) bool {
+		if report.Workloads[i].Namespace != report.Workloads[j].Namespace {
+			return report.Workloads[i].Namespace < report.Workloads[j].Namespace
+		}
+
+		return report.Workloads[i].Resource < report.Workloads[j].Resource
+	})
+
+	for _, namespace := range namespaces {
+		report.Namespaces = append(report.Namespaces, *namespace)
+	}
+
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		return report.Namespaces[i].Name < report.Namespaces[j].Name
+	})
+
+	return report, nil
+}
@@ -0,0 +1,148 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+func TestContainer(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources string
+		expected  [4]string // expected are the cpu and memory requests, followed by the cpu and memory limits
+		cpu       bool      // cpu is the expected CPUUnbounded
+		memory    bool      // memory is the expected MemoryUnbounded
+	}{
+		{name: "bounded", resources: "{ requests: { cpu: 100m, memory: 128Mi }, limits: { cpu: 500m, memory: 256Mi } }", expected: [4]string{"100m", "128Mi", "500m", "256Mi"}},
+		{name: "request-defaults-to-limit", resources: "{ limits: { cpu: 1, memory: 1Gi } }", expected: [4]string{"1", "1Gi", "1", "1Gi"}},
+		{name: "cpu-unbounded", resources: "{ requests: { cpu: 100m, memory: 128Mi }, limits: { memory: 256Mi } }", expected: [4]string{"100m", "128Mi", "0", "256Mi"}, cpu: true},
+		{name: "memory-unbounded", resources: "{ requests: { cpu: 100m }, limits: { cpu: 500m } }", expected: [4]string{"100m", "0", "500m", "0"}, memory: true},
+		{name: "undeclared", resources: "{}", expected: [4]string{"0", "0", "0", "0"}, cpu: true, memory: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documents, e := manifests.Decode("test.yaml", strings.NewReader("name: example\nresources: "+test.resources+"\n"))
+			if e != nil {
+				t.Fatalf("unable to decode container: %v", e)
+			}
+
+			footprint, e := Container(documents[0].Root())
+			if e != nil {
+				t.Fatalf("Container() returned an unexpected error: %v", e)
+			}
+
+			values := [4]string{footprint.Requests.CPU.CPU(), footprint.Requests.Memory.Memory(), footprint.Limits.CPU.CPU(), footprint.Limits.Memory.Memory()}
+			if values != test.expected {
+				t.Errorf("Container() = %v, expected %v", values, test.expected)
+			}
+
+			if footprint.CPUUnbounded != test.cpu || footprint.MemoryUnbounded != test.memory {
+				t.Errorf("Container() unbounded = (cpu: %t, memory: %t), expected (cpu: %t, memory: %t)", footprint.CPUUnbounded, footprint.MemoryUnbounded, test.cpu, test.memory)
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	documents, e := manifests.Decode("test.yaml", strings.NewReader(`apiVersion: apps/v1
+kind: Deployment
+metadata: { name: api, namespace: backend }
+spec:
+    replicas: 3
+    template:
+        spec:
+            initContainers:
+                - { name: migrate, resources: { limits: { cpu: "2", memory: 1Gi } } }
+                - { name: proxy, restartPolicy: Always, resources: { limits: { cpu: 100m, memory: 64Mi } } }
+            containers:
+                - { name: api, resources: { limits: { cpu: 500m, memory: 512Mi } } }
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata: { name: agent }
+spec:
+    template:
+        spec:
+            containers:
+                - { name: agent, resources: { requests: { cpu: 50m }, limits: { memory: 64Mi } } }
+---
+apiVersion: v1
+kind: Service
+metadata: { name: api, namespace: backend }
+`))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	report, e := Aggregate(documents, "default", 4)
+	if e != nil {
+		t.Fatalf("Aggregate() returned an unexpected error: %v", e)
+	}
+
+	if len(report.Workloads) != 2 || len(report.Namespaces) != 2 {
+		t.Fatalf("Aggregate() = (%d workloads, %d namespaces), expected (2, 2)", len(report.Workloads), len(report.Namespaces))
+	}
+
+	// --> the init-container exceeds the sum of the containers, and the sidecar counts towards the latter
+	api := report.Workloads[0]
+	if api.Resource != "deployment/api" || api.Pod.Limits.CPU.CPU() != "2" || api.Total.Limits.CPU.CPU() != "6" || api.Total.Limits.Memory.Memory() != "3Gi" {
+		t.Errorf("Aggregate() api = %s (pod cpu: %s, total cpu: %s, total memory: %s), expected deployment/api (2, 6, 3Gi)", api.Resource, api.Pod.Limits.CPU.CPU(), api.Total.Limits.CPU.CPU(), api.Total.Limits.Memory.Memory())
+	}
+
+	agent := report.Workloads[1]
+	if agent.Namespace != "default" || agent.Replicas != 4 || agent.Total.Requests.CPU.CPU() != "200m" {
+		t.Errorf("Aggregate() agent = (%s, %d replicas, %s), expected (default, 4, 200m)", agent.Namespace, agent.Replicas, agent.Total.Requests.CPU.CPU())
+	}
+
+	if !(report.Total.CPUUnbounded) || report.Total.MemoryUnbounded || !(report.Total.Unbounded()) {
+		t.Errorf("Aggregate() total unbounded = (cpu: %t, memory: %t), expected only cpu", report.Total.CPUUnbounded, report.Total.MemoryUnbounded)
+	}
+
+	if namespace := report.Namespaces[0]; namespace.Name != "backend" || namespace.Total.Unbounded() {
+		t.Errorf("Aggregate() namespace %s expected to be bounded", namespace.Name)
+	}
+}
+
+func TestSet(t *testing.T) {
+	parse := func(field, pair string) Assignment {
+		assignment, e := ParseAssignment(field, pair)
+		if e != nil {
+			t.Fatalf("ParseAssignment() returned an unexpected error: %v", e)
+		}
+
+		return assignment
+	}
+
+	documents, e := manifests.Decode("test.yaml", strings.NewReader("name: example\nresources: { requests: { cpu: 0.5 } }\n"))
+	if e != nil {
+		t.Fatalf("unable to decode container: %v", e)
+	}
+
+	container := documents[0].Root()
+
+	changes, e := Set(container, parse("requests", "cpu=500m"), parse("requests", "memory=128Mi"), parse("limits", "memory=256Mi"))
+	if e != nil {
+		t.Fatalf("Set() returned an unexpected error: %v", e)
+	}
+
+	if expected := "requests.memory=128Mi,limits.memory=256Mi"; strings.Join(changes, ",") != expected {
+		t.Errorf("Set() = %v, expected %s", changes, expected)
+	}
+
+	if cpu := manifests.Scalar(container, "resources", "requests", "cpu"); cpu != "0.5" {
+		t.Errorf("Set() replaced an equivalent value with %s", cpu)
+	}
+
+	if _, e := Set(container, parse("limits", "cpu=100m")); e == nil {
+		t.Errorf("Set() of a limit below its request expected an error")
+	}
+
+	for _, pair := range []string{"cpu", "cpu=", "cpu=fast"} {
+		if _, e := ParseAssignment("limits", pair); e == nil {
+			t.Errorf("ParseAssignment(%q) expected an error", pair)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package resources
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pattern matches a kubernetes quantity - a signed decimal followed by an optional binary-si, decimal-si, or decimal
+// exponent suffix.
+var pattern = regexp.MustCompile(`^([+-]?(?:[0-9]+\.?[0-9]*|\.[0-9]+))([KMGTPE]i|[numkMGTPE]|[eE][+-]?[0-9]+)?$`)
+
+// suffixes maps binary-si and decimal-si suffixes to their multiplier.
+var suffixes = map[string]*big.Rat{
+	"n":  big.NewRat(1, 1_000_000_000),
+	"u":  big.NewRat(1, 1_000_000),
+	"m":  big.NewRat(1, 1_000),
+	"":   big.NewRat(1, 1),
+	"k":  big.NewRat(1_000, 1),
+	"M":  big.NewRat(1_000_000, 1),
+	"G":  big.NewRat(1_000_000_000, 1),
+	"T":  big.NewRat(1_000_000_000_000, 1),
+	"P":  big.NewRat(1_000_000_000_000_000, 1),
+	"E":  big.NewRat(1_000_000_000_000_000_000, 1),
+	"Ki": new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 10)),
+	"Mi": new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 20)),
+	"Gi": new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 30)),
+	"Ti": new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 40)),
+	"Pi": new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 50)),
+	"Ei": new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 60)),
+}
+
+// units are the binary-si suffixes used when formatting memory, in descending order.
+var units = []string{"Ei", "Pi", "Ti", "Gi", "Mi", "Ki"}
+
+// Quantity is an exact kubernetes resource quantity. The zero value represents zero.
+type Quantity struct {
+	value *big.Rat
+}
+
+// ParseQuantity parses a kubernetes quantity string (e.g. "250m", "1.5Gi", "1e3").
+func ParseQuantity(value string) (Quantity, error) {
+	matches := pattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return Quantity{}, fmt.Errorf("invalid quantity: %q", value)
+	}
+
+	number, valid := new(big.Rat).SetString(matches[1])
+	if !(valid) {
+		return Quantity{}, fmt.Errorf("invalid quantity: %q", value)
+	}
+
+	suffix := matches[2]
+	if multiplier, valid := suffixes[suffix]; valid {
+		return Quantity{value: number.Mul(number, multiplier)}, nil
+	}
+
+	exponent, e := strconv.Atoi(suffix[1:])
+	if e != nil || exponent > 18 || exponent < -9 {
+		return Quantity{}, fmt.Errorf("invalid quantity exponent: %q", value)
+	}
+
+	multiplier := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exponent))), nil))
+	if exponent < 0 {
+		multiplier.Inv(multiplier)
+	}
+
+	return Quantity{value: number.Mul(number, multiplier)}, nil
+}
+
+// rat returns the quantity's value, treating the zero value as zero.
+func (q Quantity) rat() *big.Rat {
+	if q.value == nil {
+		return new(big.Rat)
+	}
+
+	return q.value
+}
+
+// Add returns the sum of q and other.
+func (q Quantity) Add(other Quantity) Quantity {
+	return Quantity{value: new(big.Rat).Add(q.rat(), other.rat())}
+}
+
+// Multiply returns q scaled by factor.
+func (q Quantity) Multiply(factor int) Quantity {
+	return Quantity{value: new(big.Rat).Mul(q.rat(), big.NewRat(int64(factor), 1))}
+}
+
+// Cmp compares q and other, returning -1, 0, or +1 as q is less than, equal to, or greater than other.
+func (q Quantity) Cmp(other Quantity) int {
+	return q.rat().Cmp(other.rat())
+}
+
+// Max returns the larger of q and other.
+func (q Quantity) Max(other Quantity) Quantity {
+	if q.Cmp(other) >= 0 {
+		return q
+	}
+
+	return other
+}
+
+// Zero reports whether the quantity is zero.
+func (q Quantity) Zero() bool {
+	return q.rat().Sign() == 0
+}
+
+// Equal reports whether q and other represent the same amount (e.g. "1Gi" and "1024Mi").
+func (q Quantity) Equal(other Quantity) bool {
+	return q.Cmp(other) == 0
+}
+
+// Millicores returns the quantity in thousandths, rounded up - the unit kubernetes schedules cpu in.
+func (q Quantity) Millicores() int64 {
+	return ceiling(new(big.Rat).Mul(q.rat(), big.NewRat(1_000, 1)))
+}
+
+// Bytes returns the quantity rounded up to a whole number.
+func (q Quantity) Bytes() int64 {
+	return ceiling(q.rat())
+}
+
+// CPU formats the quantity as cores (e.g. "2"), or millicores when fractional (e.g. "1500m").
+func (q Quantity) CPU() string {
+	millicores := q.Millicores()
+	if millicores%1_000 == 0 {
+		return strconv.FormatInt(millicores/1_000, 10)
+	}
+
+	return fmt.Sprintf("%dm", millicores)
+}
+
+// Memory formats the quantity with the largest binary-si suffix it reaches (e.g. "1.5Gi"), to at most two decimals.
+func (q Quantity) Memory() string {
+	for _, unit := range units {
+		multiplier := suffixes[unit]
+		if q.rat().Cmp(multiplier) >= 0 {
+			value := new(big.Rat).Quo(q.rat(), multiplier).FloatString(2)
+			value = strings.TrimSuffix(strings.TrimRight(value, "0"), ".")
+
+			return value + unit
+		}
+	}
+
+	return strconv.FormatInt(q.Bytes(), 10)
+}
+
+// ceiling rounds a rational number up to the nearest integer.
+func ceiling(value *big.Rat) int64 {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	return quotient.Int64()
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
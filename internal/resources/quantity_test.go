@@ -0,0 +1,71 @@
+package resources
+
+import "testing"
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		value      string
+		millicores int64
+		cpu        string
+		memory     string
+		valid      bool
+	}{
+		{value: "500m", millicores: 500, cpu: "500m", valid: true},
+		{value: "1.5", millicores: 1_500, cpu: "1500m", valid: true},
+		{value: "2", millicores: 2_000, cpu: "2", valid: true},
+		{value: "128Mi", memory: "128Mi", valid: true},
+		{value: "1536Mi", memory: "1.5Gi", valid: true},
+		{value: "1G", memory: "953.67Mi", valid: true},
+		{value: "1e3", memory: "1000", valid: true},
+		{value: "", valid: false},
+		{value: "1.5.0", valid: false},
+		{value: "128MB", valid: false},
+	}
+
+	for _, test := range tests {
+		quantity, e := ParseQuantity(test.value)
+		if (e == nil) != test.valid {
+			t.Errorf("ParseQuantity(%q) = %v, expected valid: %t", test.value, e, test.valid)
+			continue
+		}
+
+		if !(test.valid) {
+			continue
+		}
+
+		if test.cpu != "" && (quantity.Millicores() != test.millicores || quantity.CPU() != test.cpu) {
+			t.Errorf("ParseQuantity(%q) = (%d, %s), expected (%d, %s)", test.value, quantity.Millicores(), quantity.CPU(), test.millicores, test.cpu)
+		}
+
+		if test.memory != "" && quantity.Memory() != test.memory {
+			t.Errorf("ParseQuantity(%q).Memory() = %s, expected %s", test.value, quantity.Memory(), test.memory)
+		}
+	}
+}
+
+func TestQuantityArithmetic(t *testing.T) {
+	parse := func(value string) Quantity {
+		quantity, e := ParseQuantity(value)
+		if e != nil {
+			t.Fatalf("unable to parse quantity %q: %v", value, e)
+		}
+
+		return quantity
+	}
+
+	if !(parse("1Gi").Equal(parse("1024Mi"))) || !(parse("0.5").Equal(parse("500m"))) {
+		t.Errorf("Equal() expected equivalent quantities in different units to be equal")
+	}
+
+	if sum := parse("250m").Add(parse("1")).Multiply(2); sum.CPU() != "2500m" {
+		t.Errorf("(250m + 1) * 2 = %s, expected 2500m", sum.CPU())
+	}
+
+	if maximum := parse("512Mi").Max(parse("1Gi")); maximum.Memory() != "1Gi" {
+		t.Errorf("Max(512Mi, 1Gi) = %s, expected 1Gi", maximum.Memory())
+	}
+
+	if !(Quantity{}.Zero()) || parse("1m").Zero() {
+		t.Errorf("Zero() expected only the zero value to be zero")
+	}
+}
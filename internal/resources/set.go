@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Assignment represents a single resource value to set (e.g. "requests.cpu" to "250m").
+type Assignment struct {
+	Field    string   // Field is either "requests" or "limits".
+	Name     string   // Name is the resource's name (e.g. "cpu", "memory", "ephemeral-storage").
+	Value    string   // Value is the quantity as provided.
+	Quantity Quantity // Quantity is the parsed Value.
+}
+
+// ParseAssignment parses a "<resource>=<quantity>" pair (e.g. "cpu=250m") of the given field.
+func ParseAssignment(field, pair string) (Assignment, error) {
+	name, value, valid := strings.Cut(pair, "=")
+	if !(valid) || name == "" || value == "" {
+		return Assignment{}, fmt.Errorf("invalid %s - expecting \"<resource>=<quantity>\": %s", field, pair)
+	}
+
+	quantity, e := ParseQuantity(value)
+	if e != nil {
+		return Assignment{}, fmt.Errorf("invalid %s (%s): %w", field, name, e)
+	}
+
+	return Assignment{Field: field, Name: name, Value: value, Quantity: quantity}, nil
+}
+
+// String formats the assignment as "<field>.<resource>=<value>".
+func (a Assignment) String() string {
+	return fmt.Sprintf("%s.%s=%s", a.Field, a.Name, a.Value)
+}
+
+// Set applies assignment(s) to a container's "resources", returning the assignment(s) that changed a value. Existing
+// values that are equivalent (e.g. "0.5" and "500m") are left untouched. An error is returned if the result would
+// request more of a resource than its limit.
+func Set(container *yaml.Node, assignments ...Assignment) ([]string, error) {
+	var changes []string
+	for _, assignment := range assignments {
+		if current := manifests.Scalar(container, "resources", assignment.Field, assignment.Name); current != "" {
+			if quantity, e := ParseQuantity(current); e == nil && quantity.Equal(assignment.Quantity) {
+				continue
+			}
+		}
+
+		target := manifests.Ensure(container, "resources", assignment.Field)
+		if _, value := manifests.Pair(target, assignment.Name); value != nil {
+			value.Value, value.Tag, value.Style = assignment.Value, "!!str", manifests.String(assignment.Value).Style
+		} else {
+			manifests.Set(target, assignment.Name, manifests.String(assignment.Value))
+		}
+
+		changes = append(changes, assignment.String())
+	}
+
+	resources := manifests.Value(container, "resources")
+	for _, name := range manifests.Keys(manifests.Value(resources, "requests")) {
+		request, limit := manifests.Scalar(resources, "requests", name), manifests.Scalar(resources, "limits", name)
+		if limit == "" {
+			continue
+		}
+
+		requested, e := ParseQuantity(request)
+		if e != nil {
+			return nil, fmt.Errorf("invalid resources.requests.%s: %w", name, e)
+		}
+
+		limited, e := ParseQuantity(limit)
+		if e != nil {
+			return nil, fmt.Errorf("invalid resources.limits.%s: %w", name, e)
+		}
+
+		if requested.Cmp(limited) > 0 {
+			return nil, fmt.Errorf("resources.requests.%s (%s) exceeds resources.limits.%s (%s)", name, request, name, limit)
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+func TestSetSave(t *testing.T) {
+	// --> a container that already requests cpu; memory is added to "requests", and a "limits" block is created
+	deployment := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n    name: example\nspec:\n    template:\n        spec:\n            containers:\n                - name: example\n                  resources:\n"
+
+	tests := []struct {
+		name     string
+		requests string
+		expected string
+	}{
+		{
+			name:     "block",
+			requests: "                      requests:\n                          cpu: 100m\n",
+			expected: "                      requests:\n                          cpu: 100m\n                          memory: 128Mi\n                      limits:\n                          cpu: \"1\"\n                          memory: 1Gi\n",
+		},
+		{
+			name:     "flow",
+			requests: "                      requests: { cpu: 100m }\n",
+			expected: "                      requests: {cpu: 100m, memory: 128Mi}\n                      limits:\n                          cpu: \"1\"\n                          memory: 1Gi\n",
+		},
+	}
+
+	var assignments []Assignment
+	for _, pair := range [][2]string{{"requests", "memory=128Mi"}, {"limits", "cpu=1"}, {"limits", "memory=1Gi"}} {
+		assignment, e := ParseAssignment(pair[0], pair[1])
+		if e != nil {
+			t.Fatalf("ParseAssignment() returned an unexpected error: %v", e)
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "deployment.yaml")
+			if e := os.WriteFile(path, []byte(deployment+test.requests), 0o600); e != nil {
+				t.Fatalf("unable to write manifest: %v", e)
+			}
+
+			_, e := manifests.Edit(io.Discard, false, &manifests.Selector{}, func(container *yaml.Node) ([]string, error) {
+				return Set(container, assignments...)
+			}, path)
+			if e != nil {
+				t.Fatalf("Edit() returned an unexpected error: %v", e)
+			}
+
+			if saved, _ := os.ReadFile(path); string(saved) != deployment+test.expected {
+				t.Errorf("Set() saved:\n%s\nexpected:\n%s", saved, deployment+test.expected)
+			}
+
+			// --> yaml.v3 rejects duplicate mapping keys
+			if _, e := manifests.File(path); e != nil {
+				t.Errorf("Set() saved an invalid manifest: %v", e)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// objectmeta is the name of the ObjectMeta schema used to validate custom resources' metadata.
+const objectmeta = "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"
+
+// Definitions registers the "openAPIV3Schema" of every served version of each CustomResourceDefinition within
+// documents, returning the resource type(s) that were registered. Non-CRD documents are ignored.
+func (s *Specification) Definitions(documents ...*manifests.Document) ([]GroupVersionKind, error) {
+	var registered []GroupVersionKind
+	for _, document := range documents {
+		if document.Kind() != "CustomResourceDefinition" || document.Group() != "apiextensions.k8s.io" {
+			continue
+		}
+
+		root := document.Root()
+
+		group, kind := manifests.Scalar(root, "spec", "group"), manifests.Scalar(root, "spec", "names", "kind")
+		if group == "" || kind == "" {
+			return nil, fmt.Errorf("%s (%s) is missing \"spec.group\" or \"spec.names.kind\"", document, document.Location())
+		}
+
+		// --> apiextensions.k8s.io/v1beta1 definitions may declare a single, top-level validation schema
+		shared := manifests.Lookup(root, "spec", "validation", "openAPIV3Schema")
+
+		versions := manifests.Items(manifests.Lookup(root, "spec", "versions"))
+		if len(versions) == 0 && manifests.Scalar(root, "spec", "version") != "" {
+			versions = append(versions, manifests.Mapping())
+			manifests.Set(versions[0], "name", manifests.String(manifests.Scalar(root, "spec", "version")))
+		}
+
+		for _, version := range versions {
+			if manifests.Scalar(version, "served") == "false" {
+				continue
+			}
+
+			node := manifests.Lookup(version, "schema", "openAPIV3Schema")
+			if node == nil {
+				node = shared
+			}
+
+			gvk := GroupVersionKind{Group: group, Version: manifests.Scalar(version, "name"), Kind: kind}
+
+			var schema *Schema
+			if node != nil {
+				var raw interface{}
+				if e := node.Decode(&raw); e != nil {
+					return nil, fmt.Errorf("unable to decode %s schema (%s): %w", gvk, document.Location(), e)
+				}
+
+				content, e := json.Marshal(raw)
+				if e != nil {
+					return nil, fmt.Errorf("unable to convert %s schema (%s): %w", gvk, document.Location(), e)
+				}
+
+				if e := json.Unmarshal(content, &schema); e != nil {
+					return nil, fmt.Errorf("invalid %s schema (%s): %w", gvk, document.Location(), e)
+				}
+			}
+
+			s.Register(gvk, s.resource(schema))
+			s.custom[group] = true
+
+			registered = append(registered, gvk)
+		}
+	}
+
+	return registered, nil
+}
+
+// resource wraps a custom resource's schema so that its "apiVersion", "kind", and "metadata" are always permitted -
+// and metadata is validated as ObjectMeta when the specification includes it. A nil schema permits any content.
+func (s *Specification) resource(schema *Schema) *Schema {
+	if schema == nil {
+		return &Schema{Type: "object", PreserveUnknownFields: true}
+	}
+
+	wrapped := *schema
+	wrapped.Properties = make(map[string]*Schema, len(schema.Properties)+3)
+	for name, property := range schema.Properties {
+		wrapped.Properties[name] = property
+	}
+
+	wrapped.Properties["apiVersion"] = &Schema{Type: "string"}
+	wrapped.Properties["kind"] = &Schema{Type: "string"}
+
+	if _, valid := s.Schemas[objectmeta]; valid {
+		wrapped.Properties["metadata"] = &Schema{Ref: "#/components/schemas/" + objectmeta}
+	} else {
+		wrapped.Properties["metadata"] = &Schema{Type: "object", PreserveUnknownFields: true}
+	}
+
+	return &wrapped
+}
@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// definition is a CustomResourceDefinition with a served, and an unserved, version.
+const definition = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+    name: widgets.example.io
+spec:
+    group: example.io
+    names: { kind: Widget, plural: widgets }
+    scope: Namespaced
+    versions:
+        - name: v1
+          served: true
+          storage: true
+          schema:
+              openAPIV3Schema:
+                  type: object
+                  properties:
+                      spec:
+                          type: object
+                          required: [ size ]
+                          properties:
+                              size: { type: integer, minimum: 1 }
+        - name: v1alpha1
+          served: false
+          storage: false
+`
+
+func TestDefinitions(t *testing.T) {
+	specification, e := Embedded(Latest())
+	if e != nil {
+		t.Fatalf("unable to load embedded specification: %v", e)
+	}
+
+	documents, e := manifests.Decode("crd.yaml", strings.NewReader(definition))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	registered, e := specification.Definitions(documents...)
+	if e != nil {
+		t.Fatalf("Definitions() returned an unexpected error: %v", e)
+	}
+
+	if expected := []GroupVersionKind{{Group: "example.io", Version: "v1", Kind: "Widget"}}; !(slices.Equal(registered, expected)) {
+		t.Errorf("Definitions() = %v, expected %v", registered, expected)
+	}
+
+	if !(specification.Custom("example.io/v1")) || specification.Custom("apps/v1") {
+		t.Errorf("Custom() expected to only match the definition's group")
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected []string
+	}{
+		{
+			name:     "valid",
+			manifest: "apiVersion: example.io/v1\nkind: Widget\nmetadata: { name: example, labels: { app: example } }\nspec: { size: 1 }\n",
+		},
+		{
+			name:     "custom-schema",
+			manifest: "apiVersion: example.io/v1\nkind: Widget\nmetadata: { name: example }\nspec: { sizes: 1 }\n",
+			expected: []string{"spec", "spec.sizes"},
+		},
+		{
+			name:     "minimum",
+			manifest: "apiVersion: example.io/v1\nkind: Widget\nmetadata: { name: example }\nspec: { size: 0 }\n",
+			expected: []string{"spec.size"},
+		},
+		{
+			name:     "object-metadata",
+			manifest: "apiVersion: example.io/v1\nkind: Widget\nmetadata: { name: example, label: {} }\nspec: { size: 1 }\n",
+			expected: []string{"metadata.label"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documents, e := manifests.Decode("widget.yaml", strings.NewReader(test.manifest))
+			if e != nil {
+				t.Fatalf("unable to decode manifest: %v", e)
+			}
+
+			var paths []string
+			for _, violation := range specification.Validate(documents[0].Node, specification.Lookup("example.io/v1", "Widget")) {
+				paths = append(paths, violation.Path)
+			}
+
+			if !(slices.Equal(paths, test.expected)) {
+				t.Errorf("Validate() violation paths = %v, expected %v", paths, test.expected)
+			}
+		})
+	}
+
+	if specification.Lookup("example.io/v1alpha1", "Widget") != nil {
+		t.Errorf("Definitions() expected unserved versions to be skipped")
+	}
+}
+
+func TestDefinitionsInvalid(t *testing.T) {
+	documents, e := manifests.Decode("crd.yaml", strings.NewReader(strings.Replace(definition, "group: example.io", "group: \"\"", 1)))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	if _, e := New("").Definitions(documents...); e == nil {
+		t.Errorf("Definitions() of a definition without a group expected an error")
+	}
+}
@@ -0,0 +1,7 @@
+// Package schema provides offline validation of kubernetes manifests against OpenAPI v3 schemas.
+//
+// Reduced specifications for several kubernetes versions are embedded (see specs/); they're generated from the
+// kubernetes project's api/openapi-spec/v3 directory via the generate command:
+//
+//	make schemas
+package schema
@@ -0,0 +1,156 @@
+// Command generate reduces the kubernetes project's OpenAPI v3 specification files (api/openapi-spec/v3) into a
+// single, gzip-compressed OpenAPI v3 document containing only the schema keyword(s) used for validation.
+//
+// Usage:
+//
+//	go run ./internal/schema/generate --source <kubernetes>/api/openapi-spec/v3 --version v1.29.0 --output ./internal/schema/specs/v1.29.json.gz
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// keywords represents the schema keyword(s) retained in the reduced specification.
+var keywords = map[string]bool{
+	"$ref":                                 true,
+	"additionalProperties":                 true,
+	"allOf":                                true,
+	"anyOf":                                true,
+	"enum":                                 true,
+	"format":                               true,
+	"items":                                true,
+	"nullable":                             true,
+	"oneOf":                                true,
+	"properties":                           true,
+	"required":                             true,
+	"type":                                 true,
+	"x-kubernetes-embedded-resource":       true,
+	"x-kubernetes-group-version-kind":      true,
+	"x-kubernetes-int-or-string":           true,
+	"x-kubernetes-preserve-unknown-fields": true,
+}
+
+func main() {
+	var source, version, output string
+
+	flag.StringVar(&source, "source", "", "directory containing the kubernetes openapi v3 specification files")
+	flag.StringVar(&version, "version", "", "the kubernetes version of the source specification (e.g. \"v1.29.0\")")
+	flag.StringVar(&output, "output", "", "the target gzip-compressed json file")
+	flag.Parse()
+
+	if source == "" || version == "" || output == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if e := generate(source, version, output); e != nil {
+		fmt.Fprintf(os.Stderr, "error - %s\n", e)
+		os.Exit(1)
+	}
+}
+
+func generate(source, version, output string) error {
+	files, e := filepath.Glob(filepath.Join(source, "*_openapi.json"))
+	if e != nil {
+		return e
+	}
+
+	schemas := make(map[string]interface{})
+	for _, file := range files {
+		content, e := os.ReadFile(file)
+		if e != nil {
+			return e
+		}
+
+		var document struct {
+			Components struct {
+				Schemas map[string]interface{} `json:"schemas"`
+			} `json:"components"`
+		}
+
+		if e := json.Unmarshal(content, &document); e != nil {
+			return fmt.Errorf("unable to unmarshal %s: %w", file, e)
+		}
+
+		for name, schema := range document.Components.Schemas {
+			schemas[name] = reduce(schema)
+		}
+	}
+
+	if len(schemas) == 0 {
+		return fmt.Errorf("no schemas found in %s", source)
+	}
+
+	specification := map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "Kubernetes",
+			"version": strings.TrimPrefix(version, "v"),
+		},
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+
+	handle, e := os.Create(output)
+	if e != nil {
+		return e
+	}
+
+	defer handle.Close()
+
+	writer, e := gzip.NewWriterLevel(handle, gzip.BestCompression)
+	if e != nil {
+		return e
+	}
+
+	if e := json.NewEncoder(writer).Encode(specification); e != nil {
+		return e
+	}
+
+	return writer.Close()
+}
+
+// reduce recursively removes every non-validation keyword from a schema.
+func reduce(schema interface{}) interface{} {
+	mapping, valid := schema.(map[string]interface{})
+	if !(valid) {
+		return schema
+	}
+
+	reduced := make(map[string]interface{}, len(mapping))
+	for keyword, value := range mapping {
+		if !(keywords[keyword]) {
+			continue
+		}
+
+		switch keyword {
+		case "properties":
+			properties := make(map[string]interface{})
+			for name, property := range value.(map[string]interface{}) {
+				properties[name] = reduce(property)
+			}
+
+			reduced[keyword] = properties
+		case "allOf", "anyOf", "oneOf":
+			var schemas []interface{}
+			for _, partial := range value.([]interface{}) {
+				schemas = append(schemas, reduce(partial))
+			}
+
+			reduced[keyword] = schemas
+		case "items", "additionalProperties":
+			reduced[keyword] = reduce(value)
+		default:
+			reduced[keyword] = value
+		}
+	}
+
+	return reduced
+}
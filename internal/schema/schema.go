@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Schema represents the subset of an OpenAPI v3 schema object used for validation.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Additional        `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	MinLength        *int64   `json:"minLength,omitempty"`
+	MaxLength        *int64   `json:"maxLength,omitempty"`
+	MinItems         *int64   `json:"minItems,omitempty"`
+	MaxItems         *int64   `json:"maxItems,omitempty"`
+
+	IntOrString           bool               `json:"x-kubernetes-int-or-string,omitempty"`
+	PreserveUnknownFields bool               `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+	EmbeddedResource      bool               `json:"x-kubernetes-embedded-resource,omitempty"`
+	GroupVersionKinds     []GroupVersionKind `json:"x-kubernetes-group-version-kind,omitempty"`
+}
+
+// Additional represents an "additionalProperties" value, which is either a boolean or a [Schema].
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *Additional) UnmarshalJSON(content []byte) error {
+	if e := json.Unmarshal(content, &a.Allowed); e == nil {
+		return nil
+	}
+
+	a.Allowed = true
+
+	return json.Unmarshal(content, &a.Schema)
+}
+
+func (a Additional) MarshalJSON() ([]byte, error) {
+	if a.Schema != nil {
+		return json.Marshal(a.Schema)
+	}
+
+	return json.Marshal(a.Allowed)
+}
+
+// GroupVersionKind identifies the resource type a top-level schema describes.
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// APIVersion returns the "apiVersion" representation of the group and version.
+func (g GroupVersionKind) APIVersion() string {
+	if g.Group == "" {
+		return g.Version
+	}
+
+	return g.Group + "/" + g.Version
+}
+
+func (g GroupVersionKind) String() string {
+	return g.APIVersion() + ", Kind=" + g.Kind
+}
+
+// name returns the schema name referenced by a "$ref" (e.g. "#/components/schemas/io.k8s.api.apps.v1.Deployment").
+func name(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
@@ -0,0 +1,222 @@
+package schema
+
+import (
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed specs/*.json.gz
+var specs embed.FS
+
+// ErrUnsupportedVersion is returned when no embedded specification exists for a kubernetes version.
+var ErrUnsupportedVersion = errors.New("unsupported kubernetes version")
+
+// Specification represents a set of named schemas, indexed by the resource type(s) they describe.
+type Specification struct {
+	Version string             // Version is the specification's kubernetes version (e.g. "1.29").
+	Source  string             // Source describes the specification's origin (e.g. "kubernetes 1.29").
+	Schemas map[string]*Schema // Schemas are the specification's "components.schemas".
+
+	kinds  map[GroupVersionKind]*Schema
+	groups map[string]bool
+	custom map[string]bool
+}
+
+// document represents the portion of an OpenAPI v3 document relevant to validation.
+type document struct {
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Versions returns the kubernetes "<major>.<minor>" version(s) of the embedded specifications, in ascending order.
+func Versions() []string {
+	entries, _ := specs.ReadDir("specs")
+
+	var versions []string
+	for _, entry := range entries {
+		versions = append(versions, strings.TrimSuffix(strings.TrimPrefix(entry.Name(), "v"), ".json.gz"))
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return Compare(versions[i], versions[j]) < 0
+	})
+
+	return versions
+}
+
+// Latest returns the most recent embedded kubernetes version.
+func Latest() string {
+	versions := Versions()
+
+	return versions[len(versions)-1]
+}
+
+// Normalize reduces a kubernetes version (e.g. "v1.29.3") to its "<major>.<minor>" form.
+func Normalize(version string) string {
+	partials := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".", 3)
+	if len(partials) < 2 {
+		return strings.Join(partials, ".")
+	}
+
+	return partials[0] + "." + partials[1]
+}
+
+// Embedded loads the embedded specification for a kubernetes version.
+func Embedded(version string) (*Specification, error) {
+	version = Normalize(version)
+
+	content, e := specs.ReadFile(fmt.Sprintf("specs/v%s.json.gz", version))
+	if e != nil {
+		return nil, fmt.Errorf("%w (%s) - expecting one of: %s", ErrUnsupportedVersion, version, strings.Join(Versions(), ", "))
+	}
+
+	specification := New(version)
+	specification.Source = fmt.Sprintf("kubernetes %s", version)
+	if e := specification.decode("embedded", bytes.NewReader(content), true); e != nil {
+		return nil, e
+	}
+
+	return specification, nil
+}
+
+// Directory loads every OpenAPI v3 document (*.json or *.json.gz) within path - for example, the kubernetes
+// project's api/openapi-spec/v3 directory, or the output of "kubectl get --raw /openapi/v3/<group-version>".
+func Directory(path string) (*Specification, error) {
+	entries, e := os.ReadDir(path)
+	if e != nil {
+		e = fmt.Errorf("unable to read schema directory: %w", e)
+		return nil, e
+	}
+
+	specification := New("")
+	specification.Source = path
+	for _, entry := range entries {
+		compressed := strings.HasSuffix(entry.Name(), ".json.gz")
+		if entry.IsDir() || !(compressed || filepath.Ext(entry.Name()) == ".json") {
+			continue
+		}
+
+		content, e := os.ReadFile(filepath.Join(path, entry.Name()))
+		if e != nil {
+			return nil, e
+		}
+
+		if e := specification.decode(entry.Name(), bytes.NewReader(content), compressed); e != nil {
+			return nil, e
+		}
+	}
+
+	if len(specification.Schemas) == 0 {
+		return nil, fmt.Errorf("no openapi v3 schemas found in %s", path)
+	}
+
+	return specification, nil
+}
+
+// New creates an empty specification.
+func New(version string) *Specification {
+	return &Specification{
+		Version: version,
+		Schemas: make(map[string]*Schema),
+		kinds:   make(map[GroupVersionKind]*Schema),
+		groups:  make(map[string]bool),
+		custom:  make(map[string]bool),
+	}
+}
+
+// decode merges an OpenAPI v3 document's schemas into the specification.
+func (s *Specification) decode(source string, reader io.Reader, compressed bool) error {
+	if compressed {
+		decompressor, e := gzip.NewReader(reader)
+		if e != nil {
+			return fmt.Errorf("unable to decompress %s: %w", source, e)
+		}
+
+		defer decompressor.Close()
+
+		reader = decompressor
+	}
+
+	var specification document
+	if e := json.NewDecoder(reader).Decode(&specification); e != nil {
+		return fmt.Errorf("unable to decode openapi specification (%s): %w", source, e)
+	}
+
+	if s.Version == "" && specification.Info.Version != "" && specification.Info.Version != "unversioned" {
+		s.Version = Normalize(specification.Info.Version)
+	}
+
+	for name, schema := range specification.Components.Schemas {
+		s.Schemas[name] = schema
+
+		for _, kind := range schema.GroupVersionKinds {
+			s.Register(kind, schema)
+		}
+	}
+
+	return nil
+}
+
+// Register associates a top-level schema with a resource type - e.g. a custom resource's version.
+func (s *Specification) Register(kind GroupVersionKind, schema *Schema) {
+	s.kinds[kind] = schema
+	s.groups[kind.Group] = true
+}
+
+// Lookup returns the schema for an "apiVersion" and "kind", or nil if the specification doesn't describe it.
+func (s *Specification) Lookup(apiVersion, kind string) *Schema {
+	group, version := "", apiVersion
+	if index := strings.LastIndex(apiVersion, "/"); index >= 0 {
+		group, version = apiVersion[:index], apiVersion[index+1:]
+	}
+
+	return s.kinds[GroupVersionKind{Group: group, Version: version, Kind: kind}]
+}
+
+// Known reports whether the specification describes any resource type(s) of an "apiVersion"'s group.
+func (s *Specification) Known(apiVersion string) bool {
+	return s.groups[group(apiVersion)]
+}
+
+// Custom reports whether an "apiVersion"'s group was registered from a CustomResourceDefinition.
+func (s *Specification) Custom(apiVersion string) bool {
+	return s.custom[group(apiVersion)]
+}
+
+// group returns the API group of an "apiVersion".
+func group(apiVersion string) string {
+	if index := strings.LastIndex(apiVersion, "/"); index >= 0 {
+		return apiVersion[:index]
+	}
+
+	return ""
+}
+
+// Compare orders "<major>.<minor>" kubernetes versions numerically, returning a negative number, zero, or a positive
+// number.
+func Compare(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for index := 0; index < len(pa) && index < len(pb); index++ {
+		na, _ := strconv.Atoi(pa[index])
+		nb, _ := strconv.Atoi(pb[index])
+		if na != nb {
+			return na - nb
+		}
+	}
+
+	return len(pa) - len(pb)
+}
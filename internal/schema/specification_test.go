@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"1.29":     "1.29",
+		"v1.29.3":  "1.29",
+		" 1.30.0 ": "1.30",
+		"1":        "1",
+	}
+
+	for version, expected := range tests {
+		if normalized := Normalize(version); normalized != expected {
+			t.Errorf("Normalize(%q) = %s, expected %s", version, normalized, expected)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int // expected is the sign of the comparison
+	}{
+		{a: "1.9", b: "1.10", expected: -1},
+		{a: "1.29", b: "1.29", expected: 0},
+		{a: "1.30", b: "1.29", expected: 1},
+		{a: "1.29", b: "1.29.1", expected: -1},
+	}
+
+	for _, test := range tests {
+		result := Compare(test.a, test.b)
+		if (result < 0 && test.expected >= 0) || (result > 0 && test.expected <= 0) || (result == 0 && test.expected != 0) {
+			t.Errorf("Compare(%s, %s) = %d, expected a result with the sign of %d", test.a, test.b, result, test.expected)
+		}
+	}
+}
+
+func TestVersions(t *testing.T) {
+	versions := Versions()
+	if len(versions) == 0 {
+		t.Fatalf("Versions() returned no embedded specifications")
+	}
+
+	if !(slices.IsSortedFunc(versions, Compare)) {
+		t.Errorf("Versions() = %v, expected ascending order", versions)
+	}
+
+	if Latest() != versions[len(versions)-1] {
+		t.Errorf("Latest() = %s, expected %s", Latest(), versions[len(versions)-1])
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	specification, e := Embedded("v" + Latest() + ".0")
+	if e != nil {
+		t.Fatalf("Embedded() returned an unexpected error: %v", e)
+	}
+
+	if specification.Version != Latest() {
+		t.Errorf("Embedded().Version = %s, expected %s", specification.Version, Latest())
+	}
+
+	if specification.Lookup("apps/v1", "Deployment") == nil || specification.Lookup("v1", "ConfigMap") == nil {
+		t.Errorf("Embedded() expected to describe apps/v1 Deployment and v1 ConfigMap")
+	}
+
+	if !(specification.Known("apps/v1beta9")) || specification.Known("example.io/v1") {
+		t.Errorf("Known() expected to match by api group only")
+	}
+
+	if _, e := Embedded("1.0"); !(errors.Is(e, ErrUnsupportedVersion)) {
+		t.Errorf("Embedded(1.0) = %v, expected ErrUnsupportedVersion", e)
+	}
+}
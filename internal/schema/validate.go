@@ -0,0 +1,335 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// Violation represents a single schema validation failure.
+type Violation struct {
+	Path    string `json:"path" yaml:"path"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// Validate evaluates a node against schema, resolving any "$ref" against the specification's schemas.
+func (s *Specification) Validate(node *yaml.Node, schema *Schema) []Violation {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	var violations []Violation
+
+	s.validate(node, schema, manifests.Path{}, &violations)
+
+	return violations
+}
+
+// resolve follows a schema's "$ref", if any.
+func (s *Specification) resolve(schema *Schema) *Schema {
+	for depth := 0; schema != nil && schema.Ref != "" && depth < 32; depth++ {
+		schema = s.Schemas[name(schema.Ref)]
+	}
+
+	return schema
+}
+
+func (s *Specification) validate(node *yaml.Node, schema *Schema, path manifests.Path, violations *[]Violation) {
+	schema = s.resolve(schema)
+	if schema == nil || node == nil {
+		return
+	}
+
+	report := func(format string, arguments ...interface{}) {
+		*violations = append(*violations, Violation{Path: path.String(), Message: fmt.Sprintf(format, arguments...)})
+	}
+
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	// --> explicit nulls are dropped by the api-server's decoder
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	for _, partial := range schema.AllOf {
+		s.validate(node, partial, path, violations)
+	}
+
+	if schema.IntOrString || schema.Format == "int-or-string" {
+		if node.Kind != yaml.ScalarNode || !(node.Tag == "!!int" || node.Tag == "!!str") {
+			report("expected integer or string, found %s", describe(node))
+		}
+
+		return
+	}
+
+	if alternatives := append(append([]*Schema{}, schema.OneOf...), schema.AnyOf...); len(alternatives) > 0 {
+		var types []string
+		var matched bool
+		for _, alternative := range alternatives {
+			var partial []Violation
+			s.validate(node, alternative, path, &partial)
+			if len(partial) == 0 {
+				matched = true
+				break
+			}
+
+			if resolved := s.resolve(alternative); resolved != nil && resolved.Type != "" {
+				types = append(types, resolved.Type)
+			}
+		}
+
+		if !(matched) {
+			if len(types) == len(alternatives) {
+				report("expected %s, found %s", strings.Join(types, " or "), describe(node))
+			} else {
+				report("value doesn't match any of the %d allowed schema(s)", len(alternatives))
+			}
+
+			return
+		}
+	}
+
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			report("expected object, found %s", describe(node))
+			return
+		}
+
+		s.object(node, schema, path, violations)
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			report("expected array, found %s", describe(node))
+			return
+		}
+
+		if schema.MinItems != nil && int64(len(node.Content)) < *schema.MinItems {
+			report("expected at least %d item(s), found %d", *schema.MinItems, len(node.Content))
+		}
+
+		if schema.MaxItems != nil && int64(len(node.Content)) > *schema.MaxItems {
+			report("expected at most %d item(s), found %d", *schema.MaxItems, len(node.Content))
+		}
+
+		for index, item := range node.Content {
+			s.validate(item, schema.Items, path.Index(index), violations)
+		}
+	case "string":
+		// --> yaml timestamps (e.g. 2024-01-01) are strings to the api-server
+		if node.Kind != yaml.ScalarNode || !(node.Tag == "!!str" || node.Tag == "!!timestamp" || node.Tag == "!!binary") {
+			report("expected string, found %s", describe(node))
+			return
+		}
+
+		s.text(node, schema, report)
+	case "integer":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			report("expected integer, found %s", describe(node))
+			return
+		}
+
+		s.number(node, schema, report)
+	case "number":
+		if node.Kind != yaml.ScalarNode || !(node.Tag == "!!int" || node.Tag == "!!float") {
+			report("expected number, found %s", describe(node))
+			return
+		}
+
+		s.number(node, schema, report)
+	case "boolean":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			report("expected boolean, found %s", describe(node))
+			return
+		}
+	default:
+		if node.Kind == yaml.MappingNode && (schema.Properties != nil || schema.AdditionalProperties != nil) {
+			s.object(node, schema, path, violations)
+		}
+	}
+
+	if len(schema.Enum) > 0 && node.Kind == yaml.ScalarNode {
+		var allowed []string
+		for _, value := range schema.Enum {
+			allowed = append(allowed, fmt.Sprintf("%v", value))
+		}
+
+		var found bool
+		for _, value := range allowed {
+			found = found || value == node.Value
+		}
+
+		if !(found) {
+			report("unsupported value %q - expecting one of: %s", node.Value, strings.Join(allowed, ", "))
+		}
+	}
+}
+
+// number validates a numeric scalar's "minimum" and "maximum" bound(s).
+func (s *Specification) number(node *yaml.Node, schema *Schema, report func(format string, arguments ...interface{})) {
+	var value float64
+	if e := node.Decode(&value); e != nil {
+		return
+	}
+
+	if bound := schema.Minimum; bound != nil && (value < *bound || (schema.ExclusiveMinimum && value == *bound)) {
+		qualifier := "greater than or equal to"
+		if schema.ExclusiveMinimum {
+			qualifier = "greater than"
+		}
+
+		report("%s must be %s %s", node.Value, qualifier, strconv.FormatFloat(*bound, 'f', -1, 64))
+	}
+
+	if bound := schema.Maximum; bound != nil && (value > *bound || (schema.ExclusiveMaximum && value == *bound)) {
+		qualifier := "less than or equal to"
+		if schema.ExclusiveMaximum {
+			qualifier = "less than"
+		}
+
+		report("%s must be %s %s", node.Value, qualifier, strconv.FormatFloat(*bound, 'f', -1, 64))
+	}
+}
+
+// text validates a string scalar's "minLength", "maxLength", and "pattern" constraint(s).
+func (s *Specification) text(node *yaml.Node, schema *Schema, report func(format string, arguments ...interface{})) {
+	length := int64(utf8.RuneCountInString(node.Value))
+
+	if schema.MinLength != nil && length < *schema.MinLength {
+		report("%q must be at least %d character(s) long", node.Value, *schema.MinLength)
+	}
+
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		report("%q must be at most %d character(s) long", node.Value, *schema.MaxLength)
+	}
+
+	if schema.Pattern != "" {
+		// --> an uncompilable (e.g. non-RE2) pattern is skipped rather than reported against the manifest
+		if expression, e := regexp.Compile(schema.Pattern); e == nil && !(expression.MatchString(node.Value)) {
+			report("%q must match the pattern %q", node.Value, schema.Pattern)
+		}
+	}
+}
+
+// object validates a mapping node's required, known, and additional field(s).
+func (s *Specification) object(node *yaml.Node, schema *Schema, path manifests.Path, violations *[]Violation) {
+	present := make(map[string]bool, len(node.Content)/2)
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		present[node.Content[index].Value] = true
+	}
+
+	for _, field := range schema.Required {
+		if !(present[field]) {
+			*violations = append(*violations, Violation{Path: path.String(), Message: fmt.Sprintf("missing required field %q", field)})
+		}
+	}
+
+	// --> objects without declared properties are free-form (e.g. RawExtension)
+	open := schema.PreserveUnknownFields || schema.EmbeddedResource || (schema.Properties == nil && schema.AdditionalProperties == nil)
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		key, value := node.Content[index].Value, node.Content[index+1]
+
+		if property, valid := schema.Properties[key]; valid {
+			s.validate(value, property, path.Key(key), violations)
+			continue
+		}
+
+		if schema.EmbeddedResource && (key == "apiVersion" || key == "kind" || key == "metadata") {
+			continue
+		}
+
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Allowed {
+			s.validate(value, schema.AdditionalProperties.Schema, path.Key(key), violations)
+			continue
+		}
+
+		if !(open) {
+			message := fmt.Sprintf("unknown field %q", key)
+			if suggestion := suggest(key, schema.Properties); suggestion != "" {
+				message = fmt.Sprintf("%s - did you mean %q?", message, suggestion)
+			}
+
+			*violations = append(*violations, Violation{Path: path.Key(key).String(), Message: message})
+		}
+	}
+}
+
+// describe returns a node's type for use in violation messages.
+func describe(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+
+	switch node.Tag {
+	case "!!int":
+		return fmt.Sprintf("integer (%s)", node.Value)
+	case "!!float":
+		return fmt.Sprintf("number (%s)", node.Value)
+	case "!!bool":
+		return fmt.Sprintf("boolean (%s)", node.Value)
+	default:
+		return fmt.Sprintf("string (%q)", node.Value)
+	}
+}
+
+// suggest returns the closest known property to an unknown field, if any is sufficiently similar.
+func suggest(field string, properties map[string]*Schema) string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	best, distance := "", 3
+	for _, name := range names {
+		if strings.EqualFold(name, field) {
+			return name
+		}
+
+		if d := levenshtein(strings.ToLower(field), strings.ToLower(name)); d < distance {
+			best, distance = name, d
+		}
+	}
+
+	return best
+}
+
+// levenshtein computes the edit distance between two strings.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous = current
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,204 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/x-ethr/ethr-cli/internal/manifests"
+)
+
+// deployment is a valid apps/v1 Deployment.
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+    name: example
+    creationTimestamp: null
+spec:
+    replicas: 1
+    selector:
+        matchLabels: { app: example }
+    template:
+        metadata:
+            labels: { app: example }
+        spec:
+            containers:
+                - name: example
+                  image: example:1.0.0
+                  ports: [ { containerPort: 8080, protocol: TCP } ]
+                  readinessProbe: { httpGet: { path: /health, port: http } }
+`
+
+func TestValidate(t *testing.T) {
+	specification, e := Embedded(Latest())
+	if e != nil {
+		t.Fatalf("unable to load embedded specification: %v", e)
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected []Violation
+	}{
+		{
+			name:     "valid",
+			manifest: deployment,
+		},
+		{
+			name:     "unknown-field",
+			manifest: strings.Replace(deployment, "replicas: 1", "replica: 1", 1),
+			expected: []Violation{{Path: "spec.replica", Message: `unknown field "replica" - did you mean "replicas"?`}},
+		},
+		{
+			name:     "wrong-type",
+			manifest: strings.Replace(deployment, "replicas: 1", `replicas: "1"`, 1),
+			expected: []Violation{{Path: "spec.replicas", Message: `expected integer, found string ("1")`}},
+		},
+		{
+			name:     "missing-required-field",
+			manifest: strings.Replace(deployment, "- name: example\n", "- \n", 1),
+			expected: []Violation{{Path: "spec.template.spec.containers[0]", Message: `missing required field "name"`}},
+		},
+		{
+			name:     "int-or-string",
+			manifest: strings.Replace(deployment, "port: http", "port: [ 8080 ]", 1),
+			expected: []Violation{{Path: "spec.template.spec.containers[0].readinessProbe.httpGet.port", Message: "expected integer or string, found array"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documents, e := manifests.Decode("test.yaml", strings.NewReader(test.manifest))
+			if e != nil {
+				t.Fatalf("unable to decode manifest: %v", e)
+			}
+
+			violations := specification.Validate(documents[0].Node, specification.Lookup("apps/v1", "Deployment"))
+			if len(violations) != len(test.expected) {
+				t.Fatalf("Validate() = %v, expected %v", violations, test.expected)
+			}
+
+			for index := range violations {
+				if violations[index] != test.expected[index] {
+					t.Errorf("Validate()[%d] = %+v, expected %+v", index, violations[index], test.expected[index])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEnum(t *testing.T) {
+	specification := New("")
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{"protocol": {Type: "string", Enum: []interface{}{"TCP", "UDP"}}}}
+
+	documents, e := manifests.Decode("test.yaml", strings.NewReader("protocol: HTTP\n"))
+	if e != nil {
+		t.Fatalf("unable to decode manifest: %v", e)
+	}
+
+	expected := Violation{Path: "protocol", Message: `unsupported value "HTTP" - expecting one of: TCP, UDP`}
+	if violations := specification.Validate(documents[0].Node, schema); len(violations) != 1 || violations[0] != expected {
+		t.Errorf("Validate() = %v, expected [%v]", violations, expected)
+	}
+}
+
+func TestValidateBounds(t *testing.T) {
+	specification := New("")
+
+	minimum, maximum := float64(1), float64(10)
+	shortest, longest, fewest, most := int64(2), int64(4), int64(1), int64(2)
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{
+		"replicas": {Type: "integer", Minimum: &minimum, Maximum: &maximum},
+		"ratio":    {Type: "number", Maximum: &maximum, ExclusiveMaximum: true},
+		"name":     {Type: "string", MinLength: &shortest, MaxLength: &longest, Pattern: "^[a-z]+$"},
+		"hosts":    {Type: "array", MinItems: &fewest, MaxItems: &most, Items: &Schema{Type: "string"}},
+	}}
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected []Violation
+	}{
+		{
+			name:     "valid",
+			manifest: "replicas: 1\nratio: 9.5\nname: abcd\nhosts: [ a, b ]\n",
+		},
+		{
+			name:     "minimum",
+			manifest: "replicas: 0\n",
+			expected: []Violation{{Path: "replicas", Message: "0 must be greater than or equal to 1"}},
+		},
+		{
+			name:     "maximum",
+			manifest: "replicas: 11\n",
+			expected: []Violation{{Path: "replicas", Message: "11 must be less than or equal to 10"}},
+		},
+		{
+			name:     "exclusive-maximum",
+			manifest: "ratio: 10\n",
+			expected: []Violation{{Path: "ratio", Message: "10 must be less than 10"}},
+		},
+		{
+			name:     "min-length",
+			manifest: "name: a\n",
+			expected: []Violation{{Path: "name", Message: `"a" must be at least 2 character(s) long`}},
+		},
+		{
+			name:     "max-length",
+			manifest: "name: abcde\n",
+			expected: []Violation{{Path: "name", Message: `"abcde" must be at most 4 character(s) long`}},
+		},
+		{
+			name:     "pattern",
+			manifest: "name: AB\n",
+			expected: []Violation{{Path: "name", Message: `"AB" must match the pattern "^[a-z]+$"`}},
+		},
+		{
+			name:     "min-items",
+			manifest: "hosts: []\n",
+			expected: []Violation{{Path: "hosts", Message: "expected at least 1 item(s), found 0"}},
+		},
+		{
+			name:     "max-items",
+			manifest: "hosts: [ a, b, c ]\n",
+			expected: []Violation{{Path: "hosts", Message: "expected at most 2 item(s), found 3"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			documents, e := manifests.Decode("test.yaml", strings.NewReader(test.manifest))
+			if e != nil {
+				t.Fatalf("unable to decode manifest: %v", e)
+			}
+
+			violations := specification.Validate(documents[0].Node, schema)
+			if len(violations) != len(test.expected) {
+				t.Fatalf("Validate() = %v, expected %v", violations, test.expected)
+			}
+
+			for index := range violations {
+				if violations[index] != test.expected[index] {
+					t.Errorf("Validate()[%d] = %+v, expected %+v", index, violations[index], test.expected[index])
+				}
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	properties := map[string]*Schema{"replicas": {}, "selector": {}, "template": {}}
+
+	tests := map[string]string{
+		"replica":  "replicas",
+		"Selector": "selector",
+		"strategy": "",
+	}
+
+	for field, expected := range tests {
+		if suggestion := suggest(field, properties); suggestion != expected {
+			t.Errorf("suggest(%q) = %q, expected %q", field, suggestion, expected)
+		}
+	}
+}
@@ -6,8 +6,10 @@ import "errors"
 type Type string
 
 const (
-	JSON Type = "json"
-	YAML Type = "yaml"
+	JSON  Type = "json"
+	YAML  Type = "yaml"
+	Text  Type = "text"
+	Table Type = "table"
 )
 
 // String is used both by fmt.Print and by Cobra in help text
@@ -18,15 +20,15 @@ func (o *Type) String() string {
 // Set must have pointer receiver so it doesn't change the value of a copy
 func (o *Type) Set(v string) error {
 	switch v {
-	case "json", "yaml":
+	case "json", "yaml", "text", "table":
 		*o = Type(v)
 		return nil
 	default:
-		return errors.New("must be one of \"json\" or \"yaml\"")
+		return errors.New("must be one of \"json\", \"yaml\", \"text\" or \"table\"")
 	}
 }
 
 // Type is only used in help text
 func (o *Type) Type() string {
-	return "[\"json\"|\"yaml\"]"
+	return "[\"json\"|\"yaml\"|\"text\"|\"table\"]"
 }
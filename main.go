@@ -80,7 +80,7 @@ func main() {
 
 	root.PersistentFlags().VarP(&logging, "verbosity", "v", "sets and configures logging verbosity")
 
-	commands.Execute(root)
+	os.Exit(commands.Execute(root))
 }
 
 func init() {